@@ -0,0 +1,366 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: notifier/v1/notifier.proto
+
+// gRPC API сервиса отложенных уведомлений. Повторяет REST API (/notify, /admin/notify):
+// те же правила валидации и те же статусы.
+
+package notifierv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	NotifierService_CreateNotify_FullMethodName     = "/notifier.v1.NotifierService/CreateNotify"
+	NotifierService_GetNotify_FullMethodName        = "/notifier.v1.NotifierService/GetNotify"
+	NotifierService_ListNotifies_FullMethodName     = "/notifier.v1.NotifierService/ListNotifies"
+	NotifierService_CancelNotify_FullMethodName     = "/notifier.v1.NotifierService/CancelNotify"
+	NotifierService_RescheduleNotify_FullMethodName = "/notifier.v1.NotifierService/RescheduleNotify"
+	NotifierService_WatchNotify_FullMethodName      = "/notifier.v1.NotifierService/WatchNotify"
+	NotifierService_ListDeliveries_FullMethodName   = "/notifier.v1.NotifierService/ListDeliveries"
+)
+
+// NotifierServiceClient is the client API for NotifierService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NotifierServiceClient interface {
+	CreateNotify(ctx context.Context, in *CreateNotifyRequest, opts ...grpc.CallOption) (*Notify, error)
+	GetNotify(ctx context.Context, in *GetNotifyRequest, opts ...grpc.CallOption) (*Notify, error)
+	ListNotifies(ctx context.Context, in *ListNotifiesRequest, opts ...grpc.CallOption) (*ListNotifiesResponse, error)
+	// CancelNotify отменяет запланированное уведомление; для других статусов — FAILED_PRECONDITION.
+	CancelNotify(ctx context.Context, in *CancelNotifyRequest, opts ...grpc.CallOption) (*Notify, error)
+	// RescheduleNotify переносит уведомление; failed, skipped и canceled снова становятся scheduled.
+	RescheduleNotify(ctx context.Context, in *RescheduleNotifyRequest, opts ...grpc.CallOption) (*Notify, error)
+	// WatchNotify сразу присылает текущее состояние, затем каждое изменение статуса.
+	// Поток завершается, когда уведомление отправлено, упало, пропущено, отменено или удалено.
+	WatchNotify(ctx context.Context, in *WatchNotifyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Notify], error)
+	// ListDeliveries возвращает статус отправки каждому получателю; пусто, пока уведомление не отправлялось.
+	ListDeliveries(ctx context.Context, in *ListDeliveriesRequest, opts ...grpc.CallOption) (*ListDeliveriesResponse, error)
+}
+
+type notifierServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNotifierServiceClient(cc grpc.ClientConnInterface) NotifierServiceClient {
+	return &notifierServiceClient{cc}
+}
+
+func (c *notifierServiceClient) CreateNotify(ctx context.Context, in *CreateNotifyRequest, opts ...grpc.CallOption) (*Notify, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Notify)
+	err := c.cc.Invoke(ctx, NotifierService_CreateNotify_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notifierServiceClient) GetNotify(ctx context.Context, in *GetNotifyRequest, opts ...grpc.CallOption) (*Notify, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Notify)
+	err := c.cc.Invoke(ctx, NotifierService_GetNotify_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notifierServiceClient) ListNotifies(ctx context.Context, in *ListNotifiesRequest, opts ...grpc.CallOption) (*ListNotifiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNotifiesResponse)
+	err := c.cc.Invoke(ctx, NotifierService_ListNotifies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notifierServiceClient) CancelNotify(ctx context.Context, in *CancelNotifyRequest, opts ...grpc.CallOption) (*Notify, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Notify)
+	err := c.cc.Invoke(ctx, NotifierService_CancelNotify_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notifierServiceClient) RescheduleNotify(ctx context.Context, in *RescheduleNotifyRequest, opts ...grpc.CallOption) (*Notify, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Notify)
+	err := c.cc.Invoke(ctx, NotifierService_RescheduleNotify_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notifierServiceClient) WatchNotify(ctx context.Context, in *WatchNotifyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Notify], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NotifierService_ServiceDesc.Streams[0], NotifierService_WatchNotify_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchNotifyRequest, Notify]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NotifierService_WatchNotifyClient = grpc.ServerStreamingClient[Notify]
+
+func (c *notifierServiceClient) ListDeliveries(ctx context.Context, in *ListDeliveriesRequest, opts ...grpc.CallOption) (*ListDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeliveriesResponse)
+	err := c.cc.Invoke(ctx, NotifierService_ListDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NotifierServiceServer is the server API for NotifierService service.
+// All implementations must embed UnimplementedNotifierServiceServer
+// for forward compatibility.
+type NotifierServiceServer interface {
+	CreateNotify(context.Context, *CreateNotifyRequest) (*Notify, error)
+	GetNotify(context.Context, *GetNotifyRequest) (*Notify, error)
+	ListNotifies(context.Context, *ListNotifiesRequest) (*ListNotifiesResponse, error)
+	// CancelNotify отменяет запланированное уведомление; для других статусов — FAILED_PRECONDITION.
+	CancelNotify(context.Context, *CancelNotifyRequest) (*Notify, error)
+	// RescheduleNotify переносит уведомление; failed, skipped и canceled снова становятся scheduled.
+	RescheduleNotify(context.Context, *RescheduleNotifyRequest) (*Notify, error)
+	// WatchNotify сразу присылает текущее состояние, затем каждое изменение статуса.
+	// Поток завершается, когда уведомление отправлено, упало, пропущено, отменено или удалено.
+	WatchNotify(*WatchNotifyRequest, grpc.ServerStreamingServer[Notify]) error
+	// ListDeliveries возвращает статус отправки каждому получателю; пусто, пока уведомление не отправлялось.
+	ListDeliveries(context.Context, *ListDeliveriesRequest) (*ListDeliveriesResponse, error)
+	mustEmbedUnimplementedNotifierServiceServer()
+}
+
+// UnimplementedNotifierServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedNotifierServiceServer struct{}
+
+func (UnimplementedNotifierServiceServer) CreateNotify(context.Context, *CreateNotifyRequest) (*Notify, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateNotify not implemented")
+}
+func (UnimplementedNotifierServiceServer) GetNotify(context.Context, *GetNotifyRequest) (*Notify, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotify not implemented")
+}
+func (UnimplementedNotifierServiceServer) ListNotifies(context.Context, *ListNotifiesRequest) (*ListNotifiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNotifies not implemented")
+}
+func (UnimplementedNotifierServiceServer) CancelNotify(context.Context, *CancelNotifyRequest) (*Notify, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelNotify not implemented")
+}
+func (UnimplementedNotifierServiceServer) RescheduleNotify(context.Context, *RescheduleNotifyRequest) (*Notify, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RescheduleNotify not implemented")
+}
+func (UnimplementedNotifierServiceServer) WatchNotify(*WatchNotifyRequest, grpc.ServerStreamingServer[Notify]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchNotify not implemented")
+}
+func (UnimplementedNotifierServiceServer) ListDeliveries(context.Context, *ListDeliveriesRequest) (*ListDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeliveries not implemented")
+}
+func (UnimplementedNotifierServiceServer) mustEmbedUnimplementedNotifierServiceServer() {}
+func (UnimplementedNotifierServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeNotifierServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NotifierServiceServer will
+// result in compilation errors.
+type UnsafeNotifierServiceServer interface {
+	mustEmbedUnimplementedNotifierServiceServer()
+}
+
+func RegisterNotifierServiceServer(s grpc.ServiceRegistrar, srv NotifierServiceServer) {
+	// If the following call pancis, it indicates UnimplementedNotifierServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&NotifierService_ServiceDesc, srv)
+}
+
+func _NotifierService_CreateNotify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNotifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotifierServiceServer).CreateNotify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotifierService_CreateNotify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotifierServiceServer).CreateNotify(ctx, req.(*CreateNotifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotifierService_GetNotify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotifierServiceServer).GetNotify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotifierService_GetNotify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotifierServiceServer).GetNotify(ctx, req.(*GetNotifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotifierService_ListNotifies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotifiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotifierServiceServer).ListNotifies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotifierService_ListNotifies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotifierServiceServer).ListNotifies(ctx, req.(*ListNotifiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotifierService_CancelNotify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelNotifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotifierServiceServer).CancelNotify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotifierService_CancelNotify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotifierServiceServer).CancelNotify(ctx, req.(*CancelNotifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotifierService_RescheduleNotify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RescheduleNotifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotifierServiceServer).RescheduleNotify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotifierService_RescheduleNotify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotifierServiceServer).RescheduleNotify(ctx, req.(*RescheduleNotifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotifierService_WatchNotify_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchNotifyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NotifierServiceServer).WatchNotify(m, &grpc.GenericServerStream[WatchNotifyRequest, Notify]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NotifierService_WatchNotifyServer = grpc.ServerStreamingServer[Notify]
+
+func _NotifierService_ListDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotifierServiceServer).ListDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotifierService_ListDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotifierServiceServer).ListDeliveries(ctx, req.(*ListDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NotifierService_ServiceDesc is the grpc.ServiceDesc for NotifierService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NotifierService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "notifier.v1.NotifierService",
+	HandlerType: (*NotifierServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateNotify",
+			Handler:    _NotifierService_CreateNotify_Handler,
+		},
+		{
+			MethodName: "GetNotify",
+			Handler:    _NotifierService_GetNotify_Handler,
+		},
+		{
+			MethodName: "ListNotifies",
+			Handler:    _NotifierService_ListNotifies_Handler,
+		},
+		{
+			MethodName: "CancelNotify",
+			Handler:    _NotifierService_CancelNotify_Handler,
+		},
+		{
+			MethodName: "RescheduleNotify",
+			Handler:    _NotifierService_RescheduleNotify_Handler,
+		},
+		{
+			MethodName: "ListDeliveries",
+			Handler:    _NotifierService_ListDeliveries_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchNotify",
+			Handler:       _NotifierService_WatchNotify_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "notifier/v1/notifier.proto",
+}
@@ -0,0 +1,45 @@
+// Package openapi встраивает спецификацию HTTP API в бинарники.
+package openapi
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"delayed-notifier/internal/entity"
+)
+
+//go:embed openapi.yaml
+var spec []byte
+
+func init() {
+	// date-time и email проверяются так же, как в обработчиках и entity.Notify.Validate
+	openapi3.DefineStringFormatValidator("date-time", openapi3.NewCallbackValidator(func(s string) error {
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			return errors.New("must be an RFC 3339 date-time")
+		}
+		return nil
+	}))
+	openapi3.DefineStringFormatValidator("email", openapi3.NewCallbackValidator(func(s string) error {
+		if !entity.ValidEmail(s) {
+			return errors.New("invalid email format")
+		}
+		return nil
+	}))
+}
+
+// Load разбирает встроенную спецификацию и проверяет, что она корректна.
+func Load() (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("Load: %w", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("Load: invalid spec: %w", err)
+	}
+	return doc, nil
+}
@@ -12,14 +12,18 @@ import (
 
 	"github.com/go-chi/chi/v5"
 
+	"delayed-notifier/internal/app"
 	"delayed-notifier/internal/config"
-	httpHandlers "delayed-notifier/internal/controller/http"
 	"delayed-notifier/internal/controller/http/middleware"
 	"delayed-notifier/internal/logger"
+	"delayed-notifier/internal/metrics"
+	"delayed-notifier/internal/repository/dlq"
 	"delayed-notifier/internal/repository/email"
+	"delayed-notifier/internal/repository/memory"
 	"delayed-notifier/internal/repository/postgres"
 	"delayed-notifier/internal/repository/producer"
 	"delayed-notifier/internal/repository/redis"
+	"delayed-notifier/internal/secret"
 	"delayed-notifier/internal/service"
 )
 
@@ -28,24 +32,39 @@ func main() {
 	defer stop()
 
 	// Config
-	cfg, err := config.New()
+	args := os.Args[1:]
+	if len(args) >= 2 && args[0] == "config" && args[1] == "print" {
+		if err := config.Print(os.Stdout, args[2:]); err != nil {
+			log.Fatal("config error: ", err) //nolint:gocritic
+		}
+		return
+	}
+	cfg, err := config.Load(args)
 	if err != nil {
 		log.Fatal("config error: ", err) //nolint:gocritic
 	}
 
 	// Logger
-	logg := logger.New(cfg.Logger.Level)
+	logg := logger.New(cfg.Logger.Level, cfg.Logger.Format, cfg.Logger.Redact)
 	logg.Info("logger initialized")
 
+	// Секреты из *_FILE перечитываются при ротации без перезапуска
+	secrets := secret.NewWatcher(cfg.Secrets.ReloadInterval, logg)
+	dbPassword := secrets.Watch(cfg.Database.PasswordFile, cfg.Database.Password)
+	redisPassword := secrets.Watch(cfg.Redis.PasswordFile, cfg.Redis.Password)
+	mailPassword := secrets.Watch(cfg.Mail.PasswordFile, cfg.Mail.Password)
+	adminToken := secrets.Watch(cfg.Admin.TokenFile, cfg.Admin.Token)
+	go secrets.Start(ctx)
+
 	// DB connection
-	db, err := postgres.NewDbConnection(cfg)
+	db, err := postgres.NewDbConnection(cfg, dbPassword)
 	if err != nil {
 		logg.Error("db connection error", slog.Any("error", err))
 		os.Exit(1)
 	}
 	logg.Info("db connection initialized")
 
-	redisClient, err := redis.NewRedisClient(cfg)
+	redisClient, err := redis.NewRedisClient(cfg, redisPassword)
 	if err != nil {
 		logg.Error("redis connection error", slog.Any("error", err))
 		os.Exit(1)
@@ -53,7 +72,7 @@ func main() {
 	logg.Info("redis connection initialized")
 
 	// Kafka producer
-	producer := producer.NewNotifyProducer(cfg.Kafka.Host+":"+cfg.Kafka.Port, cfg.Kafka.Topic, logg)
+	producer := producer.NewNotifyProducer(cfg.Kafka.Broker(), cfg.Kafka.Topic, logg)
 	if err != nil {
 		logg.Error("failed to initialize notify producer", slog.Any("error", err))
 		os.Exit(1)
@@ -62,22 +81,67 @@ func main() {
 
 	// Repository and service
 	notifyRepo := postgres.NewNotifyDBRepository(db.Pool)
-	cacheRepo := redis.NewNotifyRedisRepository(redisClient, logg)
-	notifierRepo := email.NewMailer(cfg.Mail)
-	notifyService := service.NewNotifyService(notifyRepo, cacheRepo, producer, notifierRepo, logg)
+	registry := metrics.NewRegistry()
+	var cacheRepo service.NotifyCacheRepository = redis.NewNotifyRedisRepository(redisClient, cfg.Cache, logg)
+	if cfg.Cache.LocalSize > 0 {
+		localCache := memory.NewNotifyLRUCache(cacheRepo, cfg.Cache.LocalSize, cfg.Cache.LocalTTL)
+		cacheRepo = localCache
+		app.RegisterLocalCacheMetrics(registry, localCache)
+	}
+	// API письма не отправляет, поэтому ключи DKIM ему не нужны
+	notifierRepo := email.NewMailer(cfg.Mail, mailPassword, nil)
+	attachmentStore, err := app.AttachmentStore(cfg)
+	if err != nil {
+		logg.Error("attachment store error", slog.Any("error", err))
+		os.Exit(1)
+	}
+	senderOpts, err := app.SenderOptions(cfg)
+	if err != nil {
+		logg.Error("mail identities error", slog.Any("error", err))
+		os.Exit(1)
+	}
+	serviceOpts := append(app.AttachmentOptions(cfg, attachmentStore), senderOpts...)
+	serviceOpts = append(serviceOpts, service.WithSuppressions(notifyRepo))
+	var (
+		adminOpts []service.AdminOption
+		dlqOpts   []service.DLQOption
+	)
+	if cfg.Scheduler.Backend == config.SchedulerBackendRedis {
+		scheduleRepo := redis.NewNotifyScheduleRepository(redisClient)
+		serviceOpts = append(serviceOpts, service.WithScheduleRepository(scheduleRepo))
+		adminOpts = append(adminOpts, service.WithAdminScheduleRepository(scheduleRepo))
+		dlqOpts = append(dlqOpts, service.WithDLQScheduleRepository(scheduleRepo))
+	}
+	notifyService := service.NewNotifyService(notifyRepo, cacheRepo, producer, notifierRepo, logg, serviceOpts...)
+	app.RegisterCacheMetrics(registry, notifyService)
+	dlqRepo := dlq.NewNotifyDLQRepository(cfg.Kafka.Broker(), cfg.Kafka.DLQTopic(), logg)
+	dlqService := service.NewDLQService(dlqRepo, notifyRepo, cacheRepo, producer, logg, dlqOpts...)
+	adminService := service.NewAdminService(notifyRepo, notifyRepo, cacheRepo, producer, logg, adminOpts...)
 
 	// Router and middleware
 	r := chi.NewRouter()
+	r.Use(middleware.RequestIDMiddleware)
 	r.Use(middleware.LoggingMiddleware(logg))
-
-	notifyHandler := httpHandlers.NewNotifyHandler(notifyService, logg)
-	r.Route("/notify", func(r chi.Router) {
-		r.Post("/", notifyHandler.CreateNotify)
-		r.Route("/{notifyID}", func(r chi.Router) {
-			r.Get("/", notifyHandler.GetNotify)
-			r.Delete("/", notifyHandler.DeleteNotify)
+	r.Use(middleware.MaxBodySize(app.MaxBodySize(cfg)))
+	if err := app.OpenAPI(r, logg); err != nil {
+		logg.Error("openapi spec error", slog.Any("error", err))
+		os.Exit(1)
+	}
+	app.ProblemHandlers(r, logg)
+	r.Handle("/metrics", registry)
+
+	r.Route(app.APIVersion, func(r chi.Router) {
+		app.NotifyRoutes(r, notifyService, logg)
+		app.RecipientListRoutes(r, service.NewRecipientListService(notifyRepo), logg)
+		app.SuppressionRoutes(r, service.NewSuppressionService(notifyRepo), logg)
+		// Административный API доступен только с токеном ADMIN_TOKEN
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.AdminToken(adminToken, logg))
+			app.DLQRoutes(r, dlqService, logg)
+			app.AdminRoutes(r, adminService, logg)
 		})
 	})
+	app.LegacyRedirects(r)
 
 	// HTTP server
 	server := &http.Server{
@@ -96,10 +160,20 @@ func main() {
 		}
 	}()
 
+	// gRPC API на отдельном порту, поверх тех же сервисов
+	var grpcDone <-chan struct{}
+	if cfg.GRPC.Enabled {
+		grpcDone, err = app.ServeGRPC(ctx, cfg, notifyService, adminService, logg)
+		if err != nil {
+			logg.Error("grpc server error", slog.Any("error", err))
+			os.Exit(1)
+		}
+	}
+
 	<-ctx.Done()
 	logg.Info("shutdown signal received")
 
-	shutdownCtx, cancel := context.WithTimeout(ctx, time.Duration(cfg.Server.ShutdownTimeout)*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 
 	if err := server.Shutdown(shutdownCtx); err != nil {
@@ -107,4 +181,7 @@ func main() {
 	} else {
 		logg.Info("server gracefully shutdown")
 	}
+	if grpcDone != nil {
+		<-grpcDone
+	}
 }
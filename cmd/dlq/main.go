@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"delayed-notifier/internal/config"
+	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/logger"
+	"delayed-notifier/internal/repository/dlq"
+	"delayed-notifier/internal/repository/postgres"
+	"delayed-notifier/internal/repository/producer"
+	"delayed-notifier/internal/repository/redis"
+	"delayed-notifier/internal/secret"
+	"delayed-notifier/internal/service"
+)
+
+const usage = `usage:
+  dlq list   [-reason REASON] [-limit N] [-json]
+  dlq replay (-all | -entry PARTITION:OFFSET ...) [-reason REASON] [-reschedule] [-dry-run] [-actor NAME] [-json]
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		_, _ = io.WriteString(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var err error
+	switch os.Args[1] {
+	case "list":
+		err = runList(ctx, os.Args[2:])
+	case "replay":
+		err = runReplay(ctx, os.Args[2:])
+	default:
+		_, _ = io.WriteString(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal("dlq: ", err) //nolint:gocritic
+	}
+}
+
+func runList(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	reason := fs.String("reason", "", "filter by DLQ reason (invalid_json, processing_failed)")
+	limit := fs.Int("limit", 0, "maximum number of entries, 0 means no limit")
+	asJSON := fs.Bool("json", false, "print entries as JSON")
+	_ = fs.Parse(args)
+
+	dlqService, err := newDLQService()
+	if err != nil {
+		return err
+	}
+
+	entries, err := dlqService.ListDLQ(ctx, entity.DLQFilter{Reason: *reason, Limit: *limit})
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return writeJSON(os.Stdout, entries)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "PARTITION\tOFFSET\tNOTIFY ID\tREASON\tTIMESTAMP\tERROR")
+	for _, e := range entries {
+		_, _ = fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\t%s\n",
+			e.Partition, e.Offset, e.NotifyID, e.Reason, e.Timestamp.Format(time.RFC3339), e.ErrorMessage)
+	}
+	return tw.Flush()
+}
+
+func runReplay(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	var req entity.DLQReplayRequest
+	fs.BoolVar(&req.All, "all", false, "replay all entries (respecting -reason)")
+	fs.StringVar(&req.Reason, "reason", "", "only replay entries with this reason")
+	fs.BoolVar(&req.DryRun, "dry-run", false, "show what would be replayed without changing anything")
+	fs.BoolVar(&req.Reschedule, "reschedule", false, "reset status to scheduled instead of enqueueing immediately")
+	fs.StringVar(&req.Actor, "actor", os.Getenv("USER"), "name recorded in the replay audit log")
+	fs.Func("entry", "entry to replay as PARTITION:OFFSET (repeatable)", func(v string) error {
+		pos, err := parsePosition(v)
+		if err != nil {
+			return err
+		}
+		req.Entries = append(req.Entries, pos)
+		return nil
+	})
+	asJSON := fs.Bool("json", false, "print results as JSON")
+	_ = fs.Parse(args)
+
+	if !req.All && len(req.Entries) == 0 {
+		return errors.New("either -all or -entry is required")
+	}
+
+	dlqService, err := newDLQService()
+	if err != nil {
+		return err
+	}
+
+	results, err := dlqService.ReplayDLQ(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return writeJSON(os.Stdout, results)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "PARTITION\tOFFSET\tNOTIFY ID\tACTION\tSTATUS\tERROR")
+	for _, r := range results {
+		_, _ = fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\t%s\n",
+			r.Partition, r.Offset, r.NotifyID, r.Action, r.Status, r.Error)
+	}
+	return tw.Flush()
+}
+
+func newDLQService() (*service.DLQService, error) {
+	cfg, err := config.New()
+	if err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
+	// Логи (включая аудит replay) пишем в stderr, чтобы не смешивать с выводом команды
+	logg := logger.NewWithWriter(os.Stderr, cfg.Logger.Level, cfg.Logger.Format, cfg.Logger.Redact)
+
+	db, err := postgres.NewDbConnection(cfg, secret.Static(cfg.Database.Password))
+	if err != nil {
+		return nil, fmt.Errorf("db connection error: %w", err)
+	}
+
+	redisClient, err := redis.NewRedisClient(cfg, secret.Static(cfg.Redis.Password))
+	if err != nil {
+		return nil, fmt.Errorf("redis connection error: %w", err)
+	}
+
+	notifyProducer := producer.NewNotifyProducer(cfg.Kafka.Broker(), cfg.Kafka.Topic, logg)
+	notifyRepo := postgres.NewNotifyDBRepository(db.Pool)
+	cacheRepo := redis.NewNotifyRedisRepository(redisClient, cfg.Cache, logg)
+	dlqRepo := dlq.NewNotifyDLQRepository(cfg.Kafka.Broker(), cfg.Kafka.DLQTopic(), logg)
+
+	var opts []service.DLQOption
+	if cfg.Scheduler.Backend == config.SchedulerBackendRedis {
+		opts = append(opts, service.WithDLQScheduleRepository(redis.NewNotifyScheduleRepository(redisClient)))
+	}
+	return service.NewDLQService(dlqRepo, notifyRepo, cacheRepo, notifyProducer, logg, opts...), nil
+}
+
+func parsePosition(v string) (entity.DLQPosition, error) {
+	partition, offset, ok := strings.Cut(v, ":")
+	if !ok {
+		return entity.DLQPosition{}, fmt.Errorf("invalid entry %q, expected PARTITION:OFFSET", v)
+	}
+	p, err := strconv.Atoi(partition)
+	if err != nil {
+		return entity.DLQPosition{}, fmt.Errorf("invalid partition in %q: %w", v, err)
+	}
+	o, err := strconv.ParseInt(offset, 10, 64)
+	if err != nil {
+		return entity.DLQPosition{}, fmt.Errorf("invalid offset in %q: %w", v, err)
+	}
+	return entity.DLQPosition{Partition: p, Offset: o}, nil
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"delayed-notifier/internal/app"
+	"delayed-notifier/internal/config"
+	"delayed-notifier/internal/controller"
+	"delayed-notifier/internal/controller/consumer"
+	httpHandlers "delayed-notifier/internal/controller/http"
+	"delayed-notifier/internal/controller/http/middleware"
+	"delayed-notifier/internal/leader"
+	"delayed-notifier/internal/logger"
+	"delayed-notifier/internal/metrics"
+	"delayed-notifier/internal/repository/dlq"
+	"delayed-notifier/internal/repository/email"
+	"delayed-notifier/internal/repository/memory"
+	"delayed-notifier/internal/repository/postgres"
+	"delayed-notifier/internal/repository/producer"
+	"delayed-notifier/internal/repository/redis"
+	"delayed-notifier/internal/secret"
+	"delayed-notifier/internal/service"
+)
+
+// notifier запускает API, планировщик и консьюмер в одном процессе. Каждый
+// бэкенд можно заменить реализацией в памяти, чтобы поднять сервис локально
+// или в тестах без Postgres, Redis, Kafka и SMTP.
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Config
+	args := os.Args[1:]
+	if len(args) >= 2 && args[0] == "config" && args[1] == "print" {
+		if err := config.Print(os.Stdout, args[2:]); err != nil {
+			log.Fatal("config error: ", err) //nolint:gocritic
+		}
+		return
+	}
+	cfg, err := config.Load(args)
+	if err != nil {
+		log.Fatal("config error: ", err) //nolint:gocritic
+	}
+	nc := cfg.Notifier
+
+	// Logger
+	logg := logger.New(cfg.Logger.Level, cfg.Logger.Format, cfg.Logger.Redact)
+	logg.Info("logger initialized",
+		slog.String("roles", nc.Roles),
+		slog.String("db", nc.DB),
+		slog.String("cache", nc.Cache),
+		slog.String("queue", nc.Queue),
+		slog.String("mailer", nc.Mailer),
+	)
+
+	// Секреты из *_FILE перечитываются при ротации без перезапуска
+	secrets := secret.NewWatcher(cfg.Secrets.ReloadInterval, logg)
+	dbPassword := secrets.Watch(cfg.Database.PasswordFile, cfg.Database.Password)
+	redisPassword := secrets.Watch(cfg.Redis.PasswordFile, cfg.Redis.Password)
+	mailPassword := secrets.Watch(cfg.Mail.PasswordFile, cfg.Mail.Password)
+	adminToken := secrets.Watch(cfg.Admin.TokenFile, cfg.Admin.Token)
+	go secrets.Start(ctx)
+
+	registry := metrics.NewRegistry()
+
+	// DB
+	var (
+		db           *postgres.DB
+		notifyRepo   service.NotifyDBRepository
+		adminRepo    service.NotifyAdminRepository
+		listRepo     service.RecipientListRepository
+		suppressRepo service.SuppressionRepository
+		newListener  func() app.EventSource
+	)
+	switch nc.DB {
+	case config.BackendMemory:
+		memoryDB := memory.NewNotifyDBRepository()
+		notifyRepo, adminRepo, listRepo, suppressRepo = memoryDB, memoryDB, memoryDB, memoryDB
+		newListener = func() app.EventSource { return memoryDB.NewListener() }
+	default:
+		db, err = postgres.NewDbConnection(cfg, dbPassword)
+		if err != nil {
+			logg.Error("db connection error", slog.Any("error", err))
+			os.Exit(1)
+		}
+		logg.Info("db connection initialized")
+		if nc.Migrate {
+			if err := postgres.Migrate(ctx, db.Pool, logg); err != nil {
+				logg.Error("migration error", slog.Any("error", err))
+				os.Exit(1)
+			}
+			logg.Info("migrations applied")
+		}
+		postgresDB := postgres.NewNotifyDBRepository(db.Pool)
+		notifyRepo, adminRepo, listRepo, suppressRepo = postgresDB, postgresDB, postgresDB, postgresDB
+		newListener = func() app.EventSource { return postgres.NewNotifyListener(db.Pool, logg) }
+	}
+
+	// Redis нужен для кэша и для расписания в ZSET
+	var redisClient *redis.RedisClient
+	if nc.Cache == config.BackendRedis || cfg.Scheduler.Backend == config.SchedulerBackendRedis {
+		redisClient, err = redis.NewRedisClient(cfg, redisPassword)
+		if err != nil {
+			logg.Error("redis connection error", slog.Any("error", err))
+			os.Exit(1)
+		}
+		logg.Info("redis connection initialized")
+	}
+
+	var cacheRepo service.NotifyCacheRepository
+	switch nc.Cache {
+	case config.BackendMemory:
+		cacheRepo = memory.NewNotifyCacheRepository(cfg.Cache)
+	default:
+		cacheRepo = redis.NewNotifyRedisRepository(redisClient, cfg.Cache, logg)
+		if cfg.Cache.LocalSize > 0 {
+			localCache := memory.NewNotifyLRUCache(cacheRepo, cfg.Cache.LocalSize, cfg.Cache.LocalTTL)
+			cacheRepo = localCache
+			app.RegisterLocalCacheMetrics(registry, localCache)
+		}
+	}
+
+	// Queue
+	var (
+		notifyProducer service.NotifyProducer
+		queue          *memory.NotifyQueue
+	)
+	switch nc.Queue {
+	case config.BackendMemory:
+		queue = memory.NewNotifyQueue(nc.QueueSize)
+		notifyProducer = queue
+	default:
+		notifyProducer = producer.NewNotifyProducer(cfg.Kafka.Broker(), cfg.Kafka.Topic, logg)
+		logg.Info("notify producer initialized")
+	}
+
+	// Mailer
+	var notifierRepo service.Notifier
+	switch nc.Mailer {
+	case config.MailerLog:
+		notifierRepo = email.NewLogMailer(logg)
+	case config.MailerFile:
+		notifierRepo = email.NewFileMailer(nc.MailFile)
+	default:
+		mailer, err := app.Mailer(cfg, mailPassword)
+		if err != nil {
+			logg.Error("mailer error", slog.Any("error", err))
+			os.Exit(1)
+		}
+		defer mailer.Close()
+		app.RegisterMailMetrics(registry, mailer)
+		notifierRepo = mailer
+	}
+
+	// В памяти вложения живут вместе с уведомлениями, если каталог не задан явно
+	var attachmentStore service.AttachmentStore
+	if nc.DB == config.BackendMemory && cfg.Attachments.Dir == "" {
+		attachmentStore = memory.NewAttachmentStore()
+	} else if attachmentStore, err = app.AttachmentStore(cfg); err != nil {
+		logg.Error("attachment store error", slog.Any("error", err))
+		os.Exit(1)
+	}
+	serviceOpts := append(app.ServiceOptions(cfg), app.AttachmentOptions(cfg, attachmentStore)...)
+	senderOpts, err := app.SenderOptions(cfg)
+	if err != nil {
+		logg.Error("mail identities error", slog.Any("error", err))
+		os.Exit(1)
+	}
+	serviceOpts = append(serviceOpts, senderOpts...)
+	serviceOpts = append(serviceOpts, service.WithSuppressions(suppressRepo))
+	var (
+		adminOpts []service.AdminOption
+		dlqOpts   []service.DLQOption
+	)
+	if cfg.Scheduler.Backend == config.SchedulerBackendRedis {
+		scheduleRepo := redis.NewNotifyScheduleRepository(redisClient)
+		serviceOpts = append(serviceOpts, service.WithScheduleRepository(scheduleRepo))
+		adminOpts = append(adminOpts, service.WithAdminScheduleRepository(scheduleRepo))
+		dlqOpts = append(dlqOpts, service.WithDLQScheduleRepository(scheduleRepo))
+	}
+	notifyService := service.NewNotifyService(notifyRepo, cacheRepo, notifyProducer, notifierRepo, logg, serviceOpts...)
+	app.RegisterCacheMetrics(registry, notifyService)
+
+	var wg sync.WaitGroup
+
+	// Scheduler: выборы лидера возможны только через Postgres
+	var leaderStatus controller.LeaderStatus = leader.Standalone{}
+	if nc.HasRole(config.RoleScheduler) {
+		runScheduling := func(ctx context.Context) {
+			app.RunScheduling(ctx, cfg, newListener, notifyService, logg)
+		}
+		wg.Add(1)
+		if db != nil && cfg.Leader.Enabled {
+			elector := leader.NewElector(postgres.NewAdvisoryLock(db.Pool, cfg.Leader.LockKey), leader.Config{
+				RetryInterval: cfg.Leader.RetryInterval,
+				CheckInterval: cfg.Leader.CheckInterval,
+			}, logg)
+			leaderStatus = elector
+			registry.Counter("notifier_worker_leader_transitions_total", "Number of leadership changes of this worker.",
+				func() float64 { return float64(elector.Transitions()) })
+			go func() {
+				defer wg.Done()
+				elector.Run(ctx, runScheduling)
+			}()
+		} else {
+			go func() {
+				defer wg.Done()
+				runScheduling(ctx)
+			}()
+		}
+		registry.Gauge("notifier_worker_leader", "Whether this worker runs the scheduler (1) or not (0).",
+			func() float64 { return metrics.Bool(leaderStatus.IsLeader()) })
+	}
+
+	// Consumer
+	if nc.HasRole(config.RoleConsumer) {
+		if queue != nil {
+			localConsumer := consumer.NewLocalConsumer(queue.Receive(), cfg.Kafka.RetryDelays, notifyService, logg)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				localConsumer.Start(ctx)
+			}()
+		} else {
+			// Отдельный консьюмер на основной топик и на каждую ступень ретраев
+			pipeline := consumer.NewPipeline(cfg.Kafka.Topic, cfg.Kafka.RetryDelays)
+			for stage := 0; stage < pipeline.Stages(); stage++ {
+				kafkaConsumer := consumer.NewOrderConsumer(cfg.Kafka.Broker(), pipeline, stage, notifyService, cfg.Kafka.DLQRedact, logg)
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					kafkaConsumer.Start(ctx)
+				}()
+			}
+		}
+	}
+
+	// Router and middleware
+	r := chi.NewRouter()
+	r.Use(middleware.RequestIDMiddleware)
+	r.Use(middleware.LoggingMiddleware(logg))
+	r.Use(middleware.MaxBodySize(app.MaxBodySize(cfg)))
+	if err := app.OpenAPI(r, logg); err != nil {
+		logg.Error("openapi spec error", slog.Any("error", err))
+		os.Exit(1)
+	}
+	app.ProblemHandlers(r, logg)
+	r.Get("/health", httpHandlers.NewHealthHandler(leaderStatus, logg).Health)
+	r.Handle("/metrics", registry)
+
+	if nc.HasRole(config.RoleAPI) {
+		adminService := service.NewAdminService(adminRepo, notifyRepo, cacheRepo, notifyProducer, logg, adminOpts...)
+		r.Route(app.APIVersion, func(r chi.Router) {
+			app.NotifyRoutes(r, notifyService, logg)
+			app.RecipientListRoutes(r, service.NewRecipientListService(listRepo), logg)
+			app.SuppressionRoutes(r, service.NewSuppressionService(suppressRepo), logg)
+			// Административный API доступен только с токеном ADMIN_TOKEN
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.AdminToken(adminToken, logg))
+				app.AdminRoutes(r, adminService, logg)
+				// DLQ есть только у Kafka
+				if queue == nil {
+					dlqRepo := dlq.NewNotifyDLQRepository(cfg.Kafka.Broker(), cfg.Kafka.DLQTopic(), logg)
+					app.DLQRoutes(r, service.NewDLQService(dlqRepo, notifyRepo, cacheRepo, notifyProducer, logg, dlqOpts...), logg)
+				}
+			})
+		})
+		app.LegacyRedirects(r)
+		if cfg.GRPC.Enabled {
+			grpcDone, err := app.ServeGRPC(ctx, cfg, notifyService, adminService, logg)
+			if err != nil {
+				logg.Error("grpc server error", slog.Any("error", err))
+				os.Exit(1)
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-grpcDone
+			}()
+		}
+	}
+
+	// HTTP server
+	server := &http.Server{
+		Addr:         ":" + cfg.Server.Port,
+		Handler:      r,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+		IdleTimeout:  10 * time.Second,
+	}
+
+	logg.Info("server started", slog.String("addr", server.Addr))
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logg.Error("server error", slog.Any("error", err))
+			os.Exit(1)
+		}
+	}()
+
+	<-ctx.Done()
+	logg.Info("shutdown signal received")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logg.Error("server shutdown failed", slog.Any("error", err))
+	} else {
+		logg.Info("server gracefully shutdown")
+	}
+	wg.Wait()
+}
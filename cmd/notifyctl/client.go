@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"delayed-notifier/internal/controller/http/problem"
+	"delayed-notifier/internal/entity"
+)
+
+const apiVersion = "/v1"
+
+// apiClient реализует controller.AdminService поверх admin API сервиса.
+type apiClient struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+func newAPIClient(baseURL, token string) *apiClient {
+	return &apiClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c *apiClient) ListNotifies(ctx context.Context, filter entity.NotifyFilter) ([]entity.Notify, error) {
+	q := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	set("status", filter.Status)
+	set("email", filter.Email)
+	set("error", filter.Error)
+	if !filter.From.IsZero() {
+		q.Set("from", filter.From.Format(time.RFC3339))
+	}
+	if !filter.To.IsZero() {
+		q.Set("to", filter.To.Format(time.RFC3339))
+	}
+	if filter.Limit > 0 {
+		q.Set("limit", strconv.Itoa(filter.Limit))
+	}
+
+	var notifies []entity.Notify
+	err := c.do(ctx, http.MethodGet, "/admin/notify?"+q.Encode(), nil, &notifies)
+	return notifies, err
+}
+
+func (c *apiClient) GetNotifyDetails(ctx context.Context, notifyID string) (entity.NotifyDetails, error) {
+	var details entity.NotifyDetails
+	err := c.do(ctx, http.MethodGet, "/admin/notify/"+url.PathEscape(notifyID), nil, &details)
+	return details, err
+}
+
+func (c *apiClient) GetNotifyStats(ctx context.Context) (entity.NotifyStats, error) {
+	var stats entity.NotifyStats
+	err := c.do(ctx, http.MethodGet, "/admin/stats", nil, &stats)
+	return stats, err
+}
+
+func (c *apiClient) CancelNotify(ctx context.Context, notifyID string, req entity.AdminActionRequest) (entity.AdminResult, error) {
+	return c.action(ctx, notifyID, "cancel", req)
+}
+
+func (c *apiClient) RescheduleNotify(ctx context.Context, notifyID string, req entity.AdminActionRequest) (entity.AdminResult, error) {
+	return c.action(ctx, notifyID, "reschedule", req)
+}
+
+func (c *apiClient) ResendNotify(ctx context.Context, notifyID string, req entity.AdminActionRequest) (entity.AdminResult, error) {
+	return c.action(ctx, notifyID, "resend", req)
+}
+
+func (c *apiClient) RequeueFailed(ctx context.Context, req entity.RequeueRequest) ([]entity.AdminResult, error) {
+	var results []entity.AdminResult
+	err := c.do(ctx, http.MethodPost, "/admin/notify/requeue", req, &results)
+	return results, err
+}
+
+func (c *apiClient) action(ctx context.Context, notifyID, action string, req entity.AdminActionRequest) (entity.AdminResult, error) {
+	var result entity.AdminResult
+	err := c.do(ctx, http.MethodPost, "/admin/notify/"+url.PathEscape(notifyID)+"/"+action, req, &result)
+	return result, err
+}
+
+func (c *apiClient) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+apiVersion+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var p problem.Problem
+		_ = json.NewDecoder(resp.Body).Decode(&p)
+		if p.Code == problem.CodeNotifyNotFound {
+			return entity.ErrNotifyNotFound
+		}
+		detail := p.Detail
+		for _, e := range p.Errors {
+			detail += "; " + e.Field + ": " + e.Message
+		}
+		return fmt.Errorf("api %s %s: %d %s: %s", method, path, resp.StatusCode, p.Code, detail)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,407 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"delayed-notifier/internal/config"
+	"delayed-notifier/internal/controller"
+	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/logger"
+	"delayed-notifier/internal/repository/postgres"
+	"delayed-notifier/internal/repository/producer"
+	"delayed-notifier/internal/repository/redis"
+	"delayed-notifier/internal/secret"
+	"delayed-notifier/internal/service"
+)
+
+const usage = `usage:
+  notifyctl list       [-status S] [-email E] [-from T] [-to T] [-error TEXT] [-limit N]
+  notifyctl show       ID
+  notifyctl cancel     ID
+  notifyctl reschedule (-at T | -in DURATION) ID
+  notifyctl resend     ID
+  notifyctl requeue    [-from T] [-to T] [-error TEXT] [-limit N] [-dry-run]
+  notifyctl stats
+
+common flags: [-api URL] [-json] [-actor NAME]
+  -api (or NOTIFYCTL_API) talks to the admin API, otherwise PostgreSQL is used directly;
+  the API token (ADMIN_TOKEN of the service) is read from NOTIFYCTL_TOKEN.
+  Times are RFC 3339, e.g. 2025-08-20T10:00:00Z; -from/-to filter send_at.
+`
+
+// options — флаги, общие для всех команд
+type options struct {
+	api    string
+	json   bool
+	actor  string
+	filter entity.NotifyFilter
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		_, _ = io.WriteString(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	commands := map[string]func(context.Context, []string) error{
+		"list":       runList,
+		"show":       runShow,
+		"cancel":     runCancel,
+		"reschedule": runReschedule,
+		"resend":     runResend,
+		"requeue":    runRequeue,
+		"stats":      runStats,
+	}
+	run, ok := commands[os.Args[1]]
+	if !ok {
+		_, _ = io.WriteString(os.Stderr, usage)
+		os.Exit(2)
+	}
+	if err := run(ctx, os.Args[2:]); err != nil {
+		log.Fatal("notifyctl: ", err) //nolint:gocritic
+	}
+}
+
+func newFlagSet(name string, opts *options) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.StringVar(&opts.api, "api", os.Getenv("NOTIFYCTL_API"), "admin API base URL, e.g. http://localhost:8080")
+	fs.BoolVar(&opts.json, "json", false, "print output as JSON")
+	fs.StringVar(&opts.actor, "actor", os.Getenv("USER"), "name recorded in the notify history and audit log")
+	fs.Usage = func() { _, _ = io.WriteString(os.Stderr, usage) }
+	return fs
+}
+
+func filterFlags(fs *flag.FlagSet, filter *entity.NotifyFilter) {
+	fs.StringVar(&filter.Error, "error", "", "only notifies whose last send error contains TEXT")
+	fs.IntVar(&filter.Limit, "limit", 100, "maximum number of notifies, 0 means no limit")
+	fs.Func("from", "only notifies with send_at >= T", timeFlag(&filter.From))
+	fs.Func("to", "only notifies with send_at < T", timeFlag(&filter.To))
+}
+
+func runList(ctx context.Context, args []string) error {
+	var opts options
+	fs := newFlagSet("list", &opts)
+	fs.StringVar(&opts.filter.Status, "status", "", "filter by status")
+	fs.StringVar(&opts.filter.Email, "email", "", "filter by recipient email")
+	filterFlags(fs, &opts.filter)
+	_ = fs.Parse(args)
+
+	admin, err := newAdminService(opts.api)
+	if err != nil {
+		return err
+	}
+	notifies, err := admin.ListNotifies(ctx, opts.filter)
+	if err != nil {
+		return err
+	}
+	if opts.json {
+		return writeJSON(os.Stdout, notifies)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tSTATUS\tSEND AT\tEMAIL\tVERSION")
+	for _, n := range notifies {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\n", n.ID, n.Status, n.SendAt.Format(time.RFC3339), n.Email, n.Version)
+	}
+	return tw.Flush()
+}
+
+func runShow(ctx context.Context, args []string) error {
+	var opts options
+	fs := newFlagSet("show", &opts)
+	_ = fs.Parse(args)
+	id, err := notifyID(fs)
+	if err != nil {
+		return err
+	}
+
+	admin, err := newAdminService(opts.api)
+	if err != nil {
+		return err
+	}
+	d, err := admin.GetNotifyDetails(ctx, id)
+	if err != nil {
+		return err
+	}
+	if opts.json {
+		return writeJSON(os.Stdout, d)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "ID:\t%s\n", d.ID)
+	_, _ = fmt.Fprintf(tw, "Status:\t%s\n", d.Status)
+	_, _ = fmt.Fprintf(tw, "Send at:\t%s\n", d.SendAt.Format(time.RFC3339))
+	if d.Sender != "" {
+		_, _ = fmt.Fprintf(tw, "Sender:\t%s\n", d.Sender)
+	}
+	_, _ = fmt.Fprintf(tw, "To:\t%s\n", strings.Join(d.To, ", "))
+	if len(d.Cc) > 0 {
+		_, _ = fmt.Fprintf(tw, "Cc:\t%s\n", strings.Join(d.Cc, ", "))
+	}
+	if len(d.Bcc) > 0 {
+		_, _ = fmt.Fprintf(tw, "Bcc:\t%s\n", strings.Join(d.Bcc, ", "))
+	}
+	if len(d.RecipientLists) > 0 {
+		_, _ = fmt.Fprintf(tw, "Recipient lists:\t%s\n", strings.Join(d.RecipientLists, ", "))
+	}
+	if len(d.ReplyTo) > 0 {
+		_, _ = fmt.Fprintf(tw, "Reply-To:\t%s\n", strings.Join(d.ReplyTo, ", "))
+	}
+	if d.Subject != "" {
+		_, _ = fmt.Fprintf(tw, "Subject:\t%s\n", d.Subject)
+	}
+	headers := make([]string, 0, len(d.Headers))
+	for name := range d.Headers {
+		headers = append(headers, name)
+	}
+	sort.Strings(headers)
+	for _, name := range headers {
+		_, _ = fmt.Fprintf(tw, "Header %s:\t%s\n", name, d.Headers[name])
+	}
+	_, _ = fmt.Fprintf(tw, "Message:\t%s\n", d.Message)
+	if d.HTML != "" {
+		_, _ = fmt.Fprintf(tw, "HTML:\t%d bytes\n", len(d.HTML))
+	}
+	for _, a := range d.Attachments {
+		_, _ = fmt.Fprintf(tw, "Attachment:\t%s (%s, %d bytes)\n", a.Filename, a.ContentType, a.Size)
+	}
+	_, _ = fmt.Fprintf(tw, "Version:\t%d\n", d.Version)
+	_, _ = fmt.Fprintf(tw, "Request ID:\t%s\n", d.RequestID)
+	if d.QueuedAt != nil {
+		_, _ = fmt.Fprintf(tw, "Queued at:\t%s\n", d.QueuedAt.Format(time.RFC3339))
+	}
+	_, _ = fmt.Fprintf(tw, "Reap count:\t%d\n", d.ReapCount)
+	if d.LastError != "" {
+		_, _ = fmt.Fprintf(tw, "Last error:\t%s\n", d.LastError)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(d.Deliveries) > 0 {
+		_, _ = fmt.Fprintln(os.Stdout)
+		tw = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "RECIPIENT\tKIND\tSTATUS\tATTEMPTS\tLAST ERROR")
+		for _, r := range d.Deliveries {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", r.Email, r.Kind, r.Status, r.Attempts, r.LastError)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	_, _ = fmt.Fprintln(os.Stdout)
+	tw = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TIME\tACTION\tDETAILS")
+	for _, e := range d.Events {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", e.CreatedAt.Format(time.RFC3339), e.Action, e.Details)
+	}
+	return tw.Flush()
+}
+
+func runCancel(ctx context.Context, args []string) error {
+	var opts options
+	fs := newFlagSet("cancel", &opts)
+	_ = fs.Parse(args)
+	return runAction(ctx, fs, opts, entity.AdminActionRequest{Actor: opts.actor}, controller.AdminService.CancelNotify)
+}
+
+func runReschedule(ctx context.Context, args []string) error {
+	var (
+		opts options
+		at   time.Time
+		in   time.Duration
+	)
+	fs := newFlagSet("reschedule", &opts)
+	fs.Func("at", "new send time", timeFlag(&at))
+	fs.DurationVar(&in, "in", 0, "new send time relative to now, e.g. 10m")
+	_ = fs.Parse(args)
+
+	switch {
+	case at.IsZero() && in == 0:
+		return errors.New("either -at or -in is required")
+	case !at.IsZero() && in != 0:
+		return errors.New("-at and -in are mutually exclusive")
+	case in != 0:
+		at = time.Now().Add(in)
+	}
+	return runAction(ctx, fs, opts, entity.AdminActionRequest{SendAt: at, Actor: opts.actor}, controller.AdminService.RescheduleNotify)
+}
+
+func runResend(ctx context.Context, args []string) error {
+	var opts options
+	fs := newFlagSet("resend", &opts)
+	_ = fs.Parse(args)
+	return runAction(ctx, fs, opts, entity.AdminActionRequest{Actor: opts.actor}, controller.AdminService.ResendNotify)
+}
+
+type action func(controller.AdminService, context.Context, string, entity.AdminActionRequest) (entity.AdminResult, error)
+
+func runAction(ctx context.Context, fs *flag.FlagSet, opts options, req entity.AdminActionRequest, do action) error {
+	id, err := notifyID(fs)
+	if err != nil {
+		return err
+	}
+	admin, err := newAdminService(opts.api)
+	if err != nil {
+		return err
+	}
+	result, err := do(admin, ctx, id, req)
+	if err != nil {
+		return err
+	}
+	return writeResults(opts, []entity.AdminResult{result})
+}
+
+func runRequeue(ctx context.Context, args []string) error {
+	var (
+		opts options
+		req  entity.RequeueRequest
+	)
+	fs := newFlagSet("requeue", &opts)
+	filterFlags(fs, &req.Filter)
+	fs.BoolVar(&req.DryRun, "dry-run", false, "show what would be requeued without changing anything")
+	_ = fs.Parse(args)
+	req.Actor = opts.actor
+
+	admin, err := newAdminService(opts.api)
+	if err != nil {
+		return err
+	}
+	results, err := admin.RequeueFailed(ctx, req)
+	if err != nil {
+		return err
+	}
+	return writeResults(opts, results)
+}
+
+func runStats(ctx context.Context, args []string) error {
+	var opts options
+	fs := newFlagSet("stats", &opts)
+	_ = fs.Parse(args)
+
+	admin, err := newAdminService(opts.api)
+	if err != nil {
+		return err
+	}
+	stats, err := admin.GetNotifyStats(ctx)
+	if err != nil {
+		return err
+	}
+	if opts.json {
+		return writeJSON(os.Stdout, stats)
+	}
+
+	statuses := make([]string, 0, len(stats.ByStatus))
+	for status := range stats.ByStatus {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "STATUS\tCOUNT")
+	for _, status := range statuses {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\n", status, stats.ByStatus[status])
+	}
+	_, _ = fmt.Fprintln(tw)
+	_, _ = fmt.Fprintf(tw, "Due (scheduled, send_at passed):\t%d\n", stats.Due)
+	_, _ = fmt.Fprintf(tw, "Oldest due:\t%s\n", formatTime(stats.OldestDue))
+	_, _ = fmt.Fprintf(tw, "Next send at:\t%s\n", formatTime(stats.NextSendAt))
+	_, _ = fmt.Fprintf(tw, "Oldest queued:\t%s\n", formatTime(stats.OldestQueued))
+	return tw.Flush()
+}
+
+// newAdminService возвращает клиент admin API, если задан api, иначе сервис поверх PostgreSQL.
+func newAdminService(api string) (controller.AdminService, error) {
+	if api != "" {
+		return newAPIClient(api, os.Getenv("NOTIFYCTL_TOKEN")), nil
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
+	// Логи (включая аудит действий) пишем в stderr, чтобы не смешивать с выводом команды
+	logg := logger.NewWithWriter(os.Stderr, cfg.Logger.Level, cfg.Logger.Format, cfg.Logger.Redact)
+
+	db, err := postgres.NewDbConnection(cfg, secret.Static(cfg.Database.Password))
+	if err != nil {
+		return nil, fmt.Errorf("db connection error: %w", err)
+	}
+
+	redisClient, err := redis.NewRedisClient(cfg, secret.Static(cfg.Redis.Password))
+	if err != nil {
+		return nil, fmt.Errorf("redis connection error: %w", err)
+	}
+
+	notifyProducer := producer.NewNotifyProducer(cfg.Kafka.Broker(), cfg.Kafka.Topic, logg)
+	notifyRepo := postgres.NewNotifyDBRepository(db.Pool)
+	cacheRepo := redis.NewNotifyRedisRepository(redisClient, cfg.Cache, logg)
+
+	var opts []service.AdminOption
+	if cfg.Scheduler.Backend == config.SchedulerBackendRedis {
+		opts = append(opts, service.WithAdminScheduleRepository(redis.NewNotifyScheduleRepository(redisClient)))
+	}
+	return service.NewAdminService(notifyRepo, notifyRepo, cacheRepo, notifyProducer, logg, opts...), nil
+}
+
+func notifyID(fs *flag.FlagSet) (string, error) {
+	if fs.NArg() != 1 {
+		return "", errors.New("exactly one notify ID is required")
+	}
+	return fs.Arg(0), nil
+}
+
+func timeFlag(t *time.Time) func(string) error {
+	return func(v string) error {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return fmt.Errorf("invalid time %q, expected RFC 3339", v)
+		}
+		*t = parsed
+		return nil
+	}
+}
+
+func writeResults(opts options, results []entity.AdminResult) error {
+	if opts.json {
+		return writeJSON(os.Stdout, results)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NOTIFY ID\tACTION\tSTATUS\tERROR")
+	for _, r := range results {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.NotifyID, r.Action, r.Status, r.Error)
+	}
+	return tw.Flush()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
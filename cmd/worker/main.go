@@ -4,18 +4,26 @@ import (
 	"context"
 	"log"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+
+	"delayed-notifier/internal/app"
 	"delayed-notifier/internal/config"
+	"delayed-notifier/internal/controller"
 	"delayed-notifier/internal/controller/consumer"
+	httpHandlers "delayed-notifier/internal/controller/http"
+	"delayed-notifier/internal/leader"
 	"delayed-notifier/internal/logger"
-	"delayed-notifier/internal/repository/email"
+	"delayed-notifier/internal/metrics"
 	"delayed-notifier/internal/repository/postgres"
 	"delayed-notifier/internal/repository/producer"
 	"delayed-notifier/internal/repository/redis"
+	"delayed-notifier/internal/secret"
 	"delayed-notifier/internal/service"
 )
 
@@ -24,24 +32,38 @@ func main() {
 	defer stop()
 
 	// Config
-	cfg, err := config.New()
+	args := os.Args[1:]
+	if len(args) >= 2 && args[0] == "config" && args[1] == "print" {
+		if err := config.Print(os.Stdout, args[2:]); err != nil {
+			log.Fatal("config error: ", err) //nolint:gocritic
+		}
+		return
+	}
+	cfg, err := config.Load(args)
 	if err != nil {
 		log.Fatal("config error: ", err) //nolint:gocritic
 	}
 
 	// Logger
-	logg := logger.New(cfg.Logger.Level)
+	logg := logger.New(cfg.Logger.Level, cfg.Logger.Format, cfg.Logger.Redact)
 	logg.Info("logger initialized")
 
+	// Секреты из *_FILE перечитываются при ротации без перезапуска
+	secrets := secret.NewWatcher(cfg.Secrets.ReloadInterval, logg)
+	dbPassword := secrets.Watch(cfg.Database.PasswordFile, cfg.Database.Password)
+	redisPassword := secrets.Watch(cfg.Redis.PasswordFile, cfg.Redis.Password)
+	mailPassword := secrets.Watch(cfg.Mail.PasswordFile, cfg.Mail.Password)
+	go secrets.Start(ctx)
+
 	// DB connection
-	db, err := postgres.NewDbConnection(cfg)
+	db, err := postgres.NewDbConnection(cfg, dbPassword)
 	if err != nil {
 		logg.Error("db connection error", slog.Any("error", err))
 		os.Exit(1)
 	}
 	logg.Info("db connection initialized")
 
-	redisClient, err := redis.NewRedisClient(cfg)
+	redisClient, err := redis.NewRedisClient(cfg, redisPassword)
 	if err != nil {
 		logg.Error("redis connection error", slog.Any("error", err))
 		os.Exit(1)
@@ -49,7 +71,7 @@ func main() {
 	logg.Info("redis connection initialized")
 
 	// Kafka producer
-	producer := producer.NewNotifyProducer(cfg.Kafka.Host+":"+cfg.Kafka.Port, cfg.Kafka.Topic, logg)
+	producer := producer.NewNotifyProducer(cfg.Kafka.Broker(), cfg.Kafka.Topic, logg)
 	if err != nil {
 		logg.Error("failed to initialize notify producer", slog.Any("error", err))
 		os.Exit(1)
@@ -57,39 +79,96 @@ func main() {
 	logg.Info("notify producer initialized")
 
 	notifyRepo := postgres.NewNotifyDBRepository(db.Pool)
-	cacheRepo := redis.NewNotifyRedisRepository(redisClient, logg)
-	notifierRepo := email.NewMailer(cfg.Mail)
-	notifyService := service.NewNotifyService(notifyRepo, cacheRepo, producer, notifierRepo, logg)
-
-	kafkaConsumer := consumer.NewOrderConsumer(cfg.Kafka.Host+":"+cfg.Kafka.Port, cfg.Kafka.Topic, notifyService, logg)
-
-	// worker
+	cacheRepo := redis.NewNotifyRedisRepository(redisClient, cfg.Cache, logg)
+	notifierRepo, err := app.Mailer(cfg, mailPassword)
+	if err != nil {
+		logg.Error("mailer error", slog.Any("error", err))
+		os.Exit(1)
+	}
+	attachmentStore, err := app.AttachmentStore(cfg)
+	if err != nil {
+		logg.Error("attachment store error", slog.Any("error", err))
+		os.Exit(1)
+	}
+	serviceOpts := append(app.ServiceOptions(cfg), app.AttachmentOptions(cfg, attachmentStore)...)
+	senderOpts, err := app.SenderOptions(cfg)
+	if err != nil {
+		logg.Error("mail identities error", slog.Any("error", err))
+		os.Exit(1)
+	}
+	serviceOpts = append(serviceOpts, senderOpts...)
+	serviceOpts = append(serviceOpts, service.WithSuppressions(notifyRepo))
+	if cfg.Scheduler.Backend == config.SchedulerBackendRedis {
+		serviceOpts = append(serviceOpts, service.WithScheduleRepository(redis.NewNotifyScheduleRepository(redisClient)))
+	}
+	notifyService := service.NewNotifyService(notifyRepo, cacheRepo, producer, notifierRepo, logg, serviceOpts...)
+
+	newListener := func() app.EventSource { return postgres.NewNotifyListener(db.Pool, logg) }
+	pipeline := consumer.NewPipeline(cfg.Kafka.Topic, cfg.Kafka.RetryDelays)
+
+	// Планировщик запускается только на лидере, консьюмеры — на всех экземплярах
+	var leaderStatus controller.LeaderStatus = leader.Standalone{}
+	registry := metrics.NewRegistry()
+	app.RegisterMailMetrics(registry, notifierRepo)
+	if cfg.Leader.Enabled {
+		elector := leader.NewElector(postgres.NewAdvisoryLock(db.Pool, cfg.Leader.LockKey), leader.Config{
+			RetryInterval: cfg.Leader.RetryInterval,
+			CheckInterval: cfg.Leader.CheckInterval,
+		}, logg)
+		leaderStatus = elector
+		registry.Counter("notifier_worker_leader_transitions_total", "Number of leadership changes of this worker.",
+			func() float64 { return float64(elector.Transitions()) })
+		go func() {
+			elector.Run(ctx, func(ctx context.Context) {
+				app.RunScheduling(ctx, cfg, newListener, notifyService, logg)
+			})
+		}()
+	} else {
+		go func() {
+			app.RunScheduling(ctx, cfg, newListener, notifyService, logg)
+		}()
+	}
+	registry.Gauge("notifier_worker_leader", "Whether this worker runs the scheduler (1) or not (0).",
+		func() float64 { return metrics.Bool(leaderStatus.IsLeader()) })
+
+	r := chi.NewRouter()
+	r.Get("/health", httpHandlers.NewHealthHandler(leaderStatus, logg).Health)
+	r.Handle("/metrics", registry)
+	server := &http.Server{
+		Addr:         ":" + cfg.Worker.HTTPPort,
+		Handler:      r,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+		IdleTimeout:  10 * time.Second,
+	}
+	logg.Info("worker http server started", slog.String("addr", server.Addr))
 	go func() {
-		ticker := time.NewTicker(10 * time.Second)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ticker.C:
-				if err := notifyService.ScheduleReadyNotifies(ctx); err != nil {
-					logg.Error("schedule error", slog.Any("error", err))
-				}
-			case <-ctx.Done():
-				logg.Info("scheduler stopped")
-				return
-			}
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logg.Error("worker http server error", slog.Any("error", err))
+			os.Exit(1)
 		}
 	}()
 
-	go func() {
-		kafkaConsumer.Start(ctx)
-	}()
+	// Отдельный консьюмер на основной топик и на каждую ступень ретраев
+	for stage := 0; stage < pipeline.Stages(); stage++ {
+		kafkaConsumer := consumer.NewOrderConsumer(cfg.Kafka.Broker(), pipeline, stage, notifyService, cfg.Kafka.DLQRedact, logg)
+		go func() {
+			kafkaConsumer.Start(ctx)
+		}()
+	}
 
 	<-ctx.Done()
 	logg.Info("shutdown signal received")
 
-	_, cancel := context.WithTimeout(ctx, time.Duration(cfg.Server.ShutdownTimeout)*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 
-	logg.Info("server gracefully shutdown")
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logg.Error("worker http server shutdown failed", slog.Any("error", err))
+	} else {
+		logg.Info("server gracefully shutdown")
+	}
+	if err := notifierRepo.Close(); err != nil {
+		logg.Error("mailer close failed", slog.Any("error", err))
+	}
 }
@@ -21,7 +21,7 @@ document.getElementById('notify-form').addEventListener('submit', async function
     responseDiv.textContent = '';
 
     try {
-        const response = await fetch(`/delayed-notifier/notify`, {
+        const response = await fetch(`/delayed-notifier/v1/notify`, {
             method: 'POST',
             headers: {
                 'Content-Type': 'application/json'
@@ -35,9 +35,10 @@ document.getElementById('notify-form').addEventListener('submit', async function
             responseDiv.textContent = `Уведомление успешно создано! ID: ${result.id}`;
             form.reset();
         } else {
-            const error = await response.json();
+            const problem = await response.json();
+            const fields = (problem.errors || []).map(e => e.message).join('; ');
             responseDiv.className = 'response error';
-            responseDiv.textContent = `Ошибка: ${error.message || response.statusText}`;
+            responseDiv.textContent = `Ошибка: ${fields || problem.detail || response.statusText}`;
         }
     } catch (error) {
         responseDiv.className = 'response error';
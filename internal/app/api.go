@@ -0,0 +1,150 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"delayed-notifier/api/openapi"
+	httpHandlers "delayed-notifier/internal/controller/http"
+	"delayed-notifier/internal/controller/http/middleware"
+	"delayed-notifier/internal/controller/http/problem"
+	"delayed-notifier/internal/metrics"
+	"delayed-notifier/internal/repository/memory"
+	"delayed-notifier/internal/service"
+)
+
+// APIVersion — префикс маршрутов текущей версии API.
+const APIVersion = "/v1"
+
+// ProblemHandlers отвечает problem+json на неизвестные маршруты и методы.
+// Вызывается до r.Route, чтобы обработчики унаследовали вложенные роутеры.
+func ProblemHandlers(r chi.Router, logg *slog.Logger) {
+	r.NotFound(problem.NotFound(logg))
+	r.MethodNotAllowed(problem.MethodNotAllowed(logg))
+}
+
+// LegacyRedirects перенаправляет маршруты без версии на APIVersion. 308 сохраняет
+// метод и тело, поэтому старые клиенты продолжают работать.
+func LegacyRedirects(r chi.Router) {
+	redirect := func(w http.ResponseWriter, r *http.Request) {
+		target := APIVersion + r.URL.EscapedPath()
+		if r.URL.RawQuery != "" {
+			target += "?" + r.URL.RawQuery
+		}
+		w.Header().Set("Deprecation", "true")
+		http.Redirect(w, r, target, http.StatusPermanentRedirect)
+	}
+	r.HandleFunc("/notify", redirect)
+	r.HandleFunc("/notify/*", redirect)
+	r.HandleFunc("/admin/*", redirect)
+}
+
+// OpenAPI подключает проверку запросов по встроенной спецификации и отдаёт её
+// на /openapi.json. Вызывается до регистрации остальных маршрутов.
+func OpenAPI(r chi.Router, logg *slog.Logger) error {
+	doc, err := openapi.Load()
+	if err != nil {
+		return fmt.Errorf("OpenAPI: %w", err)
+	}
+	validator, err := middleware.OpenAPIValidator(doc, logg)
+	if err != nil {
+		return fmt.Errorf("OpenAPI: %w", err)
+	}
+	specHandler, err := httpHandlers.NewOpenAPIHandler(doc, logg)
+	if err != nil {
+		return fmt.Errorf("OpenAPI: %w", err)
+	}
+	r.Use(validator)
+	r.Get("/openapi.json", specHandler.Spec)
+	return nil
+}
+
+// NotifyRoutes регистрирует публичный API уведомлений.
+func NotifyRoutes(r chi.Router, notifyService *service.NotifyService, logg *slog.Logger) {
+	notifyHandler := httpHandlers.NewNotifyHandler(notifyService, logg)
+	r.Route("/notify", func(r chi.Router) {
+		r.Post("/", notifyHandler.CreateNotify)
+		r.Route("/{notifyID}", func(r chi.Router) {
+			r.Get("/", notifyHandler.GetNotify)
+			r.Delete("/", notifyHandler.DeleteNotify)
+			r.Get("/deliveries", notifyHandler.GetDeliveries)
+			r.Get("/attachments/{attachmentID}", notifyHandler.GetAttachment)
+		})
+	})
+}
+
+// RecipientListRoutes регистрирует API сохранённых списков получателей.
+func RecipientListRoutes(r chi.Router, recipientListService *service.RecipientListService, logg *slog.Logger) {
+	recipientListHandler := httpHandlers.NewRecipientListHandler(recipientListService, logg)
+	r.Route("/recipient-lists", func(r chi.Router) {
+		r.Get("/", recipientListHandler.ListRecipientLists)
+		r.Post("/", recipientListHandler.CreateRecipientList)
+		r.Route("/{listID}", func(r chi.Router) {
+			r.Get("/", recipientListHandler.GetRecipientList)
+			r.Put("/", recipientListHandler.UpdateRecipientList)
+			r.Delete("/", recipientListHandler.DeleteRecipientList)
+		})
+	})
+}
+
+// DLQRoutes регистрирует административный API для DLQ.
+func DLQRoutes(r chi.Router, dlqService *service.DLQService, logg *slog.Logger) {
+	dlqHandler := httpHandlers.NewDLQHandler(dlqService, logg)
+	r.Route("/admin/dlq", func(r chi.Router) {
+		r.Get("/", dlqHandler.ListDLQ)
+		r.Post("/replay", dlqHandler.ReplayDLQ)
+	})
+}
+
+// SuppressionRoutes регистрирует API списка подавления.
+func SuppressionRoutes(r chi.Router, suppressionService *service.SuppressionService, logg *slog.Logger) {
+	suppressionHandler := httpHandlers.NewSuppressionHandler(suppressionService, logg)
+	r.Route("/suppressions", func(r chi.Router) {
+		r.Get("/", suppressionHandler.ListSuppressions)
+		r.Route("/{email}", func(r chi.Router) {
+			r.Get("/", suppressionHandler.GetSuppression)
+			r.Delete("/", suppressionHandler.DeleteSuppression)
+		})
+	})
+}
+
+// AdminRoutes регистрирует административный API уведомлений для notifyctl.
+func AdminRoutes(r chi.Router, adminService *service.AdminService, logg *slog.Logger) {
+	adminHandler := httpHandlers.NewAdminHandler(adminService, logg)
+	r.Get("/admin/stats", adminHandler.Stats)
+	r.Route("/admin/notify", func(r chi.Router) {
+		r.Get("/", adminHandler.ListNotifies)
+		r.Post("/requeue", adminHandler.RequeueFailed)
+		r.Route("/{notifyID}", func(r chi.Router) {
+			r.Get("/", adminHandler.GetNotify)
+			r.Post("/cancel", adminHandler.CancelNotify)
+			r.Post("/reschedule", adminHandler.RescheduleNotify)
+			r.Post("/resend", adminHandler.ResendNotify)
+		})
+	})
+}
+
+func RegisterCacheMetrics(registry *metrics.Registry, notifyService *service.NotifyService) {
+	registry.Counter("notifier_cache_requests_total", "GetNotify cache lookups by result.",
+		func() float64 { return float64(notifyService.CacheStats().Hits) }, "result", "hit")
+	registry.Counter("notifier_cache_requests_total", "GetNotify cache lookups by result.",
+		func() float64 { return float64(notifyService.CacheStats().NegativeHits) }, "result", "negative_hit")
+	registry.Counter("notifier_cache_requests_total", "GetNotify cache lookups by result.",
+		func() float64 { return float64(notifyService.CacheStats().Misses) }, "result", "miss")
+	registry.Counter("notifier_cache_coalesced_total", "Cache misses served by a concurrent database read.",
+		func() float64 { return float64(notifyService.CacheStats().Coalesced) })
+}
+
+func RegisterLocalCacheMetrics(registry *metrics.Registry, localCache *memory.NotifyLRUCache) {
+	registry.Counter("notifier_local_cache_hits_total", "In-process cache hits.",
+		func() float64 { return float64(localCache.Stats().Hits) })
+	registry.Counter("notifier_local_cache_misses_total", "In-process cache misses.",
+		func() float64 { return float64(localCache.Stats().Misses) })
+	registry.Counter("notifier_local_cache_evictions_total", "In-process cache evictions.",
+		func() float64 { return float64(localCache.Stats().Evictions) })
+	registry.Gauge("notifier_local_cache_size", "Number of entries in the in-process cache.",
+		func() float64 { return float64(localCache.Stats().Size) })
+}
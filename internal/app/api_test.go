@@ -0,0 +1,76 @@
+package app
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/api/openapi"
+	"delayed-notifier/internal/controller/http/problem"
+)
+
+// Каждый маршрут HTTP API должен быть описан в спецификации.
+func TestOpenAPICoversRoutes(t *testing.T) {
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+	r := chi.NewRouter()
+	require.NoError(t, OpenAPI(r, logg))
+	r.Get("/health", func(http.ResponseWriter, *http.Request) {})
+	r.Route(APIVersion, func(r chi.Router) {
+		NotifyRoutes(r, nil, logg)
+		RecipientListRoutes(r, nil, logg)
+		SuppressionRoutes(r, nil, logg)
+		DLQRoutes(r, nil, logg)
+		AdminRoutes(r, nil, logg)
+	})
+
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+
+	var checked int
+	err = chi.Walk(r, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if route == "/openapi.json" {
+			return nil
+		}
+		path := strings.TrimSuffix(strings.ReplaceAll(route, "/*", ""), "/")
+		item := doc.Paths.Find(path)
+		if assert.NotNil(t, item, "path %s is not documented", path) {
+			assert.NotNil(t, item.GetOperation(method), "%s %s is not documented", method, path)
+		}
+		checked++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 23, checked)
+}
+
+func TestLegacyRedirects(t *testing.T) {
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+	r := chi.NewRouter()
+	ProblemHandlers(r, logg)
+	LegacyRedirects(r)
+
+	t.Run("redirects to v1", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/notify/42/cancel?x=1", nil))
+
+		assert.Equal(t, http.StatusPermanentRedirect, rec.Code)
+		assert.Equal(t, "/v1/admin/notify/42/cancel?x=1", rec.Header().Get("Location"))
+		assert.Equal(t, "true", rec.Header().Get("Deprecation"))
+	})
+
+	t.Run("unknown route", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/notify", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), `"code":"route_not_found"`)
+	})
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"fmt"
+
+	"delayed-notifier/internal/config"
+	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/repository/blob"
+	"delayed-notifier/internal/service"
+)
+
+// bodyOverhead — запас на base64 и остальные поля запроса сверх размера вложений
+const bodyOverhead = 1 << 20
+
+// AttachmentStore открывает хранилище вложений в cfg.Attachments.Dir.
+// Без каталога возвращает nil — вложения выключены.
+func AttachmentStore(cfg *config.Config) (service.AttachmentStore, error) {
+	if cfg.Attachments.Dir == "" {
+		return nil, nil
+	}
+	store, err := blob.NewFileStore(cfg.Attachments.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("AttachmentStore: %w", err)
+	}
+	return store, nil
+}
+
+// AttachmentOptions включает вложения в сервисе уведомлений, если store задан.
+func AttachmentOptions(cfg *config.Config, store service.AttachmentStore) []service.Option {
+	if store == nil {
+		return nil
+	}
+	return []service.Option{service.WithAttachments(store, entity.AttachmentPolicy{
+		MaxCount:     cfg.Attachments.MaxCount,
+		MaxSize:      cfg.Attachments.MaxSize,
+		MaxTotalSize: cfg.Attachments.MaxTotalSize,
+		ContentTypes: cfg.Attachments.ContentTypeList(),
+		Retention:    cfg.Attachments.Retention,
+	})}
+}
+
+// MaxBodySize — предел тела запроса: вложения в base64 занимают на треть больше исходного размера.
+func MaxBodySize(cfg *config.Config) int64 {
+	return cfg.Attachments.MaxTotalSize/3*4 + bodyOverhead
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+
+	notifierv1 "delayed-notifier/api/notifier/v1"
+	"delayed-notifier/internal/config"
+	grpcHandlers "delayed-notifier/internal/controller/grpc"
+	"delayed-notifier/internal/service"
+)
+
+// ServeGRPC поднимает gRPC API на cfg.GRPC.Port и останавливает его с отменой ctx,
+// дожидаясь завершения текущих вызовов не дольше cfg.Server.ShutdownTimeout.
+// Аутентификации, как и в REST API, нет. Возвращает ошибку, если порт не удалось занять.
+func ServeGRPC(ctx context.Context, cfg *config.Config, notifyService *service.NotifyService, adminService *service.AdminService, logg *slog.Logger) (<-chan struct{}, error) {
+	lis, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
+	if err != nil {
+		return nil, err
+	}
+
+	server := grpc.NewServer(
+		// Вложения приходят в самом запросе, поэтому предел тот же, что у тела REST-запроса
+		grpc.MaxRecvMsgSize(int(MaxBodySize(cfg))),
+		grpc.ChainUnaryInterceptor(grpcHandlers.UnaryInterceptor(logg)),
+		grpc.ChainStreamInterceptor(grpcHandlers.StreamInterceptor(logg)),
+	)
+	notifierv1.RegisterNotifierServiceServer(server,
+		grpcHandlers.NewNotifierServer(notifyService, adminService, cfg.GRPC.WatchInterval, logg))
+
+	logg.Info("grpc server started", slog.String("addr", lis.Addr().String()))
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			logg.Error("grpc server error", slog.Any("error", err))
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+			logg.Info("grpc server gracefully shutdown")
+		case <-time.After(cfg.Server.ShutdownTimeout):
+			// WatchNotify может висеть долго — обрываем оставшиеся потоки
+			server.Stop()
+			logg.Warn("grpc server shutdown timed out")
+		}
+	}()
+	return done, nil
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"fmt"
+
+	"delayed-notifier/internal/config"
+	"delayed-notifier/internal/metrics"
+	"delayed-notifier/internal/repository/email"
+	"delayed-notifier/internal/secret"
+	"delayed-notifier/internal/service"
+)
+
+// SenderOptions разрешает клиентам указывать в sender отправителей из конфигурации мейлера.
+func SenderOptions(cfg *config.Config) ([]service.Option, error) {
+	ids, err := cfg.Mail.Identities()
+	if err != nil {
+		return nil, fmt.Errorf("SenderOptions: %w", err)
+	}
+	return []service.Option{service.WithSenders(ids.Names())}, nil
+}
+
+// Mailer создаёт SMTP-мейлер с отправителями и ключами DKIM из конфигурации.
+func Mailer(cfg *config.Config, password *secret.Value) (*email.Mailer, error) {
+	ids, err := cfg.Mail.Identities()
+	if err != nil {
+		return nil, fmt.Errorf("Mailer: %w", err)
+	}
+	identities, err := email.NewIdentities(ids)
+	if err != nil {
+		return nil, fmt.Errorf("Mailer: %w", err)
+	}
+	return email.NewMailer(cfg.Mail, password, identities), nil
+}
+
+// RegisterMailMetrics публикует статистику пула SMTP-соединений мейлера.
+func RegisterMailMetrics(registry *metrics.Registry, mailer *email.Mailer) {
+	registry.Gauge("notifier_smtp_connections", "Open SMTP connections by state.",
+		func() float64 { s := mailer.Stats(); return float64(s.Open - int64(s.Idle)) }, "state", "busy")
+	registry.Gauge("notifier_smtp_connections", "Open SMTP connections by state.",
+		func() float64 { return float64(mailer.Stats().Idle) }, "state", "idle")
+	registry.Counter("notifier_smtp_dials_total", "SMTP connection attempts by result.",
+		func() float64 { s := mailer.Stats(); return float64(s.Dials - s.DialErrors) }, "result", "ok")
+	registry.Counter("notifier_smtp_dials_total", "SMTP connection attempts by result.",
+		func() float64 { return float64(mailer.Stats().DialErrors) }, "result", "error")
+	registry.Counter("notifier_smtp_reuses_total", "Messages sent over an already open SMTP connection.",
+		func() float64 { return float64(mailer.Stats().Reuses) })
+	registry.Counter("notifier_smtp_broken_total", "SMTP connections dropped after a network error or cancellation.",
+		func() float64 { return float64(mailer.Stats().Broken) })
+	registry.Counter("notifier_smtp_waits_total", "Sends that waited for a free SMTP connection.",
+		func() float64 { return float64(mailer.Stats().Waits) })
+}
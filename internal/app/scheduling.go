@@ -0,0 +1,136 @@
+// Package app собирает роли сервиса (API, планировщик, консьюмер) из компонентов,
+// чтобы отдельные бинарники и all-in-one cmd/notifier запускали их одинаково.
+package app
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"delayed-notifier/internal/config"
+	"delayed-notifier/internal/controller/scheduler"
+	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/service"
+)
+
+// EventSource — источник событий планирования (postgres.NotifyListener, memory.NotifyListener)
+type EventSource interface {
+	Subscribe() <-chan entity.ScheduleEvent
+	Start(ctx context.Context)
+}
+
+// RunScheduling запускает планировщик, колесо таймеров, сверку расписания,
+// восстановление зависших уведомлений и удаление просроченных вложений и ждёт их остановки после отмены ctx.
+// newEvents вызывается на каждый запуск, потому что источник закрывает
+// каналы подписчиков при остановке.
+func RunScheduling(ctx context.Context, cfg *config.Config, newEvents func() EventSource, notifyService *service.NotifyService, logg *slog.Logger) {
+	var wg sync.WaitGroup
+
+	schedulerCfg := scheduler.Config{
+		MinInterval: cfg.Scheduler.MinInterval,
+		MaxInterval: cfg.Scheduler.Interval,
+	}
+	var wakeups <-chan entity.ScheduleEvent
+	if cfg.Scheduler.Listen {
+		listener := newEvents()
+		wakeups = listener.Subscribe()
+
+		if cfg.Scheduler.Wheel {
+			wheel := scheduler.NewWheelScheduler(notifyService, listener.Subscribe(), scheduler.WheelConfig{
+				Horizon:        cfg.Scheduler.WheelHorizon,
+				ReloadInterval: cfg.Scheduler.WheelReload,
+			}, logg)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				wheel.Start(ctx)
+			}()
+			schedulerCfg.Grace = scheduler.WheelGrace
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			listener.Start(ctx)
+		}()
+	}
+	notifyScheduler := scheduler.NewScheduler(notifyService, wakeups, schedulerCfg, logg)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		notifyScheduler.Start(ctx)
+	}()
+
+	reaper := scheduler.NewReaper(notifyService, cfg.Reaper.Interval, logg)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		reaper.Start(ctx)
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(cfg.Attachments.CleanupInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				logg.Info("attachment cleanup stopped")
+				return
+			}
+
+			purged, err := notifyService.PurgeAttachments(ctx)
+			if err != nil {
+				logg.Error("attachment cleanup error", slog.Any("error", err))
+			}
+			if purged > 0 {
+				logg.Info("expired attachments purged", slog.Int("notifies", purged))
+			}
+		}
+	}()
+
+	if cfg.Scheduler.Backend == config.SchedulerBackendRedis {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ticker := time.NewTicker(cfg.Scheduler.ReconcileInterval)
+			defer ticker.Stop()
+
+			for {
+				if err := notifyService.ReconcileSchedule(ctx); err != nil {
+					logg.Error("schedule reconcile error", slog.Any("error", err))
+				}
+
+				select {
+				case <-ticker.C:
+				case <-ctx.Done():
+					logg.Info("schedule reconciler stopped")
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// ServiceOptions возвращает опции сервиса уведомлений из настроек планировщика и reaper.
+func ServiceOptions(cfg *config.Config) []service.Option {
+	return []service.Option{
+		service.WithBatchSize(cfg.Scheduler.BatchSize),
+		service.WithCatchUpPolicy(entity.CatchUpPolicy{
+			Mode:         cfg.Scheduler.CatchUp.Policy,
+			LateAfter:    cfg.Scheduler.CatchUp.LateAfter,
+			MaxLateness:  cfg.Scheduler.CatchUp.MaxLateness,
+			SpreadWindow: cfg.Scheduler.CatchUp.SpreadWindow,
+		}),
+		service.WithReapPolicy(entity.ReapPolicy{
+			Threshold: cfg.Reaper.Threshold,
+			MaxReaps:  cfg.Reaper.MaxReaps,
+		}),
+	}
+}
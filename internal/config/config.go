@@ -2,146 +2,475 @@ package config
 
 import (
 	"fmt"
-	"os"
-	"strconv"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
-	"github.com/joho/godotenv"
+	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/logger"
+	"delayed-notifier/internal/redact"
 )
 
+// Поля конфигурации описываются тегами: key — имя ключа в файле и флаге
+// (секции соединяются точкой), env — переменная окружения, secret — значение
+// скрывается в config print, validate — дополнительные проверки.
+
 type ServerConfig struct {
-	Port            string
-	ShutdownTimeout int
+	Port            string        `key:"port" env:"SERVER_PORT" validate:"port"`
+	ShutdownTimeout time.Duration `key:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT" validate:"positive"`
+}
+
+// AdminConfig — доступ к административному API (/v1/admin). Без токена API закрыт.
+type AdminConfig struct {
+	Token     string `key:"token" env:"ADMIN_TOKEN" secret:"true"`
+	TokenFile string `key:"token_file" env:"ADMIN_TOKEN_FILE"`
+}
+
+// GRPCConfig — gRPC API (NotifierService) рядом с REST API.
+type GRPCConfig struct {
+	Enabled bool   `key:"enabled" env:"GRPC_ENABLED"`
+	Port    string `key:"port" env:"GRPC_PORT" validate:"port"`
+	// WatchInterval — как часто WatchNotify проверяет статус уведомления
+	WatchInterval time.Duration `key:"watch_interval" env:"GRPC_WATCH_INTERVAL" validate:"positive"`
 }
 
 type LoggerConfig struct {
-	Level string
+	Level string `key:"level" env:"LOG_LEVEL"`
+	// Format — text (по умолчанию) или json
+	Format string `key:"format" env:"LOG_FORMAT"`
+	// Redact — какие персональные данные маскировать в логах
+	Redact redact.Policy `key:"redact" env:"LOG_REDACT"`
 }
 
 type DatabaseConfig struct {
-	User     string
-	Password string
-	Host     string
-	Port     string
-	Name     string
+	User     string `key:"user" env:"DB_USER"`
+	Password string `key:"password" env:"DB_PASSWORD" secret:"true"`
+	// PasswordFile — файл с паролем (Docker/Kubernetes secret), перечитывается при изменении
+	PasswordFile string `key:"password_file" env:"DB_PASSWORD_FILE"`
+	Host         string `key:"host" env:"DB_HOST"`
+	Port         string `key:"port" env:"DB_PORT" validate:"port"`
+	Name         string `key:"name" env:"DB_NAME"`
 }
 
 type PoolConfig struct {
-	MaxConns    int
-	MinConns    int
-	MaxIdleTime time.Duration
-	MaxLifeTime time.Duration
+	MaxConns    int           `key:"max_conns" env:"POOL_MAX_CONNS" validate:"positive"`
+	MinConns    int           `key:"min_conns" env:"POOL_MIN_CONNS" validate:"nonnegative"`
+	MaxIdleTime time.Duration `key:"max_idle_time" env:"POOL_MAX_IDLE_TIME" validate:"positive"`
+	MaxLifeTime time.Duration `key:"max_life_time" env:"POOL_MAX_LIFE_TIME" validate:"positive"`
 }
 
 type RedisConfig struct {
-	Host     string
-	Port     string
-	Password string
-	DB       int
+	Host         string `key:"host" env:"REDIS_HOST"`
+	Port         string `key:"port" env:"REDIS_PORT" validate:"port"`
+	Password     string `key:"password" env:"REDIS_PASSWORD" secret:"true"`
+	PasswordFile string `key:"password_file" env:"REDIS_PASSWORD_FILE"`
+	DB           int    `key:"db" env:"REDIS_DB" validate:"nonnegative"`
+}
+
+type CacheConfig struct {
+	KeyPrefix string `key:"key_prefix" env:"CACHE_KEY_PREFIX"`
+	// TTL — для уведомлений в работе (scheduled, queued), FinalTTL — для завершённых
+	TTL      time.Duration `key:"ttl" env:"CACHE_TTL" validate:"positive"`
+	FinalTTL time.Duration `key:"final_ttl" env:"CACHE_FINAL_TTL" validate:"positive"`
+	// NegativeTTL — сколько помнить, что уведомления нет
+	NegativeTTL time.Duration `key:"negative_ttl" env:"CACHE_NEGATIVE_TTL" validate:"positive"`
+	// LocalSize — размер in-process LRU перед Redis (0 — выключен), LocalTTL — время жизни записи в нём
+	LocalSize int           `key:"local_size" env:"CACHE_LOCAL_SIZE" validate:"nonnegative"`
+	LocalTTL  time.Duration `key:"local_ttl" env:"CACHE_LOCAL_TTL" validate:"positive"`
 }
 
 type KafkaConfig struct {
-	Host  string
-	Port  string
-	Topic string
+	Host        string          `key:"host" env:"KAFKA_HOST"`
+	Port        string          `key:"port" env:"KAFKA_PORT" validate:"port"`
+	Topic       string          `key:"topic" env:"KAFKA_TOPIC" validate:"required"`
+	RetryDelays []time.Duration `key:"retry_delays" env:"KAFKA_RETRY_DELAYS" validate:"positive"`
+	// DLQRedact — какие персональные данные маскировать в сообщениях DLQ
+	DLQRedact redact.Policy `key:"dlq_redact" env:"DLQ_REDACT"`
 }
 
 type MailConfig struct {
-	Host     string
-	Port     int
-	User     string
-	Password string
+	Host         string `key:"host" env:"MAIL_HOST"`
+	Port         int    `key:"port" env:"MAIL_PORT" validate:"port"`
+	User         string `key:"user" env:"MAIL_USER"`
+	Password     string `key:"password" env:"MAIL_PASSWORD" secret:"true"`
+	PasswordFile string `key:"password_file" env:"MAIL_PASSWORD_FILE"`
+	// From и FromName — отправитель по умолчанию; без From письма идут от User
+	From     string `key:"from" env:"MAIL_FROM"`
+	FromName string `key:"from_name" env:"MAIL_FROM_NAME"`
+	// DKIMSelector и DKIMKeyFile — ключ DKIM для домена отправителя по умолчанию
+	DKIMSelector string `key:"dkim_selector" env:"MAIL_DKIM_SELECTOR"`
+	DKIMKeyFile  string `key:"dkim_key_file" env:"MAIL_DKIM_KEY_FILE"`
+	// IdentitiesFile — YAML с остальными отправителями и их ключами DKIM (см. Identities)
+	IdentitiesFile string `key:"identities_file" env:"MAIL_IDENTITIES_FILE"`
+	// PoolSize — предел одновременно открытых соединений с SMTP-сервером
+	PoolSize int `key:"pool_size" env:"MAIL_POOL_SIZE" validate:"positive"`
+	// IdleTimeout — сколько держать простаивающее соединение; серверы сами закрывают их через минуту-пять
+	IdleTimeout time.Duration `key:"idle_timeout" env:"MAIL_IDLE_TIMEOUT" validate:"positive"`
+	// MaxMessagesPerConn — после стольких писем соединение переоткрывается
+	MaxMessagesPerConn int `key:"max_messages_per_conn" env:"MAIL_MAX_MESSAGES_PER_CONN" validate:"positive"`
+	// Timeout — предел на подключение и на каждую команду SMTP, если у контекста нет дедлайна раньше
+	Timeout time.Duration `key:"timeout" env:"MAIL_TIMEOUT" validate:"positive"`
+}
+
+const (
+	SchedulerBackendPostgres = "postgres"
+	SchedulerBackendRedis    = "redis"
+)
+
+type SchedulerConfig struct {
+	Backend           string        `key:"backend" env:"SCHEDULER_BACKEND"`
+	Interval          time.Duration `key:"interval" env:"SCHEDULER_INTERVAL" validate:"positive"`
+	MinInterval       time.Duration `key:"min_interval" env:"SCHEDULER_MIN_INTERVAL" validate:"positive"`
+	Listen            bool          `key:"listen" env:"SCHEDULER_LISTEN"`
+	ReconcileInterval time.Duration `key:"reconcile_interval" env:"SCHEDULER_RECONCILE_INTERVAL" validate:"positive"`
+	Wheel             bool          `key:"wheel" env:"SCHEDULER_WHEEL"`
+	WheelHorizon      time.Duration `key:"wheel_horizon" env:"SCHEDULER_WHEEL_HORIZON" validate:"positive"`
+	WheelReload       time.Duration `key:"wheel_reload_interval" env:"SCHEDULER_WHEEL_RELOAD_INTERVAL" validate:"positive"`
+	BatchSize         int           `key:"batch_size" env:"SCHEDULER_BATCH_SIZE" validate:"positive"`
+	CatchUp           CatchUpConfig `key:"catchup"`
+}
+
+type LeaderConfig struct {
+	Enabled       bool          `key:"enabled" env:"LEADER_ELECTION"`
+	LockKey       int64         `key:"lock_key" env:"LEADER_LOCK_KEY"`
+	RetryInterval time.Duration `key:"retry_interval" env:"LEADER_RETRY_INTERVAL" validate:"positive"`
+	CheckInterval time.Duration `key:"check_interval" env:"LEADER_CHECK_INTERVAL" validate:"positive"`
+}
+
+type ReaperConfig struct {
+	Interval  time.Duration `key:"interval" env:"REAPER_INTERVAL" validate:"positive"`
+	Threshold time.Duration `key:"threshold" env:"REAPER_THRESHOLD" validate:"positive"`
+	MaxReaps  int           `key:"max_reaps" env:"REAPER_MAX_REAPS" validate:"nonnegative"`
+}
+
+type SecretsConfig struct {
+	// ReloadInterval — как часто проверять файлы *_FILE на ротацию
+	ReloadInterval time.Duration `key:"reload_interval" env:"SECRETS_RELOAD_INTERVAL" validate:"positive"`
+}
+
+// AttachmentsConfig — вложения уведомлений. Без Dir вложения выключены
+// (кроме cmd/notifier с хранилищем в памяти).
+type AttachmentsConfig struct {
+	// Dir — каталог с содержимым вложений, общий для API и воркера
+	Dir          string `key:"dir" env:"ATTACHMENTS_DIR"`
+	MaxCount     int    `key:"max_count" env:"ATTACHMENTS_MAX_COUNT" validate:"positive"`
+	MaxSize      int64  `key:"max_size" env:"ATTACHMENTS_MAX_SIZE" validate:"positive"`
+	MaxTotalSize int64  `key:"max_total_size" env:"ATTACHMENTS_MAX_TOTAL_SIZE" validate:"positive"`
+	// ContentTypes — разрешённые типы через запятую
+	ContentTypes string `key:"content_types" env:"ATTACHMENTS_CONTENT_TYPES" validate:"required"`
+	// Retention — сколько хранить вложения завершённых уведомлений после send_at (0 — всегда)
+	Retention       time.Duration `key:"retention" env:"ATTACHMENTS_RETENTION" validate:"nonnegative"`
+	CleanupInterval time.Duration `key:"cleanup_interval" env:"ATTACHMENTS_CLEANUP_INTERVAL" validate:"positive"`
+}
+
+// ContentTypeList возвращает ContentTypes списком.
+func (c *AttachmentsConfig) ContentTypeList() []string {
+	var types []string
+	for _, t := range strings.Split(c.ContentTypes, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, strings.ToLower(t))
+		}
+	}
+	return types
+}
+
+type WorkerConfig struct {
+	HTTPPort string `key:"http_port" env:"WORKER_HTTP_PORT" validate:"port"`
+}
+
+// Роли и бэкенды all-in-one бинарника cmd/notifier
+const (
+	RoleAPI       = "api"
+	RoleScheduler = "scheduler"
+	RoleConsumer  = "consumer"
+
+	BackendPostgres = "postgres"
+	BackendRedis    = "redis"
+	BackendKafka    = "kafka"
+	BackendMemory   = "memory"
+
+	MailerSMTP = "smtp"
+	MailerLog  = "log"
+	MailerFile = "file"
+)
+
+type NotifierConfig struct {
+	// Roles — роли через запятую: api, scheduler, consumer
+	Roles string `key:"roles" env:"NOTIFIER_ROLES" validate:"required"`
+	// DB, Cache, Queue — postgres|memory, redis|memory, kafka|memory
+	DB    string `key:"db" env:"NOTIFIER_DB"`
+	Cache string `key:"cache" env:"NOTIFIER_CACHE"`
+	Queue string `key:"queue" env:"NOTIFIER_QUEUE"`
+	// QueueSize — ёмкость очереди в памяти
+	QueueSize int `key:"queue_size" env:"NOTIFIER_QUEUE_SIZE" validate:"positive"`
+	// Mailer — smtp, log (письма в лог) или file (JSON-строки в MailFile)
+	Mailer   string `key:"mailer" env:"NOTIFIER_MAILER"`
+	MailFile string `key:"mail_file" env:"NOTIFIER_MAIL_FILE"`
+	// Migrate — применять встроенные миграции при старте (только для postgres)
+	Migrate bool `key:"migrate" env:"NOTIFIER_MIGRATE"`
+}
+
+// HasRole сообщает, включена ли роль role.
+func (c *NotifierConfig) HasRole(role string) bool {
+	for _, r := range strings.Split(c.Roles, ",") {
+		if strings.TrimSpace(r) == role {
+			return true
+		}
+	}
+	return false
+}
+
+type CatchUpConfig struct {
+	Policy       string        `key:"policy" env:"CATCHUP_POLICY"`
+	LateAfter    time.Duration `key:"late_after" env:"CATCHUP_LATE_AFTER" validate:"positive"`
+	MaxLateness  time.Duration `key:"max_lateness" env:"CATCHUP_MAX_LATENESS" validate:"positive"`
+	SpreadWindow time.Duration `key:"spread_window" env:"CATCHUP_SPREAD_WINDOW" validate:"positive"`
 }
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Logger   LoggerConfig
-	Pool     PoolConfig
-	Redis    RedisConfig
-	Kafka    KafkaConfig
-	Mail     MailConfig
+	Server      ServerConfig      `key:"server"`
+	Admin       AdminConfig       `key:"admin"`
+	GRPC        GRPCConfig        `key:"grpc"`
+	Database    DatabaseConfig    `key:"database"`
+	Logger      LoggerConfig      `key:"log"`
+	Pool        PoolConfig        `key:"pool"`
+	Redis       RedisConfig       `key:"redis"`
+	Cache       CacheConfig       `key:"cache"`
+	Kafka       KafkaConfig       `key:"kafka"`
+	Mail        MailConfig        `key:"mail"`
+	Scheduler   SchedulerConfig   `key:"scheduler"`
+	Leader      LeaderConfig      `key:"leader"`
+	Reaper      ReaperConfig      `key:"reaper"`
+	Worker      WorkerConfig      `key:"worker"`
+	Secrets     SecretsConfig     `key:"secrets"`
+	Notifier    NotifierConfig    `key:"notifier"`
+	Attachments AttachmentsConfig `key:"attachments"`
 }
 
+// DSN собирает URL подключения; имя пользователя, пароль и имя базы экранируются,
+// поэтому пароль из секрета может содержать @, /, : и %.
 func (c *DatabaseConfig) DSN() string {
-	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s",
-		c.User, c.Password, c.Host, c.Port, c.Name)
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(c.User, c.Password),
+		Host:   net.JoinHostPort(c.Host, c.Port),
+		Path:   "/" + c.Name,
+	}
+	return u.String()
 }
 
-func New() (*Config, error) {
-	err := godotenv.Load("/.env")
-	if err != nil {
-		return nil, fmt.Errorf("failed to load env: %w", err)
-	}
+func (c *KafkaConfig) Broker() string {
+	return c.Host + ":" + c.Port
+}
+
+func (c *KafkaConfig) DLQTopic() string {
+	return c.Topic + "-dlq"
+}
+
+// Default возвращает конфигурацию по умолчанию — нижний слой перед файлом,
+// переменными окружения и флагами.
+func Default() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Port:            getEnv("SERVER_PORT", "8080"),
-			ShutdownTimeout: getEnvAsInt("SERVER_PORT", 15),
+			Port:            "8080",
+			ShutdownTimeout: 15 * time.Second,
+		},
+		GRPC: GRPCConfig{
+			Enabled:       true,
+			Port:          "9090",
+			WatchInterval: time.Second,
 		},
 		Redis: RedisConfig{
-			Host:     getEnv("REDIS_HOST", "redis"),
-			Port:     getEnv("REDIS_PORT", "6379"),
-			Password: getEnv("REDIS_PASSWORD", ""),
-			DB:       getEnvAsInt("REDIS_DB", 0),
+			Host: "redis",
+			Port: "6379",
+		},
+		Cache: CacheConfig{
+			KeyPrefix:   "notifier:notify:",
+			TTL:         24 * time.Hour,
+			FinalTTL:    24 * time.Hour,
+			NegativeTTL: 30 * time.Second,
+			LocalTTL:    5 * time.Second,
 		},
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "postgres"),
-			Port:     getEnv("DB_PORT", "5435"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "postgres"),
-			Name:     getEnv("DB_NAME", "postgres"),
+			Host:     "postgres",
+			Port:     "5435",
+			User:     "postgres",
+			Password: "postgres",
+			Name:     "postgres",
 		},
 		Pool: PoolConfig{
-			MaxConns:    getEnvAsInt("POOL_MAX_CONNS", 10),
-			MinConns:    getEnvAsInt("POOL_MIN_CONNS", 2),
-			MaxIdleTime: getEnvAsDuration("POOL_MAX_IDLE_TIME", time.Hour),
-			MaxLifeTime: getEnvAsDuration("POOL_MAX_LIFE_TIME", 10*time.Minute),
+			MaxConns:    10,
+			MinConns:    2,
+			MaxIdleTime: time.Hour,
+			MaxLifeTime: 10 * time.Minute,
 		},
 		Logger: LoggerConfig{
-			Level: getEnv("LOG_LEVEL", "debug"),
+			Level:  "debug",
+			Format: logger.FormatText,
+			Redact: redact.Policy{Email: true, Message: true},
 		},
 		Kafka: KafkaConfig{
-			Host:  getEnv("KAFKA_HOST", "kafka"),
-			Port:  getEnv("KAFKA_PORT", "9092"),
-			Topic: getEnv("KAFKA_TOPIC", "notify-topic"),
+			Host:        "kafka",
+			Port:        "9092",
+			Topic:       "notify-topic",
+			RetryDelays: []time.Duration{time.Minute, 10 * time.Minute, time.Hour},
+			DLQRedact:   redact.Policy{Email: true, Message: true},
 		},
 		Mail: MailConfig{
-			Host:     getEnv("MAIL_HOST", ""),
-			Port:     getEnvAsInt("MAIL_PORT", 465),
-			User:     getEnv("MAIL_USER", "notifier-app"),
-			Password: getEnv("MAIL_PASSWORD", ""),
+			Port:               465,
+			User:               "notifier-app",
+			PoolSize:           4,
+			IdleTimeout:        30 * time.Second,
+			MaxMessagesPerConn: 100,
+			Timeout:            30 * time.Second,
+		},
+		Scheduler: SchedulerConfig{
+			Backend:           SchedulerBackendPostgres,
+			Interval:          time.Minute,
+			MinInterval:       time.Second,
+			Listen:            true,
+			ReconcileInterval: 5 * time.Minute,
+			Wheel:             true,
+			WheelHorizon:      5 * time.Minute,
+			WheelReload:       time.Minute,
+			BatchSize:         500,
+			CatchUp: CatchUpConfig{
+				Policy:       entity.CatchUpAll,
+				LateAfter:    time.Minute,
+				MaxLateness:  time.Hour,
+				SpreadWindow: 10 * time.Minute,
+			},
+		},
+		Leader: LeaderConfig{
+			Enabled:       true,
+			LockKey:       7310001,
+			RetryInterval: 2 * time.Second,
+			CheckInterval: time.Second,
+		},
+		Reaper: ReaperConfig{
+			Interval:  time.Minute,
+			Threshold: 30 * time.Minute,
+			MaxReaps:  3,
 		},
-	}, nil
+		Worker: WorkerConfig{
+			HTTPPort: "8081",
+		},
+		Attachments: AttachmentsConfig{
+			MaxCount:        10,
+			MaxSize:         10 << 20,
+			MaxTotalSize:    20 << 20,
+			ContentTypes:    "application/pdf,text/csv,text/plain,image/png,image/jpeg,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			Retention:       30 * 24 * time.Hour,
+			CleanupInterval: time.Hour,
+		},
+		Secrets: SecretsConfig{
+			ReloadInterval: 30 * time.Second,
+		},
+		Notifier: NotifierConfig{
+			Roles:     RoleAPI + "," + RoleScheduler + "," + RoleConsumer,
+			DB:        BackendPostgres,
+			Cache:     BackendRedis,
+			Queue:     BackendKafka,
+			QueueSize: 1024,
+			Mailer:    MailerSMTP,
+			Migrate:   true,
+		},
+	}
 }
 
-func getEnv(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
-		return defaultValue
-	}
-	return value
+// New загружает конфигурацию без флагов командной строки.
+func New() (*Config, error) {
+	return Load(nil)
 }
 
-func getEnvAsInt(key string, defaultValue int) int {
-	valueStr := os.Getenv(key)
-	if valueStr == "" {
-		return defaultValue
+// validate проверяет значения, которые нельзя выразить тегом validate.
+func (c *Config) validate() []error {
+	var errs []error
+
+	switch c.Scheduler.Backend {
+	case SchedulerBackendPostgres, SchedulerBackendRedis:
+	default:
+		errs = append(errs, fmt.Errorf("scheduler.backend: unknown scheduler backend %q", c.Scheduler.Backend))
 	}
-	value, err := strconv.Atoi(valueStr)
-	if err != nil {
-		return defaultValue
+
+	switch c.Logger.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log.level: unknown log level %q", c.Logger.Level))
 	}
-	return value
+
+	switch c.Logger.Format {
+	case logger.FormatText, logger.FormatJSON:
+	default:
+		errs = append(errs, fmt.Errorf("log.format: unknown log format %q", c.Logger.Format))
+	}
+
+	switch c.Scheduler.CatchUp.Policy {
+	case entity.CatchUpAll, entity.CatchUpMaxLateness, entity.CatchUpCollapse, entity.CatchUpSpread:
+	default:
+		errs = append(errs, fmt.Errorf("scheduler.catchup.policy: unknown catch-up policy %q", c.Scheduler.CatchUp.Policy))
+	}
+
+	errs = append(errs, c.Notifier.validate()...)
+
+	// Имена топиков и групп ступеней ретраев строятся из задержки с точностью до секунды,
+	// поэтому дробные и повторяющиеся задержки дали бы двум ступеням одну группу
+	seen := make(map[time.Duration]bool, len(c.Kafka.RetryDelays))
+	for _, d := range c.Kafka.RetryDelays {
+		switch {
+		case d%time.Second != 0:
+			errs = append(errs, fmt.Errorf("kafka.retry_delays: %s is not a whole number of seconds", d))
+		case seen[d]:
+			errs = append(errs, fmt.Errorf("kafka.retry_delays: duplicate delay %s", d))
+		}
+		seen[d] = true
+	}
+
+	if c.Pool.MinConns > c.Pool.MaxConns {
+		errs = append(errs, fmt.Errorf("pool.min_conns: must not exceed pool.max_conns (%d)", c.Pool.MaxConns))
+	}
+
+	return errs
 }
 
-func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
-	valueStr := os.Getenv(key)
-	if valueStr == "" {
-		return defaultValue
+func (c *NotifierConfig) validate() []error {
+	var errs []error
+
+	for _, role := range strings.Split(c.Roles, ",") {
+		switch strings.TrimSpace(role) {
+		case RoleAPI, RoleScheduler, RoleConsumer:
+		default:
+			errs = append(errs, fmt.Errorf("notifier.roles: unknown role %q", role))
+		}
 	}
-	value, err := time.ParseDuration(valueStr)
-	if err != nil {
-		return defaultValue
+
+	backends := []struct {
+		key, value string
+		allowed    []string
+	}{
+		{"notifier.db", c.DB, []string{BackendPostgres, BackendMemory}},
+		{"notifier.cache", c.Cache, []string{BackendRedis, BackendMemory}},
+		{"notifier.queue", c.Queue, []string{BackendKafka, BackendMemory}},
+		{"notifier.mailer", c.Mailer, []string{MailerSMTP, MailerLog, MailerFile}},
 	}
-	return value
+	for _, b := range backends {
+		if !slices.Contains(b.allowed, b.value) {
+			errs = append(errs, fmt.Errorf("%s: unknown value %q, expected one of %s", b.key, b.value, strings.Join(b.allowed, ", ")))
+		}
+	}
+
+	// Данные в памяти видны только этому процессу, поэтому их некому обработать снаружи
+	if c.DB == BackendMemory && !c.HasRole(RoleScheduler) {
+		errs = append(errs, fmt.Errorf("notifier.roles: db=memory requires the %s role", RoleScheduler))
+	}
+	if c.Queue == BackendMemory && !c.HasRole(RoleConsumer) {
+		errs = append(errs, fmt.Errorf("notifier.roles: queue=memory requires the %s role", RoleConsumer))
+	}
+	if c.Mailer == MailerFile && c.MailFile == "" {
+		errs = append(errs, fmt.Errorf("notifier.mail_file: required when notifier.mailer=file"))
+	}
+
+	return errs
 }
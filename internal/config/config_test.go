@@ -0,0 +1,308 @@
+package config
+
+import (
+	"bytes"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/entity"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("ENV_FILE", filepath.Join(t.TempDir(), "missing.env"))
+
+	t.Run("defaults", func(t *testing.T) {
+		cfg, err := Load(nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, Default(), cfg)
+	})
+
+	t.Run("layers", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", `
+server:
+  port: 9000
+  shutdown_timeout: 30s
+kafka:
+  retry_delays: [5s, 1m]
+scheduler:
+  catchup:
+    policy: spread
+`)
+		t.Setenv("SERVER_PORT", "9001")
+
+		cfg, err := Load([]string{"-config", path})
+		require.NoError(t, err)
+		assert.Equal(t, "9001", cfg.Server.Port)
+		assert.Equal(t, 30*time.Second, cfg.Server.ShutdownTimeout)
+		assert.Equal(t, []time.Duration{5 * time.Second, time.Minute}, cfg.Kafka.RetryDelays)
+		assert.Equal(t, "spread", cfg.Scheduler.CatchUp.Policy)
+
+		cfg, err = Load([]string{"-config", path, "-server.port=9002"})
+		require.NoError(t, err)
+		assert.Equal(t, "9002", cfg.Server.Port)
+	})
+
+	t.Run("duration in seconds", func(t *testing.T) {
+		t.Setenv("SERVER_SHUTDOWN_TIMEOUT", "20")
+
+		cfg, err := Load(nil)
+		require.NoError(t, err)
+		assert.Equal(t, 20*time.Second, cfg.Server.ShutdownTimeout)
+	})
+
+	t.Run("empty env overrides default", func(t *testing.T) {
+		t.Setenv("MAIL_USER", "")
+		t.Setenv("KAFKA_RETRY_DELAYS", "")
+
+		cfg, err := Load(nil)
+		require.NoError(t, err)
+		assert.Empty(t, cfg.Mail.User)
+		assert.Empty(t, cfg.Kafka.RetryDelays)
+
+		t.Setenv("KAFKA_TOPIC", "")
+		_, err = Load(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "kafka.topic: must not be empty (from env KAFKA_TOPIC)")
+	})
+
+	t.Run("env example", func(t *testing.T) {
+		// Переменные ставим через t.Setenv, чтобы они не достались остальным тестам
+		values, err := godotenv.Read(filepath.Join("..", "..", ".env.example"))
+		require.NoError(t, err)
+		for key, value := range values {
+			t.Setenv(key, value)
+		}
+
+		cfg, err := Load(nil)
+		require.NoError(t, err)
+		assert.Equal(t, 15*time.Second, cfg.Server.ShutdownTimeout)
+	})
+
+	t.Run("toml", func(t *testing.T) {
+		t.Setenv("CONFIG_FILE", writeFile(t, "config.toml", `
+[log]
+format = "json"
+redact = "none"
+
+[cache]
+local_size = 1000
+`))
+
+		cfg, err := Load(nil)
+		require.NoError(t, err)
+		assert.Equal(t, "json", cfg.Logger.Format)
+		assert.False(t, cfg.Logger.Redact.Enabled())
+		assert.Equal(t, 1000, cfg.Cache.LocalSize)
+	})
+
+	t.Run("reports every bad key", func(t *testing.T) {
+		path := writeFile(t, "config.yaml", "server:\n  prot: 8080\n")
+		t.Setenv("REDIS_DB", "zero")
+		t.Setenv("CACHE_TTL", "-1s")
+		t.Setenv("SCHEDULER_BACKEND", "mysql")
+
+		_, err := Load([]string{"-config", path, "-worker.http_port=70000"})
+
+		require.Error(t, err)
+		for _, want := range []string{
+			"server.prot: unknown key",
+			`redis.db: invalid value "zero" from env REDIS_DB`,
+			"cache.ttl: must be positive (from env CACHE_TTL)",
+			"worker.http_port: must be a port number 1-65535 (from flag -worker.http_port)",
+			`scheduler.backend: unknown scheduler backend "mysql"`,
+		} {
+			assert.Contains(t, err.Error(), want)
+		}
+	})
+
+	t.Run("retry delays", func(t *testing.T) {
+		t.Setenv("KAFKA_RETRY_DELAYS", "1s,1500ms,1m,60s")
+
+		_, err := Load(nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "kafka.retry_delays: 1.5s is not a whole number of seconds")
+		assert.Contains(t, err.Error(), "kafka.retry_delays: duplicate delay 1m0s")
+	})
+
+	t.Run("notifier roles", func(t *testing.T) {
+		t.Setenv("NOTIFIER_QUEUE", "memory")
+		t.Setenv("NOTIFIER_ROLES", "api,scheduler")
+
+		_, err := Load(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "notifier.roles: queue=memory requires the consumer role")
+
+		cfg, err := Load([]string{"-notifier.roles=api, scheduler, consumer"})
+		require.NoError(t, err)
+		assert.True(t, cfg.Notifier.HasRole(RoleConsumer))
+	})
+}
+
+func TestSecretFiles(t *testing.T) {
+	t.Setenv("ENV_FILE", filepath.Join(t.TempDir(), "missing.env"))
+
+	t.Run("reads file", func(t *testing.T) {
+		t.Setenv("MAIL_PASSWORD_FILE", writeFile(t, "mail", "smtp-pass\n"))
+
+		cfg, err := Load(nil)
+		require.NoError(t, err)
+		assert.Equal(t, "smtp-pass", cfg.Mail.Password)
+	})
+
+	t.Run("rejects both", func(t *testing.T) {
+		t.Setenv("REDIS_PASSWORD", "inline")
+		t.Setenv("REDIS_PASSWORD_FILE", writeFile(t, "redis", "from-file"))
+
+		_, err := Load(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "redis.password: set either env REDIS_PASSWORD or env REDIS_PASSWORD_FILE, not both")
+	})
+
+	t.Run("empty value with file", func(t *testing.T) {
+		t.Setenv("REDIS_PASSWORD", "")
+		t.Setenv("REDIS_PASSWORD_FILE", writeFile(t, "redis", "from-file"))
+
+		cfg, err := Load(nil)
+		require.NoError(t, err)
+		assert.Equal(t, "from-file", cfg.Redis.Password)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Setenv("DB_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+
+		_, err := Load(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "database.password_file")
+	})
+}
+
+func TestDSN(t *testing.T) {
+	db := DatabaseConfig{User: "app", Password: "p@ss/w:rd%41", Host: "postgres", Port: "5432", Name: "notify"}
+
+	u, err := url.Parse(db.DSN())
+
+	require.NoError(t, err)
+	password, _ := u.User.Password()
+	assert.Equal(t, "p@ss/w:rd%41", password)
+	assert.Equal(t, "app", u.User.Username())
+	assert.Equal(t, "postgres:5432", u.Host)
+	assert.Equal(t, "/notify", u.Path)
+}
+
+func TestPrint(t *testing.T) {
+	t.Setenv("ENV_FILE", filepath.Join(t.TempDir(), "missing.env"))
+	t.Setenv("DB_PASSWORD", "s3cret")
+
+	var buf bytes.Buffer
+	require.NoError(t, Print(&buf, []string{"-mail.port=587"}))
+
+	out := buf.String()
+	assert.NotContains(t, out, "s3cret")
+	assert.Contains(t, out, "password: '***' # env DB_PASSWORD")
+	assert.Contains(t, out, "port: 587 # flag -mail.port")
+	assert.Contains(t, out, "  catchup:\n    policy: all\n")
+
+	// Вывод можно использовать как файл конфигурации
+	path := writeFile(t, "printed.yaml", out)
+	t.Setenv("DB_PASSWORD", "")
+	cfg, err := Load([]string{"-config", path})
+	require.NoError(t, err)
+	assert.Equal(t, 587, cfg.Mail.Port)
+}
+
+func TestMailIdentities(t *testing.T) {
+	t.Run("default from env and file", func(t *testing.T) {
+		cfg := MailConfig{
+			User:         "smtp-user@example.com",
+			FromName:     "Notifier",
+			DKIMSelector: "s1",
+			DKIMKeyFile:  "/keys/example.pem",
+			IdentitiesFile: writeFile(t, "identities.yaml", `
+senders:
+  - name: billing
+    from_name: Биллинг
+    address: billing@example.org
+    reply_to: [support@example.org]
+dkim:
+  - domain: example.org
+    selector: s2
+    key_file: /keys/example.org.pem
+`),
+		}
+
+		ids, err := cfg.Identities()
+
+		require.NoError(t, err)
+		assert.Equal(t, Identities{
+			Senders: []entity.Identity{
+				{Name: entity.DefaultSender, FromName: "Notifier", Address: "smtp-user@example.com"},
+				{Name: "billing", FromName: "Биллинг", Address: "billing@example.org", ReplyTo: []string{"support@example.org"}},
+			},
+			DKIM: []DKIMKey{
+				{Domain: "example.com", Selector: "s1", KeyFile: "/keys/example.pem"},
+				{Domain: "example.org", Selector: "s2", KeyFile: "/keys/example.org.pem"},
+			},
+		}, ids)
+		assert.Equal(t, []string{entity.DefaultSender, "billing"}, ids.Names())
+	})
+
+	t.Run("file overrides default", func(t *testing.T) {
+		cfg := MailConfig{
+			From: "noreply@example.com",
+			IdentitiesFile: writeFile(t, "identities.yaml", `
+senders:
+  - name: default
+    address: hello@example.com
+`),
+		}
+
+		ids, err := cfg.Identities()
+
+		require.NoError(t, err)
+		assert.Equal(t, []entity.Identity{{Name: entity.DefaultSender, Address: "hello@example.com"}}, ids.Senders)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cfg := MailConfig{
+			DKIMKeyFile: "/keys/example.pem",
+			IdentitiesFile: writeFile(t, "identities.yaml", `
+senders:
+  - name: Billing
+    address: billing@example.com
+  - name: ops
+    address: not-an-email
+  - name: ops
+    address: ops@example.com
+`),
+		}
+
+		_, err := cfg.Identities()
+
+		require.Error(t, err)
+		for _, msg := range []string{
+			`senders.1.name: invalid name "Billing"`,
+			`senders.2.address: invalid email "not-an-email"`,
+			`senders.3.name: duplicate name "ops"`,
+			"dkim.0.domain: required",
+			"dkim.0.selector: required",
+		} {
+			assert.ErrorContains(t, err, msg)
+		}
+	})
+}
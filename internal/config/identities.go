@@ -0,0 +1,141 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"delayed-notifier/internal/entity"
+)
+
+// Identities — отправители писем и ключи DKIM для их доменов.
+type Identities struct {
+	Senders []entity.Identity `yaml:"senders"`
+	DKIM    []DKIMKey         `yaml:"dkim"`
+}
+
+// DKIMKey — закрытый ключ (PEM, RSA или Ed25519) для подписи писем домена.
+// Открытый ключ публикуется в TXT-записи <selector>._domainkey.<domain>.
+type DKIMKey struct {
+	Domain   string `yaml:"domain"`
+	Selector string `yaml:"selector"`
+	KeyFile  string `yaml:"key_file"`
+}
+
+// Identities собирает отправителей: отправитель по умолчанию из From/FromName
+// (или User) и ключ из DKIMSelector/DKIMKeyFile, затем содержимое IdentitiesFile.
+// Отправитель default из файла заменяет заданного переменными окружения.
+//
+//	senders:
+//	  - name: billing
+//	    from_name: Биллинг
+//	    address: billing@example.com
+//	    reply_to: [support@example.com]
+//	dkim:
+//	  - domain: example.com
+//	    selector: notifier
+//	    key_file: /run/secrets/dkim-example.com.pem
+func (c *MailConfig) Identities() (Identities, error) {
+	address := c.From
+	if address == "" {
+		address = c.User
+	}
+	ids := Identities{Senders: []entity.Identity{{Name: entity.DefaultSender, FromName: c.FromName, Address: address}}}
+	if c.DKIMKeyFile != "" {
+		ids.DKIM = append(ids.DKIM, DKIMKey{Domain: domainOf(address), Selector: c.DKIMSelector, KeyFile: c.DKIMKeyFile})
+	}
+
+	// MAIL_USER бывает логином, а не адресом, поэтому проверяется только явный From
+	var errs []error
+	if c.From != "" && !entity.ValidEmail(c.From) {
+		errs = append(errs, fmt.Errorf("mail.from: invalid email %q", c.From))
+	}
+	fromEnv := true
+
+	if c.IdentitiesFile != "" {
+		data, err := os.ReadFile(c.IdentitiesFile)
+		if err != nil {
+			return Identities{}, fmt.Errorf("mail.identities_file: %w", err)
+		}
+		var file Identities
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return Identities{}, fmt.Errorf("mail.identities_file: %w", err)
+		}
+		for _, id := range file.Senders {
+			if id.Name == entity.DefaultSender {
+				ids.Senders[0], fromEnv = id, false
+				continue
+			}
+			ids.Senders = append(ids.Senders, id)
+		}
+		ids.DKIM = append(ids.DKIM, file.DKIM...)
+	}
+
+	if err := errors.Join(append(errs, ids.validate(fromEnv))...); err != nil {
+		return Identities{}, err
+	}
+	return ids, nil
+}
+
+// Names возвращает имена отправителей, которых можно указать в уведомлении.
+func (ids Identities) Names() []string {
+	names := make([]string, 0, len(ids.Senders))
+	for _, id := range ids.Senders {
+		names = append(names, id.Name)
+	}
+	return names
+}
+
+// validate проверяет отправителей и ключи; fromEnv — адрес отправителя по умолчанию
+// взят из MailConfig и уже проверен.
+func (ids Identities) validate(fromEnv bool) error {
+	var errs []error
+
+	names := make(map[string]bool, len(ids.Senders))
+	for i, id := range ids.Senders {
+		prefix := fmt.Sprintf("mail.identities.senders.%d", i)
+		switch {
+		case !entity.ValidIdentityName(id.Name):
+			errs = append(errs, fmt.Errorf("%s.name: invalid name %q", prefix, id.Name))
+		case names[id.Name]:
+			errs = append(errs, fmt.Errorf("%s.name: duplicate name %q", prefix, id.Name))
+		}
+		names[id.Name] = true
+		if !(fromEnv && i == 0) && !entity.ValidEmail(id.Address) {
+			errs = append(errs, fmt.Errorf("%s.address: invalid email %q", prefix, id.Address))
+		}
+		for _, addr := range id.ReplyTo {
+			if !entity.ValidEmail(addr) {
+				errs = append(errs, fmt.Errorf("%s.reply_to: invalid email %q", prefix, addr))
+			}
+		}
+	}
+
+	domains := make(map[string]bool, len(ids.DKIM))
+	for i, key := range ids.DKIM {
+		prefix := fmt.Sprintf("mail.identities.dkim.%d", i)
+		switch {
+		case key.Domain == "":
+			errs = append(errs, fmt.Errorf("%s.domain: required", prefix))
+		case domains[strings.ToLower(key.Domain)]:
+			errs = append(errs, fmt.Errorf("%s.domain: duplicate domain %q", prefix, key.Domain))
+		}
+		domains[strings.ToLower(key.Domain)] = true
+		if key.Selector == "" {
+			errs = append(errs, fmt.Errorf("%s.selector: required", prefix))
+		}
+		if key.KeyFile == "" {
+			errs = append(errs, fmt.Errorf("%s.key_file: required", prefix))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func domainOf(address string) string {
+	_, domain, _ := strings.Cut(address, "@")
+	return strings.ToLower(domain)
+}
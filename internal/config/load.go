@@ -0,0 +1,392 @@
+package config
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+
+	"delayed-notifier/internal/secret"
+)
+
+const (
+	sourceDefault = "default"
+	sourceFile    = "file"
+	sourceEnv     = "env"
+	sourceFlag    = "flag"
+	sourceSecret  = "secret file"
+
+	defaultEnvFile = "/.env"
+)
+
+var (
+	durationType  = reflect.TypeOf(time.Duration(0))
+	durationsType = reflect.TypeOf([]time.Duration(nil))
+)
+
+// field — лист схемы конфигурации.
+type field struct {
+	key    string
+	env    string
+	secret bool
+	rule   string
+	value  reflect.Value
+}
+
+// Load собирает конфигурацию по слоям, каждый следующий перекрывает предыдущий:
+// значения по умолчанию, файл YAML/TOML (флаг -config или CONFIG_FILE),
+// переменные окружения (в том числе из ENV_FILE, по умолчанию /.env, если он есть)
+// и флаги вида -server.port=8080. Возвращает все ошибки сразу.
+func Load(args []string) (*Config, error) {
+	cfg, _, err := load(args)
+	return cfg, err
+}
+
+func load(args []string) (*Config, map[string]string, error) {
+	cfg := Default()
+	schema := fields(cfg)
+	sources := make(map[string]string, len(schema))
+
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	configFile := fs.String("config", "", "path to YAML or TOML config file")
+	flagValues := make(map[string]*string, len(schema))
+	for _, f := range schema {
+		flagValues[f.key] = fs.String(f.key, "", "overrides env "+f.env)
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, nil, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	setFlags := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+
+	var errs []error
+
+	envFile := getEnv("ENV_FILE", defaultEnvFile)
+	if _, err := os.Stat(envFile); err == nil {
+		// godotenv не перезаписывает уже заданные переменные окружения
+		if err := godotenv.Load(envFile); err != nil {
+			errs = append(errs, fmt.Errorf("env file %s: %w", envFile, err))
+		}
+	}
+
+	path := *configFile
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	var fileValues map[string]string
+	if path != "" {
+		values, err := readFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("config file %s: %w", path, err))
+		}
+		fileValues = values
+	}
+	known := make(map[string]bool, len(schema))
+	for _, f := range schema {
+		known[f.key] = true
+	}
+	for key := range fileValues {
+		if !known[key] {
+			errs = append(errs, fmt.Errorf("%s: unknown key in config file %s", key, path))
+		}
+	}
+
+	for _, f := range schema {
+		sources[f.key] = sourceDefault
+		failed := false
+		apply := func(raw, source string) {
+			if err := setValue(f.value, raw); err != nil {
+				errs = append(errs, fmt.Errorf("%s: invalid value %q from %s: %w", f.key, raw, describe(f, source), err))
+				failed = true
+				return
+			}
+			sources[f.key] = source
+		}
+
+		if raw, ok := fileValues[f.key]; ok {
+			apply(raw, sourceFile)
+		}
+		// Заданная, но пустая переменная тоже перекрывает значение: так можно очистить строку по умолчанию
+		if raw, ok := os.LookupEnv(f.env); f.env != "" && ok {
+			apply(raw, sourceEnv)
+		}
+		if setFlags[f.key] {
+			apply(*flagValues[f.key], sourceFlag)
+		}
+
+		if !failed {
+			if err := checkRule(f); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w (from %s)", f.key, err, describe(f, sources[f.key])))
+			}
+		}
+	}
+
+	errs = append(errs, resolveSecretFiles(schema, sources)...)
+	errs = append(errs, cfg.validate()...)
+	if len(errs) > 0 {
+		return nil, nil, fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+	return cfg, sources, nil
+}
+
+// resolveSecretFiles подставляет в секретные поля содержимое файлов из соседних
+// ключей *_file (переменные окружения *_FILE).
+func resolveSecretFiles(schema []field, sources map[string]string) []error {
+	byKey := make(map[string]field, len(schema))
+	for _, f := range schema {
+		byKey[f.key] = f
+	}
+
+	var errs []error
+	for _, f := range schema {
+		file, ok := byKey[f.key+"_file"]
+		if !f.secret || !ok || file.value.String() == "" {
+			continue
+		}
+		// Пустой пароль рядом с файлом не считается вторым источником
+		if sources[f.key] != sourceDefault && f.value.String() != "" {
+			errs = append(errs, fmt.Errorf("%s: set either %s or %s, not both", f.key, describe(f, sources[f.key]), describe(file, sources[file.key])))
+			continue
+		}
+
+		path := file.value.String()
+		value, err := secret.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w (from %s)", file.key, err, describe(file, sources[file.key])))
+			continue
+		}
+		f.value.SetString(value)
+		sources[f.key] = sourceSecret
+	}
+	return errs
+}
+
+func describe(f field, source string) string {
+	switch source {
+	case sourceEnv:
+		return "env " + f.env
+	case sourceFlag:
+		return "flag -" + f.key
+	default:
+		return source
+	}
+}
+
+func fields(cfg *Config) []field {
+	var out []field
+	walk(reflect.ValueOf(cfg).Elem(), "", &out)
+	return out
+}
+
+func walk(v reflect.Value, prefix string, out *[]field) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		key := sf.Tag.Get("key")
+		if key == "" {
+			continue
+		}
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		fv := v.Field(i)
+		if _, leaf := fv.Addr().Interface().(encoding.TextUnmarshaler); fv.Kind() == reflect.Struct && !leaf {
+			walk(fv, key, out)
+			continue
+		}
+		*out = append(*out, field{
+			key:    key,
+			env:    sf.Tag.Get("env"),
+			secret: sf.Tag.Get("secret") == "true",
+			rule:   sf.Tag.Get("validate"),
+			value:  fv,
+		})
+	}
+}
+
+func setValue(v reflect.Value, raw string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(raw))
+	}
+
+	switch v.Type() {
+	case durationType:
+		d, err := parseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	case durationsType:
+		var ds []time.Duration
+		for _, part := range strings.Split(raw, ",") {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			d, err := parseDuration(part)
+			if err != nil {
+				return err
+			}
+			ds = append(ds, d)
+		}
+		v.Set(reflect.ValueOf(ds))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return errors.New("expected integer")
+		}
+		v.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return errors.New("expected boolean")
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// parseDuration разбирает длительность вида "15s"; целое число без единицы — секунды,
+// как SERVER_SHUTDOWN_TIMEOUT=15 в конфигурациях до перехода на длительности.
+func parseDuration(raw string) (time.Duration, error) {
+	if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Duration(n) * time.Second, nil
+	}
+	return time.ParseDuration(raw)
+}
+
+func formatValue(v reflect.Value) string {
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		if err != nil {
+			return err.Error()
+		}
+		return string(text)
+	}
+
+	switch v.Type() {
+	case durationType:
+		return time.Duration(v.Int()).String()
+	case durationsType:
+		parts := make([]string, 0, v.Len())
+		for _, d := range v.Interface().([]time.Duration) {
+			parts = append(parts, d.String())
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func checkRule(f field) error {
+	switch f.rule {
+	case "":
+		return nil
+	case "required":
+		if f.value.String() == "" {
+			return errors.New("must not be empty")
+		}
+	case "positive", "nonnegative":
+		var values []int64
+		if f.value.Type() == durationsType {
+			for _, d := range f.value.Interface().([]time.Duration) {
+				values = append(values, int64(d))
+			}
+		} else {
+			values = append(values, f.value.Int())
+		}
+		for _, n := range values {
+			if f.rule == "positive" && n <= 0 {
+				return errors.New("must be positive")
+			}
+			if n < 0 {
+				return errors.New("must not be negative")
+			}
+		}
+	case "port":
+		port, err := strconv.Atoi(formatValue(f.value))
+		if err != nil || port < 1 || port > 65535 {
+			return errors.New("must be a port number 1-65535")
+		}
+	default:
+		return fmt.Errorf("unknown validation rule %q", f.rule)
+	}
+	return nil
+}
+
+// readFile читает YAML или TOML и возвращает значения по ключам вида "server.port".
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make(map[string]any)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported config format %q, expected .yaml, .yml or .toml", ext)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	flatten("", raw, values)
+	return values, nil
+}
+
+func flatten(prefix string, raw map[string]any, out map[string]string) {
+	for k, v := range raw {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch v := v.(type) {
+		case map[string]any:
+			flatten(key, v, out)
+		case []any:
+			parts := make([]string, 0, len(v))
+			for _, item := range v {
+				parts = append(parts, fmt.Sprint(item))
+			}
+			out[key] = strings.Join(parts, ",")
+		case nil:
+			out[key] = ""
+		default:
+			out[key] = fmt.Sprint(v)
+		}
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
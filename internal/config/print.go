@@ -0,0 +1,69 @@
+package config
+
+import (
+	"io"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const secretMask = "***"
+
+// Print загружает конфигурацию так же, как Load, и выводит действующие значения
+// в YAML с источником каждого значения; секреты скрываются.
+func Print(w io.Writer, args []string) error {
+	cfg, sources, err := load(args)
+	if err != nil {
+		return err
+	}
+
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	sections := map[string]*yaml.Node{"": root}
+	for _, f := range fields(cfg) {
+		parent := section(sections, f.key)
+
+		value := formatValue(f.value)
+		if f.secret && value != "" {
+			value = secretMask
+		}
+		node := &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+		switch f.value.Kind() {
+		case reflect.Bool, reflect.Int, reflect.Int64:
+		default:
+			node.Tag = "!!str"
+		}
+		if source := sources[f.key]; source != sourceDefault {
+			node.LineComment = describe(f, source)
+		}
+
+		name := f.key[strings.LastIndexByte(f.key, '.')+1:]
+		parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, node)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// section возвращает (создавая при необходимости) узел секции, в которой лежит key.
+func section(sections map[string]*yaml.Node, key string) *yaml.Node {
+	dot := strings.LastIndexByte(key, '.')
+	if dot < 0 {
+		return sections[""]
+	}
+	path := key[:dot]
+	if node, ok := sections[path]; ok {
+		return node
+	}
+
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	parent := section(sections, path)
+	name := path[strings.LastIndexByte(path, '.')+1:]
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, node)
+	sections[path] = node
+	return node
+}
@@ -0,0 +1,88 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"delayed-notifier/internal/controller"
+	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/requestid"
+)
+
+// LocalConsumer обрабатывает уведомления из очереди в памяти процесса
+// (memory.NotifyQueue). Ретраи идут с теми же задержками, что и ступени Kafka,
+// но без DLQ: после последней неудачной попытки уведомление остаётся в статусе failed.
+type LocalConsumer struct {
+	notifies <-chan entity.Notify
+	delays   []time.Duration
+	service  controller.NotifyService
+	logger   *slog.Logger
+}
+
+func NewLocalConsumer(notifies <-chan entity.Notify, delays []time.Duration, service controller.NotifyService, logger *slog.Logger) *LocalConsumer {
+	return &LocalConsumer{
+		notifies: notifies,
+		delays:   delays,
+		service:  service,
+		logger:   logger,
+	}
+}
+
+func (c *LocalConsumer) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info("local consumer stopped")
+			return
+		case notify := <-c.notifies:
+			msgCtx := requestid.NewContext(ctx, notify.RequestID)
+			if c.process(msgCtx, notify, 0) {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.retry(msgCtx, notify)
+			}()
+		}
+	}
+}
+
+// retry повторяет обработку с задержками delays, пока она не удастся
+func (c *LocalConsumer) retry(ctx context.Context, notify entity.Notify) {
+	for attempt, delay := range c.delays {
+		c.logger.InfoContext(ctx, "notify scheduled for retry",
+			slog.String("notify_id", notify.ID),
+			slog.Duration("delay", delay),
+		)
+		if !waitUntil(ctx, time.Now().Add(delay)) {
+			return
+		}
+		if c.process(ctx, notify, attempt+1) {
+			return
+		}
+	}
+	c.logger.WarnContext(ctx, "notify retries exhausted",
+		slog.String("notify_id", notify.ID),
+	)
+}
+
+func (c *LocalConsumer) process(ctx context.Context, notify entity.Notify, attempt int) bool {
+	if err := c.service.ProcessNotify(ctx, notify); err != nil {
+		c.logger.ErrorContext(ctx, "failed to process notify message",
+			slog.String("notify_id", notify.ID),
+			slog.Int("attempt", attempt),
+			slog.Any("error", err),
+		)
+		// Постоянные отказы сервера повтор не исправит
+		return errors.Is(err, entity.ErrPermanentFailure)
+	}
+	c.logger.InfoContext(ctx, "successfully sent notify", slog.String("notify_id", notify.ID))
+	return true
+}
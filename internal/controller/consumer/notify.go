@@ -3,48 +3,110 @@ package consumer
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/segmentio/kafka-go"
 
 	"delayed-notifier/internal/controller"
 	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/redact"
+	"delayed-notifier/internal/requestid"
 )
 
+const (
+	HeaderNotBefore    = "x-not-before"
+	HeaderRetryAttempt = "x-retry-attempt"
+)
+
+type RetryTier struct {
+	Topic string
+	Delay time.Duration
+}
+
+// Pipeline описывает цепочку топиков: основной, ступени ретраев и DLQ.
+type Pipeline struct {
+	Main    string
+	Retries []RetryTier
+	DLQ     string
+}
+
+func NewPipeline(topic string, delays []time.Duration) Pipeline {
+	p := Pipeline{Main: topic, DLQ: topic + "-dlq"}
+	for _, d := range delays {
+		p.Retries = append(p.Retries, RetryTier{
+			Topic: topic + "-retry-" + shortDuration(d),
+			Delay: d,
+		})
+	}
+	return p
+}
+
+// Stages возвращает количество стадий обработки: основной топик и все ступени ретраев.
+func (p Pipeline) Stages() int {
+	return len(p.Retries) + 1
+}
+
 type OrderConsumer struct {
-	reader    *kafka.Reader
-	dlqWriter *kafka.Writer
-	service   controller.NotifyService
-	logger    *slog.Logger
+	reader      *kafka.Reader
+	retryWriter *kafka.Writer
+	dlqWriter   *kafka.Writer
+	pipeline    Pipeline
+	stage       int
+	service     controller.NotifyService
+	dlqRedact   redact.Policy
+	logger      *slog.Logger
 }
 
-func NewOrderConsumer(brokers, topic string, service controller.NotifyService, logger *slog.Logger) *OrderConsumer {
+// NewOrderConsumer создаёт консьюмер для стадии stage пайплайна:
+// 0 — основной топик, i > 0 — ступень ретраев pipeline.Retries[i-1].
+// dlqRedact задаёт, какие данные маскировать в сообщениях, уходящих в DLQ.
+func NewOrderConsumer(brokers string, pipeline Pipeline, stage int, service controller.NotifyService, dlqRedact redact.Policy, logger *slog.Logger) *OrderConsumer {
+	topic, groupID := pipeline.Main, "notify-worker-group"
+	if stage > 0 {
+		topic = pipeline.Retries[stage-1].Topic
+		groupID += "-retry-" + shortDuration(pipeline.Retries[stage-1].Delay)
+	}
+
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:        []string{brokers},
 		Topic:          topic,
-		GroupID:        "notify-worker-group",
+		GroupID:        groupID,
 		MinBytes:       10e3,
 		MaxBytes:       10e6,
 		CommitInterval: 0,
 	})
 
-	dlqWriter := kafka.NewWriter(kafka.WriterConfig{
+	var retryWriter *kafka.Writer
+	if stage < len(pipeline.Retries) {
+		retryWriter = newWriter(brokers, pipeline.Retries[stage].Topic)
+	}
+
+	return &OrderConsumer{
+		reader:      reader,
+		retryWriter: retryWriter,
+		dlqWriter:   newWriter(brokers, pipeline.DLQ),
+		pipeline:    pipeline,
+		stage:       stage,
+		service:     service,
+		dlqRedact:   dlqRedact,
+		logger:      logger.With(slog.Int("stage", stage)),
+	}
+}
+
+func newWriter(brokers, topic string) *kafka.Writer {
+	return kafka.NewWriter(kafka.WriterConfig{
 		Brokers:      []string{brokers},
-		Topic:        topic + "-dlq",
+		Topic:        topic,
 		Balancer:     &kafka.LeastBytes{},
 		RequiredAcks: 1,
 		Async:        false,
 		BatchTimeout: 50 * time.Millisecond,
 		MaxAttempts:  3,
 	})
-
-	return &OrderConsumer{
-		reader:    reader,
-		dlqWriter: dlqWriter,
-		service:   service,
-		logger:    logger,
-	}
 }
 
 func (c *OrderConsumer) Start(ctx context.Context) {
@@ -54,6 +116,11 @@ func (c *OrderConsumer) Start(ctx context.Context) {
 		} else {
 			c.logger.Info("notify consumer closed")
 		}
+		if c.retryWriter != nil {
+			if err := c.retryWriter.Close(); err != nil {
+				c.logger.Error("failed to close retry writer", slog.Any("error", err))
+			}
+		}
 		if err := c.dlqWriter.Close(); err != nil {
 			c.logger.Error("failed to close dlq writer", slog.Any("error", err))
 		}
@@ -70,63 +137,129 @@ func (c *OrderConsumer) Start(ctx context.Context) {
 			continue
 		}
 
-		c.logger.Info("received message",
+		// ID запроса, создавшего уведомление, связывает логи API и воркера
+		msgCtx := requestid.NewContext(ctx, requestIDFromHeaders(m.Headers))
+		c.logger.InfoContext(msgCtx, "received message",
 			slog.String("topic", m.Topic),
 			slog.Int("partition", m.Partition),
 			slog.Int64("offset", m.Offset),
 		)
 
+		// На ступенях ретраев ждём наступления not-before. Все сообщения ступени имеют
+		// одинаковую задержку, поэтому внутри партиции они упорядочены по времени.
+		if notBefore, ok := notBeforeFromHeaders(m.Headers); ok {
+			if !waitUntil(ctx, notBefore) {
+				c.logger.InfoContext(msgCtx, "consumer context cancelled, exiting")
+				return
+			}
+		}
+
 		var notify entity.Notify
 		if err := json.Unmarshal(m.Value, &notify); err != nil {
-			c.logger.Warn("invalid json message",
+			c.logger.WarnContext(msgCtx, "invalid json message",
 				slog.Any("error", err),
 				slog.Int64("offset", m.Offset),
 			)
 			// Отправляем невалидное сообщение в DLQ
-			if err := c.sendToDLQ(ctx, m, "invalid_json", err.Error()); err != nil {
-				c.logger.Error("failed to send invalid message to DLQ", slog.Any("error", err))
+			if err := c.sendToDLQ(ctx, m, entity.DLQReasonInvalidJSON, err.Error()); err != nil {
+				c.logger.ErrorContext(msgCtx, "failed to send invalid message to DLQ", slog.Any("error", err))
 			}
 			// Коммитим сообщение с невалидным JSON
 			if err := c.reader.CommitMessages(ctx, m); err != nil {
-				c.logger.Error("failed to commit invalid message offset", slog.Any("error", err))
+				c.logger.ErrorContext(msgCtx, "failed to commit invalid message offset", slog.Any("error", err))
 			}
 			continue
 		}
 
-		c.logger.Debug("handling notify message", slog.Any("message", notify))
+		if requestid.FromContext(msgCtx) == "" {
+			msgCtx = requestid.NewContext(msgCtx, notify.RequestID)
+		}
+		c.logger.DebugContext(msgCtx, "handling notify message", slog.Any("message", notify))
 
-		if err := c.service.ProcessNotify(ctx, notify); err != nil {
-			c.logger.Error("failed to process notify message",
+		if err := c.service.ProcessNotify(msgCtx, notify); err != nil {
+			c.logger.ErrorContext(msgCtx, "failed to process notify message",
 				slog.String("notify_id", notify.ID),
 				slog.Any("error", err),
 			)
-			// Отправляем неудачное сообщение в DLQ
-			if err := c.sendToDLQ(ctx, m, "processing_failed", err.Error()); err != nil {
-				c.logger.Error("failed to send failed message to DLQ", slog.Any("error", err))
+			// Передаём сообщение на следующую ступень ретраев, после последней — в DLQ.
+			// Постоянные отказы сервера повтор не исправит, история отправки уже в БД
+			if !errors.Is(err, entity.ErrPermanentFailure) {
+				c.handleFailure(msgCtx, m, err)
 			}
 			// Коммитим сообщение даже при ошибке
 			if err := c.reader.CommitMessages(ctx, m); err != nil {
-				c.logger.Error("failed to commit failed message offset", slog.Any("error", err))
+				c.logger.ErrorContext(msgCtx, "failed to commit failed message offset", slog.Any("error", err))
 			}
 			continue
 		}
 
-		c.logger.Info("successfully sent notify",
+		c.logger.InfoContext(msgCtx, "successfully sent notify",
 			slog.String("notify_id", notify.ID),
 		)
 
 		if err := c.reader.CommitMessages(ctx, m); err != nil {
-			c.logger.Error("failed to commit notify offset",
+			c.logger.ErrorContext(msgCtx, "failed to commit notify offset",
 				slog.Any("error", err),
 				slog.Int64("offset", m.Offset),
 			)
 		} else {
-			c.logger.Debug("committed notify offset", slog.Int64("offset", m.Offset))
+			c.logger.DebugContext(msgCtx, "committed notify offset", slog.Int64("offset", m.Offset))
 		}
 	}
 }
 
+func (c *OrderConsumer) handleFailure(ctx context.Context, m kafka.Message, processErr error) {
+	if c.retryWriter == nil {
+		if err := c.sendToDLQ(ctx, m, entity.DLQReasonProcessingFailed, processErr.Error()); err != nil {
+			c.logger.ErrorContext(ctx, "failed to send failed message to DLQ", slog.Any("error", err))
+		}
+		return
+	}
+
+	tier := c.pipeline.Retries[c.stage]
+	if err := c.sendToRetry(ctx, m, tier); err != nil {
+		c.logger.ErrorContext(ctx, "failed to send failed message to retry topic",
+			slog.String("retry_topic", tier.Topic),
+			slog.Any("error", err),
+		)
+		// Если ступень ретраев недоступна, сообщение не должно потеряться
+		if err := c.sendToDLQ(ctx, m, entity.DLQReasonProcessingFailed, processErr.Error()); err != nil {
+			c.logger.ErrorContext(ctx, "failed to send failed message to DLQ", slog.Any("error", err))
+		}
+		return
+	}
+
+	c.logger.InfoContext(ctx, "notify scheduled for retry",
+		slog.String("retry_topic", tier.Topic),
+		slog.Duration("delay", tier.Delay),
+	)
+}
+
+func (c *OrderConsumer) sendToRetry(ctx context.Context, msg kafka.Message, tier RetryTier) error {
+	headers := make([]kafka.Header, 0, len(msg.Headers)+2)
+	for _, h := range msg.Headers {
+		if h.Key != HeaderNotBefore && h.Key != HeaderRetryAttempt {
+			headers = append(headers, h)
+		}
+	}
+	headers = append(headers,
+		kafka.Header{Key: HeaderNotBefore, Value: []byte(time.Now().Add(tier.Delay).UTC().Format(time.RFC3339Nano))},
+		kafka.Header{Key: HeaderRetryAttempt, Value: []byte(strconv.Itoa(c.stage + 1))},
+	)
+
+	return c.retryWriter.WriteMessages(ctx, kafka.Message{
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	})
+}
+
 func (c *OrderConsumer) sendToDLQ(ctx context.Context, msg kafka.Message, reason, errorMsg string) error {
+	// Повтор из DLQ берёт уведомление из БД, поэтому payload нужен только для разбора
+	// и его можно хранить без персональных данных
+	msg.Value = c.dlqRedact.Payload(msg.Value)
+	errorMsg = c.dlqRedact.Text(errorMsg)
+
 	dlqMessage := struct {
 		OriginalMessage kafka.Message `json:"original_message"`
 		Reason          string        `json:"reason"`
@@ -149,3 +282,53 @@ func (c *OrderConsumer) sendToDLQ(ctx context.Context, msg kafka.Message, reason
 		Value: dlqData,
 	})
 }
+
+func requestIDFromHeaders(headers []kafka.Header) string {
+	for _, h := range headers {
+		if h.Key == requestid.KafkaHeader {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func notBeforeFromHeaders(headers []kafka.Header) (time.Time, bool) {
+	for _, h := range headers {
+		if h.Key != HeaderNotBefore {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, string(h.Value))
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// waitUntil блокируется до момента t; возвращает false, если контекст отменён раньше.
+func waitUntil(ctx context.Context, t time.Time) bool {
+	d := time.Until(t)
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func shortDuration(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+}
@@ -0,0 +1,61 @@
+package consumer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPipeline(t *testing.T) {
+	p := NewPipeline("notify-topic", []time.Duration{time.Minute, 10 * time.Minute, time.Hour, 30 * time.Second})
+
+	assert.Equal(t, "notify-topic", p.Main)
+	assert.Equal(t, "notify-topic-dlq", p.DLQ)
+	require.Len(t, p.Retries, 4)
+	assert.Equal(t, "notify-topic-retry-1m", p.Retries[0].Topic)
+	assert.Equal(t, "notify-topic-retry-10m", p.Retries[1].Topic)
+	assert.Equal(t, "notify-topic-retry-1h", p.Retries[2].Topic)
+	assert.Equal(t, "notify-topic-retry-30s", p.Retries[3].Topic)
+	assert.Equal(t, 5, p.Stages())
+}
+
+func TestNotBeforeFromHeaders(t *testing.T) {
+	t.Run("present", func(t *testing.T) {
+		at := time.Date(2025, 10, 25, 10, 10, 10, 555, time.UTC)
+		headers := []kafka.Header{
+			{Key: HeaderRetryAttempt, Value: []byte("1")},
+			{Key: HeaderNotBefore, Value: []byte(at.Format(time.RFC3339Nano))},
+		}
+
+		notBefore, ok := notBeforeFromHeaders(headers)
+
+		assert.True(t, ok)
+		assert.True(t, at.Equal(notBefore))
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, ok := notBeforeFromHeaders(nil)
+		assert.False(t, ok)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, ok := notBeforeFromHeaders([]kafka.Header{{Key: HeaderNotBefore, Value: []byte("soon")}})
+		assert.False(t, ok)
+	})
+}
+
+func TestWaitUntil(t *testing.T) {
+	t.Run("past", func(t *testing.T) {
+		assert.True(t, waitUntil(context.Background(), time.Now().Add(-time.Minute)))
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.False(t, waitUntil(ctx, time.Now().Add(time.Hour)))
+	})
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	"delayed-notifier/internal/entity"
 )
@@ -12,4 +13,53 @@ type NotifyService interface {
 	DeleteNotify(ctx context.Context, notifyID string) error
 	UpdateNotifyStatus(ctx context.Context, notifyID, status string) error
 	ProcessNotify(ctx context.Context, notify entity.Notify) error
+	GetDeliveries(ctx context.Context, notifyID string) ([]entity.Delivery, error)
+	GetAttachment(ctx context.Context, notifyID, attachmentID string) (entity.Attachment, error)
+}
+
+type RecipientListService interface {
+	CreateRecipientList(ctx context.Context, list entity.RecipientList) (entity.RecipientList, error)
+	GetRecipientList(ctx context.Context, listID string) (entity.RecipientList, error)
+	ListRecipientLists(ctx context.Context) ([]entity.RecipientList, error)
+	UpdateRecipientList(ctx context.Context, list entity.RecipientList) (entity.RecipientList, error)
+	DeleteRecipientList(ctx context.Context, listID string) error
+}
+
+type SuppressionService interface {
+	ListSuppressions(ctx context.Context, filter entity.SuppressionFilter) ([]entity.Suppression, error)
+	GetSuppression(ctx context.Context, email string) (entity.Suppression, error)
+	DeleteSuppression(ctx context.Context, email string) error
+}
+
+type DLQService interface {
+	ListDLQ(ctx context.Context, filter entity.DLQFilter) ([]entity.DLQEntry, error)
+	ReplayDLQ(ctx context.Context, req entity.DLQReplayRequest) ([]entity.DLQReplayResult, error)
+}
+
+type AdminService interface {
+	ListNotifies(ctx context.Context, filter entity.NotifyFilter) ([]entity.Notify, error)
+	GetNotifyDetails(ctx context.Context, notifyID string) (entity.NotifyDetails, error)
+	GetNotifyStats(ctx context.Context) (entity.NotifyStats, error)
+	CancelNotify(ctx context.Context, notifyID string, req entity.AdminActionRequest) (entity.AdminResult, error)
+	RescheduleNotify(ctx context.Context, notifyID string, req entity.AdminActionRequest) (entity.AdminResult, error)
+	ResendNotify(ctx context.Context, notifyID string, req entity.AdminActionRequest) (entity.AdminResult, error)
+	RequeueFailed(ctx context.Context, req entity.RequeueRequest) ([]entity.AdminResult, error)
+}
+
+type SchedulerService interface {
+	ScheduleReadyNotifies(ctx context.Context) (entity.ScheduleReport, error)
+	NextSendAt(ctx context.Context) (time.Time, bool, error)
+}
+
+type WheelService interface {
+	GetUpcomingNotifies(ctx context.Context, until time.Time) ([]entity.Notify, error)
+	DispatchNotify(ctx context.Context, notifyID string) error
+}
+
+type LeaderStatus interface {
+	IsLeader() bool
+}
+
+type ReaperService interface {
+	ReapStuckNotifies(ctx context.Context) (entity.ReapReport, error)
 }
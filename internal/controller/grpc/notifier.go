@@ -0,0 +1,338 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	notifierv1 "delayed-notifier/api/notifier/v1"
+	"delayed-notifier/internal/controller"
+	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/requestid"
+)
+
+var statusToProto = map[string]notifierv1.NotifyStatus{
+	entity.StatusScheduled: notifierv1.NotifyStatus_NOTIFY_STATUS_SCHEDULED,
+	entity.StatusQueued:    notifierv1.NotifyStatus_NOTIFY_STATUS_QUEUED,
+	// Отдельного значения в протоколе нет: для клиента отправка — часть пребывания в очереди
+	entity.StatusSending:  notifierv1.NotifyStatus_NOTIFY_STATUS_QUEUED,
+	entity.StatusSent:     notifierv1.NotifyStatus_NOTIFY_STATUS_SENT,
+	entity.StatusFailed:   notifierv1.NotifyStatus_NOTIFY_STATUS_FAILED,
+	entity.StatusSkipped:  notifierv1.NotifyStatus_NOTIFY_STATUS_SKIPPED,
+	entity.StatusCanceled: notifierv1.NotifyStatus_NOTIFY_STATUS_CANCELED,
+}
+
+var statusFromProto = func() map[notifierv1.NotifyStatus]string {
+	m := make(map[notifierv1.NotifyStatus]string, len(statusToProto))
+	for s, p := range statusToProto {
+		if s != entity.StatusSending {
+			m[p] = s
+		}
+	}
+	return m
+}()
+
+// NotifierServer реализует gRPC NotifierService поверх тех же сервисов, что и REST API.
+type NotifierServer struct {
+	notifierv1.UnimplementedNotifierServiceServer
+
+	notify        controller.NotifyService
+	admin         controller.AdminService
+	watchInterval time.Duration
+	logger        *slog.Logger
+}
+
+func NewNotifierServer(notify controller.NotifyService, admin controller.AdminService, watchInterval time.Duration, logger *slog.Logger) *NotifierServer {
+	return &NotifierServer{
+		notify:        notify,
+		admin:         admin,
+		watchInterval: watchInterval,
+		logger:        logger,
+	}
+}
+
+func (s *NotifierServer) CreateNotify(ctx context.Context, req *notifierv1.CreateNotifyRequest) (*notifierv1.Notify, error) {
+	input := entity.Notify{
+		SendAt:         timeFromProto(req.GetSendAt()),
+		Message:        req.GetMessage(),
+		Email:          req.GetEmail(),
+		To:             req.GetTo(),
+		Cc:             req.GetCc(),
+		Bcc:            req.GetBcc(),
+		RecipientLists: req.GetRecipientLists(),
+		Subject:        req.GetSubject(),
+		HTML:           req.GetHtml(),
+		ReplyTo:        req.GetReplyTo(),
+		Headers:        req.GetHeaders(),
+		Attachments:    attachmentsFromProto(req.GetAttachments()),
+		Sender:         req.GetSender(),
+	}
+	if err := input.Validate(); err != nil {
+		s.logger.ErrorContext(ctx, "validation error", slog.Any("error", err))
+		return nil, validationStatus(err)
+	}
+
+	input.Normalize()
+	input.Status = entity.StatusScheduled
+	input.RequestID = requestid.FromContext(ctx)
+	created, err := s.notify.CreateNotify(ctx, input)
+	if err != nil {
+		var verr entity.ValidationError
+		if errors.As(err, &verr) {
+			return nil, validationStatus(err)
+		}
+		s.logger.ErrorContext(ctx, "failed to create notify", slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "failed to create notify")
+	}
+
+	s.logger.InfoContext(ctx, "notify created", slog.String("id", created.ID))
+	return notifyToProto(created), nil
+}
+
+func (s *NotifierServer) GetNotify(ctx context.Context, req *notifierv1.GetNotifyRequest) (*notifierv1.Notify, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	notify, err := s.notify.GetNotify(ctx, req.GetId())
+	if err != nil {
+		return nil, s.serviceError(ctx, "failed to get notify", err)
+	}
+	return notifyToProto(notify), nil
+}
+
+func (s *NotifierServer) ListNotifies(ctx context.Context, req *notifierv1.ListNotifiesRequest) (*notifierv1.ListNotifiesResponse, error) {
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid limit")
+	}
+	filter := entity.NotifyFilter{
+		Email: req.GetEmail(),
+		From:  timeFromProto(req.GetFrom()),
+		To:    timeFromProto(req.GetTo()),
+		Error: req.GetError(),
+		Limit: int(req.GetLimit()),
+	}
+	if req.GetStatus() != notifierv1.NotifyStatus_NOTIFY_STATUS_UNSPECIFIED {
+		st, ok := statusFromProto[req.GetStatus()]
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "invalid status")
+		}
+		filter.Status = st
+	}
+
+	notifies, err := s.admin.ListNotifies(ctx, filter)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to list notifies", slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "failed to list notifies")
+	}
+	resp := &notifierv1.ListNotifiesResponse{Notifies: make([]*notifierv1.Notify, 0, len(notifies))}
+	for _, n := range notifies {
+		resp.Notifies = append(resp.Notifies, notifyToProto(n))
+	}
+	return resp, nil
+}
+
+func (s *NotifierServer) CancelNotify(ctx context.Context, req *notifierv1.CancelNotifyRequest) (*notifierv1.Notify, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	result, err := s.admin.CancelNotify(ctx, req.GetId(), entity.AdminActionRequest{Actor: req.GetActor()})
+	return s.actionResult(ctx, result, err)
+}
+
+func (s *NotifierServer) RescheduleNotify(ctx context.Context, req *notifierv1.RescheduleNotifyRequest) (*notifierv1.Notify, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.GetSendAt() == nil {
+		return nil, status.Error(codes.InvalidArgument, "send_at is required")
+	}
+	result, err := s.admin.RescheduleNotify(ctx, req.GetId(), entity.AdminActionRequest{
+		SendAt: timeFromProto(req.GetSendAt()),
+		Actor:  req.GetActor(),
+	})
+	return s.actionResult(ctx, result, err)
+}
+
+// WatchNotify опрашивает уведомление раз в watchInterval и отправляет его клиенту
+// при каждом изменении версии. Поток завершается на финальном статусе или после удаления.
+func (s *NotifierServer) WatchNotify(req *notifierv1.WatchNotifyRequest, stream notifierv1.NotifierService_WatchNotifyServer) error {
+	ctx := stream.Context()
+	if req.GetId() == "" {
+		return status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	ticker := time.NewTicker(s.watchInterval)
+	defer ticker.Stop()
+
+	var (
+		version int64
+		sent    bool
+	)
+	for {
+		notify, err := s.notify.GetNotify(ctx, req.GetId())
+		switch {
+		case sent && errors.Is(err, entity.ErrNotifyNotFound):
+			return nil
+		case err != nil:
+			return s.serviceError(ctx, "failed to get notify", err)
+		}
+
+		if !sent || notify.Version != version {
+			if err := stream.Send(notifyToProto(notify)); err != nil {
+				return err
+			}
+			sent, version = true, notify.Version
+		}
+		if isFinal(notify.Status) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *NotifierServer) ListDeliveries(ctx context.Context, req *notifierv1.ListDeliveriesRequest) (*notifierv1.ListDeliveriesResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	deliveries, err := s.notify.GetDeliveries(ctx, req.GetId())
+	if err != nil {
+		return nil, s.serviceError(ctx, "failed to get deliveries", err)
+	}
+	resp := &notifierv1.ListDeliveriesResponse{Deliveries: make([]*notifierv1.Delivery, 0, len(deliveries))}
+	for _, d := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, &notifierv1.Delivery{
+			Email:     d.Email,
+			Kind:      d.Kind,
+			ListId:    d.ListID,
+			Status:    d.Status,
+			Attempts:  int32(d.Attempts),
+			LastError: d.LastError,
+			UpdatedAt: timestamppb.New(d.UpdatedAt),
+		})
+	}
+	return resp, nil
+}
+
+// actionResult переводит результат административного действия в ответ: пропущенное
+// действие — FAILED_PRECONDITION, иначе актуальное состояние уведомления.
+func (s *NotifierServer) actionResult(ctx context.Context, result entity.AdminResult, err error) (*notifierv1.Notify, error) {
+	if err != nil {
+		return nil, s.serviceError(ctx, "failed to update notify", err)
+	}
+	if result.Action == entity.AdminActionSkipped {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s (status %s)", result.Error, result.Status)
+	}
+	notify, err := s.notify.GetNotify(ctx, result.NotifyID)
+	if err != nil {
+		return nil, s.serviceError(ctx, "failed to get notify", err)
+	}
+	return notifyToProto(notify), nil
+}
+
+func (s *NotifierServer) serviceError(ctx context.Context, message string, err error) error {
+	if errors.Is(err, entity.ErrNotifyNotFound) {
+		return status.Error(codes.NotFound, "notify not found")
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	s.logger.ErrorContext(ctx, message, slog.Any("error", err))
+	return status.Error(codes.Internal, message)
+}
+
+// validationStatus возвращает INVALID_ARGUMENT с нарушениями по полям в деталях
+// (google.rpc.BadRequest), как errors в problem+json у REST API.
+func validationStatus(err error) error {
+	st := status.New(codes.InvalidArgument, err.Error())
+	var verr entity.ValidationError
+	if !errors.As(err, &verr) {
+		return st.Err()
+	}
+	details := &errdetails.BadRequest{}
+	for _, f := range verr {
+		details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       f.Field,
+			Description: f.Message,
+		})
+	}
+	if withDetails, detailsErr := st.WithDetails(details); detailsErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+func isFinal(st string) bool {
+	switch st {
+	case entity.StatusSent, entity.StatusFailed, entity.StatusSkipped, entity.StatusCanceled:
+		return true
+	}
+	return false
+}
+
+func notifyToProto(n entity.Notify) *notifierv1.Notify {
+	return &notifierv1.Notify{
+		Id:             n.ID,
+		SendAt:         timestamppb.New(n.SendAt),
+		Message:        n.Message,
+		Status:         statusToProto[n.Status],
+		Email:          n.Email,
+		Version:        n.Version,
+		RequestId:      n.RequestID,
+		To:             n.To,
+		Cc:             n.Cc,
+		Bcc:            n.Bcc,
+		RecipientLists: n.RecipientLists,
+		Subject:        n.Subject,
+		Html:           n.HTML,
+		ReplyTo:        n.ReplyTo,
+		Headers:        n.Headers,
+		Attachments:    attachmentsToProto(n.Attachments),
+		Sender:         n.Sender,
+	}
+}
+
+func attachmentsFromProto(attachments []*notifierv1.Attachment) []entity.Attachment {
+	if len(attachments) == 0 {
+		return nil
+	}
+	res := make([]entity.Attachment, 0, len(attachments))
+	for _, a := range attachments {
+		res = append(res, entity.Attachment{
+			Filename:    a.GetFilename(),
+			ContentType: a.GetContentType(),
+			Content:     a.GetContent(),
+		})
+	}
+	return res
+}
+
+func attachmentsToProto(attachments []entity.Attachment) []*notifierv1.Attachment {
+	res := make([]*notifierv1.Attachment, 0, len(attachments))
+	for _, a := range attachments {
+		res = append(res, &notifierv1.Attachment{
+			Id:          a.ID,
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Size:        a.Size,
+		})
+	}
+	return res
+}
+
+func timeFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,270 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	notifierv1 "delayed-notifier/api/notifier/v1"
+	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/requestid"
+	mock_service "delayed-notifier/internal/service/mocks"
+)
+
+func setupServer(t *testing.T) (notifierv1.NotifierServiceClient, *mock_service.NotifyService, *mock_service.AdminService) {
+	notifyService := mock_service.NewNotifyService(t)
+	adminService := mock_service.NewAdminService(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryInterceptor(logger)),
+		grpc.ChainStreamInterceptor(StreamInterceptor(logger)),
+	)
+	notifierv1.RegisterNotifierServiceServer(server, NewNotifierServer(notifyService, adminService, 10*time.Millisecond, logger))
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return notifierv1.NewNotifierServiceClient(conn), notifyService, adminService
+}
+
+func TestCreateNotify(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		client, notifyService, _ := setupServer(t)
+		sendAt := time.Now().Add(time.Hour).UTC()
+
+		notifyService.On("CreateNotify", mock.Anything, mock.MatchedBy(func(n entity.Notify) bool {
+			return n.Status == entity.StatusScheduled && n.RequestID == "client-42" && n.SendAt.Equal(sendAt)
+		})).Return(entity.Notify{
+			ID: "1", SendAt: sendAt, Message: "hi", Email: "user@example.com",
+			Status: entity.StatusScheduled, Version: 1, RequestID: "client-42",
+		}, nil).Once()
+
+		var header metadata.MD
+		ctx := metadata.AppendToOutgoingContext(context.Background(), requestid.KafkaHeader, "client-42")
+		resp, err := client.CreateNotify(ctx, &notifierv1.CreateNotifyRequest{
+			SendAt:  timestamppb.New(sendAt),
+			Message: "hi",
+			Email:   "user@example.com",
+		}, grpc.Header(&header))
+
+		require.NoError(t, err)
+		assert.Equal(t, "1", resp.GetId())
+		assert.Equal(t, notifierv1.NotifyStatus_NOTIFY_STATUS_SCHEDULED, resp.GetStatus())
+		assert.Equal(t, []string{"client-42"}, header.Get(requestid.KafkaHeader))
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		client, _, _ := setupServer(t)
+
+		_, err := client.CreateNotify(context.Background(), &notifierv1.CreateNotifyRequest{
+			SendAt:  timestamppb.New(time.Now().Add(time.Hour)),
+			Message: "hi",
+			Email:   "not-an-email",
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "invalid email format")
+		details := status.Convert(err).Details()
+		require.Len(t, details, 1)
+		badRequest, ok := details[0].(*errdetails.BadRequest)
+		require.True(t, ok)
+		require.Len(t, badRequest.GetFieldViolations(), 1)
+		assert.Equal(t, "email", badRequest.GetFieldViolations()[0].GetField())
+	})
+
+	t.Run("reserved header", func(t *testing.T) {
+		client, _, _ := setupServer(t)
+
+		_, err := client.CreateNotify(context.Background(), &notifierv1.CreateNotifyRequest{
+			SendAt:  timestamppb.New(time.Now().Add(time.Hour)),
+			Message: "hi",
+			Email:   "user@example.com",
+			Headers: map[string]string{"Bcc": "victim@example.com"},
+		})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		badRequest, ok := status.Convert(err).Details()[0].(*errdetails.BadRequest)
+		require.True(t, ok)
+		assert.Equal(t, "headers.Bcc", badRequest.GetFieldViolations()[0].GetField())
+	})
+
+	t.Run("multiple recipients", func(t *testing.T) {
+		client, notifyService, _ := setupServer(t)
+
+		notifyService.On("CreateNotify", mock.Anything, mock.MatchedBy(func(n entity.Notify) bool {
+			return n.Email == "a@example.com" && len(n.To) == 2 && len(n.Bcc) == 1 && len(n.RecipientLists) == 1
+		})).Return(entity.Notify{ID: "1", To: []string{"a@example.com", "b@example.com"}, Status: entity.StatusScheduled}, nil).Once()
+
+		resp, err := client.CreateNotify(context.Background(), &notifierv1.CreateNotifyRequest{
+			SendAt:         timestamppb.New(time.Now().Add(time.Hour)),
+			Message:        "hi",
+			To:             []string{"a@example.com", "b@example.com"},
+			Bcc:            []string{"c@example.com"},
+			RecipientLists: []string{"l1"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a@example.com", "b@example.com"}, resp.GetTo())
+	})
+
+	t.Run("attachments", func(t *testing.T) {
+		client, notifyService, _ := setupServer(t)
+
+		notifyService.On("CreateNotify", mock.Anything, mock.MatchedBy(func(n entity.Notify) bool {
+			return len(n.Attachments) == 1 && string(n.Attachments[0].Content) == "a,b\n"
+		})).Return(entity.Notify{ID: "1", Status: entity.StatusScheduled, Attachments: []entity.Attachment{
+			{ID: "a1", Filename: "report.csv", ContentType: "text/csv", Size: 4},
+		}}, nil).Once()
+
+		resp, err := client.CreateNotify(context.Background(), &notifierv1.CreateNotifyRequest{
+			SendAt:  timestamppb.New(time.Now().Add(time.Hour)),
+			Message: "hi",
+			Email:   "user@example.com",
+			Attachments: []*notifierv1.Attachment{
+				{Filename: "report.csv", ContentType: "text/csv", Content: []byte("a,b\n")},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resp.GetAttachments(), 1)
+		assert.Equal(t, "a1", resp.GetAttachments()[0].GetId())
+		assert.Equal(t, int64(4), resp.GetAttachments()[0].GetSize())
+		assert.Empty(t, resp.GetAttachments()[0].GetContent())
+	})
+}
+
+func TestListDeliveries(t *testing.T) {
+	client, notifyService, _ := setupServer(t)
+	notifyService.On("GetDeliveries", mock.Anything, "1").Return([]entity.Delivery{
+		{NotifyID: "1", Email: "a@example.com", Kind: entity.RecipientTo, Status: entity.StatusSent, Attempts: 1},
+		{NotifyID: "1", Email: "b@example.com", Kind: entity.RecipientBcc, ListID: "l1", Status: entity.StatusFailed, Attempts: 2, LastError: "timeout"},
+	}, nil).Once()
+
+	resp, err := client.ListDeliveries(context.Background(), &notifierv1.ListDeliveriesRequest{Id: "1"})
+
+	require.NoError(t, err)
+	require.Len(t, resp.GetDeliveries(), 2)
+	assert.Equal(t, "l1", resp.GetDeliveries()[1].GetListId())
+	assert.Equal(t, "timeout", resp.GetDeliveries()[1].GetLastError())
+}
+
+func TestGetNotify(t *testing.T) {
+	client, notifyService, _ := setupServer(t)
+	notifyService.On("GetNotify", mock.Anything, "missing").Return(entity.Notify{}, entity.ErrNotifyNotFound).Once()
+
+	_, err := client.GetNotify(context.Background(), &notifierv1.GetNotifyRequest{Id: "missing"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestListNotifies(t *testing.T) {
+	client, _, adminService := setupServer(t)
+	from := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	adminService.On("ListNotifies", mock.Anything, entity.NotifyFilter{
+		Status: entity.StatusFailed,
+		From:   from,
+		Limit:  10,
+	}).Return([]entity.Notify{{ID: "1", Status: entity.StatusFailed}}, nil).Once()
+
+	resp, err := client.ListNotifies(context.Background(), &notifierv1.ListNotifiesRequest{
+		Status: notifierv1.NotifyStatus_NOTIFY_STATUS_FAILED,
+		From:   timestamppb.New(from),
+		Limit:  10,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.GetNotifies(), 1)
+	assert.Equal(t, notifierv1.NotifyStatus_NOTIFY_STATUS_FAILED, resp.GetNotifies()[0].GetStatus())
+}
+
+func TestCancelNotify(t *testing.T) {
+	t.Run("canceled", func(t *testing.T) {
+		client, notifyService, adminService := setupServer(t)
+		adminService.On("CancelNotify", mock.Anything, "1", entity.AdminActionRequest{Actor: "alice"}).
+			Return(entity.AdminResult{NotifyID: "1", Action: entity.AdminActionCanceled, Status: entity.StatusCanceled}, nil).Once()
+		notifyService.On("GetNotify", mock.Anything, "1").Return(entity.Notify{ID: "1", Status: entity.StatusCanceled}, nil).Once()
+
+		resp, err := client.CancelNotify(context.Background(), &notifierv1.CancelNotifyRequest{Id: "1", Actor: "alice"})
+
+		require.NoError(t, err)
+		assert.Equal(t, notifierv1.NotifyStatus_NOTIFY_STATUS_CANCELED, resp.GetStatus())
+	})
+
+	t.Run("not scheduled", func(t *testing.T) {
+		client, _, adminService := setupServer(t)
+		adminService.On("CancelNotify", mock.Anything, "1", mock.Anything).
+			Return(entity.AdminResult{NotifyID: "1", Action: entity.AdminActionSkipped, Status: entity.StatusSent, Error: "notify is not scheduled"}, nil).Once()
+
+		_, err := client.CancelNotify(context.Background(), &notifierv1.CancelNotifyRequest{Id: "1"})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestRescheduleNotify(t *testing.T) {
+	client, _, _ := setupServer(t)
+
+	_, err := client.RescheduleNotify(context.Background(), &notifierv1.RescheduleNotifyRequest{Id: "1"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestWatchNotify(t *testing.T) {
+	t.Run("streams changes until final status", func(t *testing.T) {
+		client, notifyService, _ := setupServer(t)
+		notifyService.On("GetNotify", mock.Anything, "1").Return(entity.Notify{ID: "1", Status: entity.StatusScheduled, Version: 1}, nil).Twice()
+		notifyService.On("GetNotify", mock.Anything, "1").Return(entity.Notify{ID: "1", Status: entity.StatusQueued, Version: 2}, nil).Once()
+		notifyService.On("GetNotify", mock.Anything, "1").Return(entity.Notify{ID: "1", Status: entity.StatusSent, Version: 3}, nil).Once()
+
+		stream, err := client.WatchNotify(context.Background(), &notifierv1.WatchNotifyRequest{Id: "1"})
+		require.NoError(t, err)
+
+		var got []notifierv1.NotifyStatus
+		for {
+			n, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			got = append(got, n.GetStatus())
+		}
+		assert.Equal(t, []notifierv1.NotifyStatus{
+			notifierv1.NotifyStatus_NOTIFY_STATUS_SCHEDULED,
+			notifierv1.NotifyStatus_NOTIFY_STATUS_QUEUED,
+			notifierv1.NotifyStatus_NOTIFY_STATUS_SENT,
+		}, got)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		client, notifyService, _ := setupServer(t)
+		notifyService.On("GetNotify", mock.Anything, "missing").Return(entity.Notify{}, entity.ErrNotifyNotFound).Once()
+
+		stream, err := client.WatchNotify(context.Background(), &notifierv1.WatchNotifyRequest{Id: "missing"})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
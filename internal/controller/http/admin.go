@@ -0,0 +1,168 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"delayed-notifier/internal/controller"
+	"delayed-notifier/internal/controller/http/problem"
+	"delayed-notifier/internal/entity"
+)
+
+type AdminHandler struct {
+	service controller.AdminService
+	logger  *slog.Logger
+}
+
+func NewAdminHandler(service controller.AdminService, logger *slog.Logger) *AdminHandler {
+	return &AdminHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *AdminHandler) ListNotifies(w http.ResponseWriter, r *http.Request) {
+	filter, errs := parseNotifyFilter(r.URL.Query())
+	if len(errs) > 0 {
+		problem.Write(w, r, problem.FromValidationError("query", errs), h.logger)
+		return
+	}
+
+	notifies, err := h.service.ListNotifies(r.Context(), filter)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "failed to list notifies", slog.Any("error", err))
+		problem.Write(w, r, problem.New(problem.CodeInternal, "failed to list notifies"), h.logger)
+		return
+	}
+	if notifies == nil {
+		notifies = []entity.Notify{}
+	}
+	h.writeJSON(w, r, http.StatusOK, notifies)
+}
+
+func (h *AdminHandler) GetNotify(w http.ResponseWriter, r *http.Request) {
+	details, err := h.service.GetNotifyDetails(r.Context(), chi.URLParam(r, "notifyID"))
+	if err != nil {
+		h.writeServiceError(w, r, "failed to get notify", err)
+		return
+	}
+	h.writeJSON(w, r, http.StatusOK, details)
+}
+
+func (h *AdminHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.service.GetNotifyStats(r.Context())
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "failed to get notify stats", slog.Any("error", err))
+		problem.Write(w, r, problem.New(problem.CodeInternal, "failed to get notify stats"), h.logger)
+		return
+	}
+	h.writeJSON(w, r, http.StatusOK, stats)
+}
+
+func (h *AdminHandler) CancelNotify(w http.ResponseWriter, r *http.Request) {
+	h.action(w, r, h.service.CancelNotify)
+}
+
+func (h *AdminHandler) RescheduleNotify(w http.ResponseWriter, r *http.Request) {
+	h.action(w, r, h.service.RescheduleNotify)
+}
+
+func (h *AdminHandler) ResendNotify(w http.ResponseWriter, r *http.Request) {
+	h.action(w, r, h.service.ResendNotify)
+}
+
+func (h *AdminHandler) RequeueFailed(w http.ResponseWriter, r *http.Request) {
+	var req entity.RequeueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.ErrorContext(r.Context(), "invalid request body", slog.Any("error", err))
+		problem.Write(w, r, problem.Body(err), h.logger)
+		return
+	}
+
+	results, err := h.service.RequeueFailed(r.Context(), req)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "failed to requeue notifies", slog.Any("error", err))
+		problem.Write(w, r, problem.New(problem.CodeInternal, "failed to requeue notifies"), h.logger)
+		return
+	}
+	h.writeJSON(w, r, http.StatusOK, results)
+}
+
+type adminAction func(ctx context.Context, notifyID string, req entity.AdminActionRequest) (entity.AdminResult, error)
+
+func (h *AdminHandler) action(w http.ResponseWriter, r *http.Request, do adminAction) {
+	var req entity.AdminActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.ErrorContext(r.Context(), "invalid request body", slog.Any("error", err))
+		problem.Write(w, r, problem.Body(err), h.logger)
+		return
+	}
+
+	result, err := do(r.Context(), chi.URLParam(r, "notifyID"), req)
+	if err != nil {
+		h.writeServiceError(w, r, "failed to update notify", err)
+		return
+	}
+	h.writeJSON(w, r, http.StatusOK, result)
+}
+
+func (h *AdminHandler) writeServiceError(w http.ResponseWriter, r *http.Request, message string, err error) {
+	if errors.Is(err, entity.ErrNotifyNotFound) {
+		problem.Write(w, r, problem.New(problem.CodeNotifyNotFound, "notify not found"), h.logger)
+		return
+	}
+	h.logger.ErrorContext(r.Context(), message, slog.Any("error", err))
+	problem.Write(w, r, problem.New(problem.CodeInternal, message), h.logger)
+}
+
+func (h *AdminHandler) writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.ErrorContext(r.Context(), "failed to encode response", slog.Any("error", err))
+	}
+}
+
+// parseNotifyFilter разбирает фильтр из query-параметров status, email, from, to
+// (RFC 3339), error и limit и возвращает все ошибки разбора.
+func parseNotifyFilter(q url.Values) (entity.NotifyFilter, entity.ValidationError) {
+	get := q.Get
+
+	filter := entity.NotifyFilter{
+		Status: get("status"),
+		Email:  get("email"),
+		Error:  get("error"),
+	}
+	var errs entity.ValidationError
+	bounds := []struct {
+		key string
+		t   *time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}}
+	for _, b := range bounds {
+		if raw := get(b.key); raw != "" {
+			parsed, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				errs = append(errs, entity.FieldError{Field: b.key, Message: "invalid " + b.key})
+				continue
+			}
+			*b.t = parsed
+		}
+	}
+	if raw := get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 0 {
+			errs = append(errs, entity.FieldError{Field: "limit", Message: "invalid limit"})
+		} else {
+			filter.Limit = limit
+		}
+	}
+	return filter, errs
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/entity"
+	mock_service "delayed-notifier/internal/service/mocks"
+)
+
+func setupAdminHandler(t *testing.T) (*AdminHandler, *mock_service.AdminService) {
+	mockService := mock_service.NewAdminService(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewAdminHandler(mockService, logger), mockService
+}
+
+func TestAdminListNotifies(t *testing.T) {
+	t.Run("filter from query", func(t *testing.T) {
+		handler, mockService := setupAdminHandler(t)
+
+		filter := entity.NotifyFilter{
+			Status: entity.StatusFailed,
+			From:   time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+			Error:  "smtp",
+			Limit:  10,
+		}
+		mockService.On("ListNotifies", mock.Anything, filter).Return(nil, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/notify?status=failed&from=2025-08-01T00:00:00Z&error=smtp&limit=10", nil)
+		rec := httptest.NewRecorder()
+		handler.ListNotifies(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, "[]", rec.Body.String())
+	})
+
+	t.Run("invalid time", func(t *testing.T) {
+		handler, _ := setupAdminHandler(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/notify?to=yesterday", nil)
+		rec := httptest.NewRecorder()
+		handler.ListNotifies(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid to")
+	})
+}
+
+func TestAdminGetNotify(t *testing.T) {
+	handler, mockService := setupAdminHandler(t)
+
+	mockService.On("GetNotifyDetails", mock.Anything, "id1").Return(entity.NotifyDetails{}, entity.ErrNotifyNotFound).Once()
+
+	req := addNotifyIDToCtx(httptest.NewRequest(http.MethodGet, "/admin/notify/id1", nil), "id1")
+	rec := httptest.NewRecorder()
+	handler.GetNotify(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAdminRescheduleNotify(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		handler, mockService := setupAdminHandler(t)
+
+		sendAt := time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+		result := entity.AdminResult{NotifyID: "id1", Action: entity.AdminActionRescheduled, Status: entity.StatusScheduled}
+		mockService.On("RescheduleNotify", mock.Anything, "id1", entity.AdminActionRequest{SendAt: sendAt, Actor: "alice"}).Return(result, nil).Once()
+
+		body := strings.NewReader(`{"send_at":"2030-01-01T10:00:00Z","actor":"alice"}`)
+		req := addNotifyIDToCtx(httptest.NewRequest(http.MethodPost, "/admin/notify/id1/reschedule", body), "id1")
+		rec := httptest.NewRecorder()
+		handler.RescheduleNotify(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var actual entity.AdminResult
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&actual))
+		assert.Equal(t, result, actual)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		handler, _ := setupAdminHandler(t)
+
+		req := addNotifyIDToCtx(httptest.NewRequest(http.MethodPost, "/admin/notify/id1/reschedule", strings.NewReader("{")), "id1")
+		rec := httptest.NewRecorder()
+		handler.RescheduleNotify(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
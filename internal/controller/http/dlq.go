@@ -0,0 +1,82 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"delayed-notifier/internal/controller"
+	"delayed-notifier/internal/controller/http/problem"
+	"delayed-notifier/internal/entity"
+)
+
+type DLQHandler struct {
+	service controller.DLQService
+	logger  *slog.Logger
+}
+
+func NewDLQHandler(service controller.DLQService, logger *slog.Logger) *DLQHandler {
+	return &DLQHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *DLQHandler) ListDLQ(w http.ResponseWriter, r *http.Request) {
+	filter := entity.DLQFilter{Reason: r.URL.Query().Get("reason")}
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 0 {
+			problem.Write(w, r, problem.Validation(problem.FieldError{Field: "limit", In: "query", Message: "invalid limit"}), h.logger)
+			return
+		}
+		filter.Limit = limit
+	}
+
+	entries, err := h.service.ListDLQ(r.Context(), filter)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "failed to list dlq", slog.Any("error", err))
+		problem.Write(w, r, problem.New(problem.CodeInternal, "failed to list dlq"), h.logger)
+		return
+	}
+	if entries == nil {
+		entries = []entity.DLQEntry{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		h.logger.ErrorContext(r.Context(), "failed to encode dlq entries", slog.Any("error", err))
+	}
+}
+
+func (h *DLQHandler) ReplayDLQ(w http.ResponseWriter, r *http.Request) {
+	var req entity.DLQReplayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.ErrorContext(r.Context(), "invalid request body", slog.Any("error", err))
+		problem.Write(w, r, problem.Body(err), h.logger)
+		return
+	}
+
+	if !req.All && len(req.Entries) == 0 {
+		problem.Write(w, r, problem.Validation(problem.FieldError{Field: "entries", In: "body", Message: "entries or all is required"}), h.logger)
+		return
+	}
+
+	results, err := h.service.ReplayDLQ(r.Context(), req)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "failed to replay dlq", slog.Any("error", err))
+		problem.Write(w, r, problem.New(problem.CodeInternal, "failed to replay dlq"), h.logger)
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "dlq replayed",
+		slog.String("actor", req.Actor),
+		slog.Bool("dry_run", req.DryRun),
+		slog.Int("count", len(results)),
+	)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		h.logger.ErrorContext(r.Context(), "failed to encode replay results", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/entity"
+	mock_service "delayed-notifier/internal/service/mocks"
+)
+
+func setupDLQHandler() (*DLQHandler, *mock_service.DLQService) {
+	mockService := new(mock_service.DLQService)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := NewDLQHandler(mockService, logger)
+	return handler, mockService
+}
+
+func TestListDLQ(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		handler, mockService := setupDLQHandler()
+
+		entries := []entity.DLQEntry{{Partition: 1, Offset: 5, NotifyID: "id1", Reason: entity.DLQReasonProcessingFailed, ErrorMessage: "smtp down"}}
+		mockService.
+			On("ListDLQ", mock.Anything, entity.DLQFilter{Reason: entity.DLQReasonProcessingFailed, Limit: 5}).
+			Return(entries, nil).
+			Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/dlq?reason=processing_failed&limit=5", nil)
+		rec := httptest.NewRecorder()
+		handler.ListDLQ(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var actual []entity.DLQEntry
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&actual))
+		assert.Equal(t, entries[0].NotifyID, actual[0].NotifyID)
+		assert.Equal(t, entries[0].ErrorMessage, actual[0].ErrorMessage)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		handler, _ := setupDLQHandler()
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/dlq?limit=abc", nil)
+		rec := httptest.NewRecorder()
+		handler.ListDLQ(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid limit")
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		handler, mockService := setupDLQHandler()
+
+		mockService.On("ListDLQ", mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/dlq", nil)
+		rec := httptest.NewRecorder()
+		handler.ListDLQ(rec, req)
+
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), "failed to list dlq")
+	})
+}
+
+func TestReplayDLQ(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		handler, mockService := setupDLQHandler()
+
+		input := entity.DLQReplayRequest{
+			Entries: []entity.DLQPosition{{Partition: 0, Offset: 3}},
+			DryRun:  true,
+			Actor:   "ops",
+		}
+		results := []entity.DLQReplayResult{{Partition: 0, Offset: 3, NotifyID: "id1", Action: entity.DLQActionWouldReplay}}
+		mockService.On("ReplayDLQ", mock.Anything, input).Return(results, nil).Once()
+
+		body, contentType := mustEncode(t, input)
+		req := httptest.NewRequest(http.MethodPost, "/admin/dlq/replay", body)
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+		handler.ReplayDLQ(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var actual []entity.DLQReplayResult
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&actual))
+		assert.Equal(t, results, actual)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("nothing selected", func(t *testing.T) {
+		handler, mockService := setupDLQHandler()
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/dlq/replay", bytes.NewBufferString(`{"dry_run": true}`))
+		rec := httptest.NewRecorder()
+		handler.ReplayDLQ(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		mockService.AssertNotCalled(t, "ReplayDLQ", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		handler, _ := setupDLQHandler()
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/dlq/replay", bytes.NewBufferString("{invalid}"))
+		rec := httptest.NewRecorder()
+		handler.ReplayDLQ(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid request body")
+	})
+}
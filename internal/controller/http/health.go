@@ -0,0 +1,31 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"delayed-notifier/internal/controller"
+)
+
+type HealthHandler struct {
+	leader controller.LeaderStatus
+	logger *slog.Logger
+}
+
+func NewHealthHandler(leader controller.LeaderStatus, logger *slog.Logger) *HealthHandler {
+	return &HealthHandler{
+		leader: leader,
+		logger: logger,
+	}
+}
+
+func (h *HealthHandler) Health(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]any{
+		"status": "ok",
+		"leader": h.leader.IsLeader(),
+	}); err != nil {
+		h.logger.Error("failed to encode response", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mock_service "delayed-notifier/internal/service/mocks"
+)
+
+func TestHealth(t *testing.T) {
+	for _, leader := range []bool{true, false} {
+		mockLeader := new(mock_service.LeaderStatus)
+		mockLeader.On("IsLeader").Return(leader).Once()
+		handler := NewHealthHandler(mockLeader, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		rec := httptest.NewRecorder()
+		handler.Health(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "ok", body["status"])
+		assert.Equal(t, leader, body["leader"])
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"delayed-notifier/internal/controller/http/problem"
+	"delayed-notifier/internal/secret"
+)
+
+// AdminToken пропускает только запросы с заголовком Authorization: Bearer <token>.
+// Токен читается при каждом запросе, поэтому ротация файла секрета подхватывается
+// без перезапуска. Пустой токен закрывает административный API целиком.
+func AdminToken(token *secret.Value, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			want := token.Get()
+			got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if want == "" || !ok || subtle.ConstantTimeCompare([]byte(got), []byte(want)) != 1 {
+				logger.WarnContext(r.Context(), "admin request rejected",
+					slog.String("path", r.URL.Path),
+					slog.Bool("configured", want != ""),
+				)
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				problem.Write(w, r, problem.New(problem.CodeUnauthorized, "admin token is missing or invalid"), logger)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/controller/http/problem"
+	"delayed-notifier/internal/secret"
+)
+
+func TestAdminToken(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	serve := func(token, header string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/admin/notify", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		AdminToken(secret.Static(token), logger)(ok).ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, serve("s3cret", "Bearer s3cret").Code)
+	})
+
+	for name, tc := range map[string]struct{ token, header string }{
+		"missing header": {"s3cret", ""},
+		"wrong token":    {"s3cret", "Bearer guess"},
+		"wrong scheme":   {"s3cret", "Basic s3cret"},
+		"empty bearer":   {"", "Bearer "},
+		"token not set":  {"", ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := serve(tc.token, tc.header)
+
+			require.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+			var p problem.Problem
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&p))
+			assert.Equal(t, problem.CodeUnauthorized, p.Code)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// MaxBodySize ограничивает тело запроса limit байтами. Превышение видно при чтении
+// тела как *http.MaxBytesError и возвращается клиенту кодом payload_too_large.
+func MaxBodySize(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -23,7 +23,7 @@ func LoggingMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
 			rw := &responseWriterWithStatus{ResponseWriter: w, status: 200}
 			next.ServeHTTP(rw, r)
 			duration := time.Since(start)
-			logger.Info("http request",
+			logger.InfoContext(r.Context(), "http request",
 				slog.String("method", r.Method),
 				slog.String("path", r.URL.Path),
 				slog.Int("status", rw.status),
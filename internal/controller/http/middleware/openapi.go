@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+
+	"delayed-notifier/internal/controller/http/problem"
+)
+
+// OpenAPIValidator проверяет запросы по спецификации до обработчиков и отвечает
+// problem+json со списком ошибок по полям. Маршруты, которых нет в спецификации (/metrics), пропускаются.
+func OpenAPIValidator(doc *openapi3.T, logger *slog.Logger) (func(http.Handler) http.Handler, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+	options := &openapi3filter.Options{
+		MultiError:         true,
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				if !errors.Is(err, routers.ErrPathNotFound) && !errors.Is(err, routers.ErrMethodNotAllowed) {
+					logger.ErrorContext(r.Context(), "openapi route lookup failed", slog.Any("error", err))
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			err = openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			})
+			if err != nil {
+				p := requestProblem(err)
+				logger.InfoContext(r.Context(), "request validation failed",
+					slog.String("operation", route.Operation.OperationID),
+					slog.String("code", p.Code),
+					slog.Int("errors", len(p.Errors)),
+				)
+				problem.Write(w, r, p, logger)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+// requestProblem выбирает код ответа: неподходящий Content-Type и нечитаемое тело
+// отличаются от ошибок значений полей.
+func requestProblem(err error) *problem.Problem {
+	var reqErr *openapi3filter.RequestError
+	if errors.As(err, &reqErr) && reqErr.RequestBody != nil {
+		switch {
+		case reqErr.Err == nil:
+			return problem.New(problem.CodeUnsupportedMediaType, reqErr.Reason)
+		case !errors.Is(reqErr.Err, openapi3filter.ErrInvalidRequired) && !isSchemaError(reqErr.Err):
+			return problem.Body(reqErr.Err)
+		}
+	}
+	return problem.Validation(fieldErrors(err)...)
+}
+
+func isSchemaError(err error) bool {
+	var schemaErr *openapi3.SchemaError
+	var multi openapi3.MultiError
+	return errors.As(err, &schemaErr) || errors.As(err, &multi)
+}
+
+// fieldErrors раскладывает ошибки kin-openapi по полям.
+func fieldErrors(err error) []problem.FieldError {
+	var fields []problem.FieldError
+	switch e := err.(type) { //nolint:errorlint // разбираем дерево ошибок ValidateRequest по уровням
+	case openapi3.MultiError:
+		for _, inner := range e {
+			fields = append(fields, fieldErrors(inner)...)
+		}
+	case *openapi3filter.RequestError:
+		field, in := "", "body"
+		if e.Parameter != nil {
+			field, in = e.Parameter.Name, e.Parameter.In
+		}
+		if e.Err == nil {
+			return []problem.FieldError{{Field: field, In: in, Message: e.Reason}}
+		}
+		for _, inner := range flatten(e.Err) {
+			fields = append(fields, schemaFieldError(field, in, inner))
+		}
+	default:
+		fields = append(fields, problem.FieldError{Message: err.Error()})
+	}
+	return fields
+}
+
+func flatten(err error) []error {
+	var multi openapi3.MultiError
+	if !errors.As(err, &multi) {
+		return []error{err}
+	}
+	var errs []error
+	for _, e := range multi {
+		errs = append(errs, flatten(e)...)
+	}
+	return errs
+}
+
+// schemaFieldError дополняет имя параметра путём внутри значения (для тела — путём к полю).
+func schemaFieldError(field, in string, err error) problem.FieldError {
+	var schemaErr *openapi3.SchemaError
+	if !errors.As(err, &schemaErr) {
+		return problem.FieldError{Field: field, In: in, Message: err.Error()}
+	}
+	path := schemaErr.JSONPointer()
+	if field != "" {
+		path = append([]string{field}, path...)
+	}
+	return problem.FieldError{Field: strings.Join(path, "."), In: in, Message: schemaErr.Reason}
+}
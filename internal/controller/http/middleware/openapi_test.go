@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/api/openapi"
+	"delayed-notifier/internal/controller/http/problem"
+)
+
+func setupValidator(t *testing.T) (http.Handler, *bool) {
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+	validator, err := OpenAPIValidator(doc, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	called := new(bool)
+	return validator(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*called = true
+		// Тело должно остаться доступным обработчику
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	})), called
+}
+
+func TestOpenAPIValidator(t *testing.T) {
+	t.Run("valid request", func(t *testing.T) {
+		handler, called := setupValidator(t)
+		body := `{"send_at":"2030-01-01T00:00:00Z","message":"hi","email":"user@example.com"}`
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/notify", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.True(t, *called)
+		assert.Equal(t, body, rec.Body.String())
+	})
+
+	t.Run("field errors", func(t *testing.T) {
+		handler, called := setupValidator(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/notify", strings.NewReader(`{"send_at":"tomorrow","email":"nope"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.False(t, *called)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		var resp problem.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, problem.CodeValidationFailed, resp.Code)
+		assert.ElementsMatch(t, []problem.FieldError{
+			{Field: "send_at", In: "body", Message: `string doesn't match the format "date-time" (must be an RFC 3339 date-time)`},
+			{Field: "email", In: "body", Message: `string doesn't match the format "email" (invalid email format)`},
+			{Field: "message", In: "body", Message: `property "message" is missing`},
+		}, resp.Errors)
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		handler, called := setupValidator(t)
+		body := `{"send_at":"2030-01-01T00:00:00Z","message":"` + strings.Repeat("x", 200) + `","email":"user@example.com"}`
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/notify", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		MaxBodySize(100)(handler).ServeHTTP(rec, req)
+
+		assert.False(t, *called)
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		var resp problem.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, problem.CodePayloadTooLarge, resp.Code)
+	})
+
+	t.Run("attachment content must be base64", func(t *testing.T) {
+		handler, called := setupValidator(t)
+		body := `{"send_at":"2030-01-01T00:00:00Z","message":"hi","email":"user@example.com",` +
+			`"attachments":[{"filename":"a.csv","content_type":"text/csv","content":"not base64!"}]}`
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/notify", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.False(t, *called)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		var resp problem.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "attachments.0.content", resp.Errors[0].Field)
+	})
+
+	t.Run("query parameters", func(t *testing.T) {
+		handler, called := setupValidator(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/admin/notify?status=lost&limit=-1&from=yesterday", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.False(t, *called)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		var resp problem.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		fields := make([]string, 0, len(resp.Errors))
+		for _, e := range resp.Errors {
+			assert.Equal(t, "query", e.In)
+			fields = append(fields, e.Field)
+		}
+		assert.ElementsMatch(t, []string{"status", "limit", "from"}, fields)
+	})
+
+	t.Run("content type", func(t *testing.T) {
+		handler, called := setupValidator(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/admin/notify/42/cancel", strings.NewReader(`{"actor":"ops"}`))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.False(t, *called)
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+		assert.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		handler, called := setupValidator(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/notify", strings.NewReader(`{"send_at":`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		assert.False(t, *called)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		var resp problem.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, problem.CodeInvalidBody, resp.Code)
+	})
+
+	t.Run("unknown route passes through", func(t *testing.T) {
+		handler, called := setupValidator(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.True(t, *called)
+	})
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+
+	"delayed-notifier/internal/requestid"
+)
+
+// RequestIDMiddleware берёт X-Request-ID из запроса или генерирует новый, кладёт его
+// в контекст и возвращает в ответе. Должен стоять перед LoggingMiddleware.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestid.HTTPHeader)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+		w.Header().Set(requestid.HTTPHeader, id)
+		next.ServeHTTP(w, r.WithContext(requestid.NewContext(r.Context(), id)))
+	})
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"delayed-notifier/internal/requestid"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var got string
+	handler := RequestIDMiddleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = requestid.FromContext(r.Context())
+	}))
+
+	t.Run("keeps valid id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(requestid.HTTPHeader, "client-42")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, "client-42", got)
+		assert.Equal(t, "client-42", rec.Header().Get(requestid.HTTPHeader))
+	})
+
+	t.Run("generates id", func(t *testing.T) {
+		for _, header := range []string{"", "bad id"} {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(requestid.HTTPHeader, header)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Len(t, got, 32)
+			assert.Equal(t, got, rec.Header().Get(requestid.HTTPHeader))
+		}
+	})
+}
@@ -4,22 +4,18 @@ import (
 	"encoding/json"
 	"errors"
 	"log/slog"
+	"mime"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 
 	"delayed-notifier/internal/controller"
+	"delayed-notifier/internal/controller/http/problem"
 	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/requestid"
 )
 
-func writeError(w http.ResponseWriter, message string, statusCode int, log *slog.Logger) {
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(statusCode)
-	if err := json.NewEncoder(w).Encode(map[string]string{"message": message}); err != nil {
-		log.Error("failed to encode error", slog.Any("error", err))
-	}
-}
-
 type NotifyHandler struct {
 	service controller.NotifyService
 	logger  *slog.Logger
@@ -35,32 +31,47 @@ func NewNotifyHandler(service controller.NotifyService, logger *slog.Logger) *No
 func (h *NotifyHandler) CreateNotify(w http.ResponseWriter, r *http.Request) {
 	var input entity.Notify
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		h.logger.Error("invalid request body", slog.Any("error", err))
-		writeError(w, "invalid request body", http.StatusBadRequest, h.logger)
+		h.logger.ErrorContext(r.Context(), "invalid request body", slog.Any("error", err))
+		problem.Write(w, r, problem.Body(err), h.logger)
 		return
 	}
 
 	if err := input.Validate(); err != nil {
-		h.logger.Error("validation error", slog.Any("error", err))
-		writeError(w, err.Error(), http.StatusBadRequest, h.logger)
+		h.logger.ErrorContext(r.Context(), "validation error", slog.Any("error", err))
+		var verr entity.ValidationError
+		if errors.As(err, &verr) {
+			problem.Write(w, r, problem.FromValidationError("body", verr), h.logger)
+			return
+		}
+		problem.Write(w, r, problem.Validation(problem.FieldError{In: "body", Message: err.Error()}), h.logger)
 		return
 	}
 
+	input.Normalize()
 	input.Status = entity.StatusScheduled
+	// ID запроса сохраняется вместе с уведомлением, чтобы связать с ним логи воркера
+	input.RequestID = requestid.FromContext(r.Context())
 	created, err := h.service.CreateNotify(r.Context(), input)
 	if err != nil {
-		h.logger.Error("failed to create notify", slog.Any("error", err))
-		writeError(w, "failed to create notify", http.StatusInternalServerError, h.logger)
+		// Сервис отклоняет ссылки на несуществующие списки получателей
+		var verr entity.ValidationError
+		if errors.As(err, &verr) {
+			h.logger.InfoContext(r.Context(), "validation error", slog.Any("error", err))
+			problem.Write(w, r, problem.FromValidationError("body", verr), h.logger)
+			return
+		}
+		h.logger.ErrorContext(r.Context(), "failed to create notify", slog.Any("error", err))
+		problem.Write(w, r, problem.New(problem.CodeInternal, "failed to create notify"), h.logger)
 		return
 	}
 
-	h.logger.Info("notify created", slog.String("id", created.ID))
+	h.logger.InfoContext(r.Context(), "notify created", slog.String("id", created.ID))
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 
 	if err := json.NewEncoder(w).Encode(created); err != nil {
-		h.logger.Error("failed to encode notify", slog.Any("error", err))
-		writeError(w, "failed to encode notify", http.StatusInternalServerError, h.logger)
+		h.logger.ErrorContext(r.Context(), "failed to encode notify", slog.Any("error", err))
+		problem.Write(w, r, problem.New(problem.CodeInternal, "failed to encode notify"), h.logger)
 		return
 	}
 }
@@ -68,46 +79,100 @@ func (h *NotifyHandler) CreateNotify(w http.ResponseWriter, r *http.Request) {
 func (h *NotifyHandler) GetNotify(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "notifyID")
 	if id == "" {
-		writeError(w, "notifyID is required", http.StatusBadRequest, h.logger)
+		problem.Write(w, r, problem.Validation(problem.FieldError{Field: "notifyID", In: "path", Message: "notifyID is required"}), h.logger)
 		return
 	}
 
 	notify, err := h.service.GetNotify(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, entity.ErrNotifyNotFound) {
-			h.logger.Info("notify not found", slog.String("id", id))
-			writeError(w, "notify not found", http.StatusNotFound, h.logger)
+			h.logger.InfoContext(r.Context(), "notify not found", slog.String("id", id))
+			problem.Write(w, r, problem.New(problem.CodeNotifyNotFound, "notify not found"), h.logger)
 			return
 		}
 
-		h.logger.Error("failed to get notify", slog.Any("error", err))
-		writeError(w, "internal server error", http.StatusInternalServerError, h.logger)
+		h.logger.ErrorContext(r.Context(), "failed to get notify", slog.Any("error", err))
+		problem.Write(w, r, problem.New(problem.CodeInternal, "internal server error"), h.logger)
 		return
 	}
 
-	h.logger.Info("notify fetched", slog.String("id", notify.ID))
+	h.logger.InfoContext(r.Context(), "notify fetched", slog.String("id", notify.ID))
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(notify); err != nil {
-		h.logger.Error("failed to encode notify", slog.Any("error", err))
-		writeError(w, "failed to encode notify", http.StatusInternalServerError, h.logger)
+		h.logger.ErrorContext(r.Context(), "failed to encode notify", slog.Any("error", err))
+		problem.Write(w, r, problem.New(problem.CodeInternal, "failed to encode notify"), h.logger)
 		return
 	}
 }
 
+// GetDeliveries возвращает статус отправки уведомления каждому получателю.
+func (h *NotifyHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "notifyID")
+	if id == "" {
+		problem.Write(w, r, problem.Validation(problem.FieldError{Field: "notifyID", In: "path", Message: "notifyID is required"}), h.logger)
+		return
+	}
+
+	deliveries, err := h.service.GetDeliveries(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotifyNotFound) {
+			problem.Write(w, r, problem.New(problem.CodeNotifyNotFound, "notify not found"), h.logger)
+			return
+		}
+
+		h.logger.ErrorContext(r.Context(), "failed to get deliveries", slog.Any("error", err))
+		problem.Write(w, r, problem.New(problem.CodeInternal, "internal server error"), h.logger)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(deliveries); err != nil {
+		h.logger.ErrorContext(r.Context(), "failed to encode deliveries", slog.Any("error", err))
+	}
+}
+
+// GetAttachment отдаёт содержимое вложения уведомления как файл.
+func (h *NotifyHandler) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	notifyID, attachmentID := chi.URLParam(r, "notifyID"), chi.URLParam(r, "attachmentID")
+
+	attachment, err := h.service.GetAttachment(r.Context(), notifyID, attachmentID)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrNotifyNotFound):
+			problem.Write(w, r, problem.New(problem.CodeNotifyNotFound, "notify not found"), h.logger)
+		case errors.Is(err, entity.ErrAttachmentNotFound):
+			problem.Write(w, r, problem.New(problem.CodeAttachmentNotFound, "attachment not found"), h.logger)
+		default:
+			h.logger.ErrorContext(r.Context(), "failed to get attachment", slog.Any("error", err))
+			problem.Write(w, r, problem.New(problem.CodeInternal, "internal server error"), h.logger)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	// Браузер не должен угадывать тип и, например, исполнять HTML из вложения
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Length", strconv.Itoa(len(attachment.Content)))
+	if _, err := w.Write(attachment.Content); err != nil {
+		h.logger.ErrorContext(r.Context(), "failed to write attachment", slog.Any("error", err))
+	}
+}
+
 func (h *NotifyHandler) DeleteNotify(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "notifyID")
 	if id == "" {
-		writeError(w, "notifyID is required", http.StatusBadRequest, h.logger)
+		problem.Write(w, r, problem.Validation(problem.FieldError{Field: "notifyID", In: "path", Message: "notifyID is required"}), h.logger)
 		return
 	}
 
 	err := h.service.DeleteNotify(r.Context(), id)
 	if err != nil {
-		h.logger.Error("failed to delete notify", slog.Any("error", err), slog.String("id", id))
-		writeError(w, "failed to delete notify", http.StatusInternalServerError, h.logger)
+		h.logger.ErrorContext(r.Context(), "failed to delete notify", slog.Any("error", err), slog.String("id", id))
+		problem.Write(w, r, problem.New(problem.CodeInternal, "failed to delete notify"), h.logger)
 		return
 	}
 
-	h.logger.Info("notify deleted", slog.String("id", id))
+	h.logger.InfoContext(r.Context(), "notify deleted", slog.String("id", id))
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"delayed-notifier/internal/controller/http/problem"
 	"delayed-notifier/internal/entity"
 	mock_service "delayed-notifier/internal/service/mocks"
 )
@@ -112,7 +113,107 @@ func TestCreateNotify(t *testing.T) {
 		handler.CreateNotify(rec, req)
 
 		require.Equal(t, http.StatusBadRequest, rec.Code)
-		assert.Contains(t, rec.Body.String(), "message is required")
+		assert.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+		var resp problem.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, problem.CodeValidationFailed, resp.Code)
+		assert.Equal(t, "/notify", resp.Instance)
+		// Возвращаются все нарушения, а не только первое
+		assert.Equal(t, []problem.FieldError{
+			{Field: "message", In: "body", Message: "message is required"},
+			{Field: "to", In: "body", Message: "at least one recipient is required"},
+		}, resp.Errors)
+	})
+
+	t.Run("legacy email becomes to", func(t *testing.T) {
+		handler, mockNotifyService := setupHandler()
+
+		mockNotifyService.
+			On("CreateNotify", mock.Anything, mock.MatchedBy(func(n entity.Notify) bool {
+				return n.Email == "a@example.com" && assert.ObjectsAreEqual([]string{"a@example.com"}, n.To)
+			})).Return(entity.Notify{ID: "1"}, nil).Once()
+
+		body, contentType := mustEncode(t, entity.Notify{SendAt: time.Now().Add(time.Minute), Message: "hi", Email: "a@example.com"})
+		req := httptest.NewRequest(http.MethodPost, "/notify", body)
+		req.Header.Set("Content-Type", contentType)
+
+		rec := httptest.NewRecorder()
+		handler.CreateNotify(rec, req)
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		mockNotifyService.AssertExpectations(t)
+	})
+
+	t.Run("invalid recipients", func(t *testing.T) {
+		handler, _ := setupHandler()
+
+		input := entity.Notify{
+			SendAt:  time.Now().Add(time.Minute),
+			Message: "hi",
+			To:      []string{"a@example.com", "bad"},
+			Cc:      []string{"also-bad"},
+		}
+		body, contentType := mustEncode(t, input)
+		req := httptest.NewRequest(http.MethodPost, "/notify", body)
+		req.Header.Set("Content-Type", contentType)
+
+		rec := httptest.NewRecorder()
+		handler.CreateNotify(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		var resp problem.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, []problem.FieldError{
+			{Field: "to.1", In: "body", Message: "invalid email format"},
+			{Field: "cc.0", In: "body", Message: "invalid email format"},
+		}, resp.Errors)
+	})
+
+	t.Run("invalid headers", func(t *testing.T) {
+		handler, _ := setupHandler()
+
+		input := entity.Notify{
+			SendAt:  time.Now().Add(time.Minute),
+			Message: "hi",
+			Email:   "a@example.com",
+			Subject: "hi\r\nBcc: victim@example.com",
+			ReplyTo: []string{"bad"},
+			Headers: map[string]string{"X-Tag": "a\nb", "From": "boss@example.com", "X-Ok": "ok"},
+		}
+		body, contentType := mustEncode(t, input)
+		req := httptest.NewRequest(http.MethodPost, "/notify", body)
+		req.Header.Set("Content-Type", contentType)
+
+		rec := httptest.NewRecorder()
+		handler.CreateNotify(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		var resp problem.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, []problem.FieldError{
+			{Field: "subject", In: "body", Message: "subject must not contain line breaks or control characters"},
+			{Field: "reply_to.0", In: "body", Message: "invalid email format"},
+			{Field: "headers.From", In: "body", Message: "header name is invalid or reserved"},
+			{Field: "headers.X-Tag", In: "body", Message: "header value must not contain line breaks or control characters"},
+		}, resp.Errors)
+	})
+
+	t.Run("unknown recipient list", func(t *testing.T) {
+		handler, mockNotifyService := setupHandler()
+
+		mockNotifyService.On("CreateNotify", mock.Anything, mock.Anything).Return(entity.Notify{}, entity.ValidationError{
+			{Field: "recipient_lists.0", Message: "recipient list l1 not found"},
+		}).Once()
+
+		body, contentType := mustEncode(t, entity.Notify{SendAt: time.Now().Add(time.Minute), Message: "hi", RecipientLists: []string{"l1"}})
+		req := httptest.NewRequest(http.MethodPost, "/notify", body)
+		req.Header.Set("Content-Type", contentType)
+
+		rec := httptest.NewRecorder()
+		handler.CreateNotify(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"field":"recipient_lists.0"`)
 	})
 
 	t.Run("internal error", func(t *testing.T) {
@@ -275,3 +376,85 @@ func TestDeleteNotify(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 }
+
+func TestGetDeliveries(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		handler, mockService := setupHandler()
+
+		deliveries := []entity.Delivery{
+			{NotifyID: "123", Email: "a@example.com", Kind: entity.RecipientTo, Status: entity.StatusSent, Attempts: 1},
+			{NotifyID: "123", Email: "b@example.com", Kind: entity.RecipientBcc, ListID: "l1", Status: entity.StatusFailed, Attempts: 2, LastError: "timeout"},
+		}
+		mockService.On("GetDeliveries", mock.Anything, "123").Return(deliveries, nil).Once()
+
+		req := addNotifyIDToCtx(httptest.NewRequest(http.MethodGet, "/notify/123/deliveries", nil), "123")
+		rec := httptest.NewRecorder()
+		handler.GetDeliveries(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var actual []entity.Delivery
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&actual))
+		assert.Equal(t, deliveries, actual)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		handler, mockService := setupHandler()
+
+		mockService.On("GetDeliveries", mock.Anything, "404").Return(nil, entity.ErrNotifyNotFound).Once()
+
+		req := addNotifyIDToCtx(httptest.NewRequest(http.MethodGet, "/notify/404/deliveries", nil), "404")
+		rec := httptest.NewRecorder()
+		handler.GetDeliveries(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"notify_not_found"`)
+	})
+}
+
+func TestGetAttachment(t *testing.T) {
+	newRequest := func(notifyID, attachmentID string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/notify/"+notifyID+"/attachments/"+attachmentID, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("notifyID", notifyID)
+		rctx.URLParams.Add("attachmentID", attachmentID)
+		return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	}
+
+	t.Run("success", func(t *testing.T) {
+		handler, mockService := setupHandler()
+		mockService.On("GetAttachment", mock.Anything, "123", "a1").Return(entity.Attachment{
+			ID: "a1", Filename: "отчёт.csv", ContentType: "text/csv", Size: 8, Content: []byte("a,b\n1,2\n"),
+		}, nil).Once()
+
+		rec := httptest.NewRecorder()
+		handler.GetAttachment(rec, newRequest("123", "a1"))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "attachment; filename*=utf-8''%D0%BE%D1%82%D1%87%D1%91%D1%82.csv", rec.Header().Get("Content-Disposition"))
+		assert.Equal(t, "a,b\n1,2\n", rec.Body.String())
+	})
+
+	t.Run("attachment not found", func(t *testing.T) {
+		handler, mockService := setupHandler()
+		mockService.On("GetAttachment", mock.Anything, "123", "missing").Return(entity.Attachment{}, entity.ErrAttachmentNotFound).Once()
+
+		rec := httptest.NewRecorder()
+		handler.GetAttachment(rec, newRequest("123", "missing"))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"attachment_not_found"`)
+	})
+
+	t.Run("notify not found", func(t *testing.T) {
+		handler, mockService := setupHandler()
+		mockService.On("GetAttachment", mock.Anything, "404", "a1").Return(entity.Attachment{}, entity.ErrNotifyNotFound).Once()
+
+		rec := httptest.NewRecorder()
+		handler.GetAttachment(rec, newRequest("404", "a1"))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"notify_not_found"`)
+	})
+}
@@ -0,0 +1,30 @@
+package http
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+type OpenAPIHandler struct {
+	spec   []byte
+	logger *slog.Logger
+}
+
+func NewOpenAPIHandler(doc *openapi3.T, logger *slog.Logger) (*OpenAPIHandler, error) {
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &OpenAPIHandler{spec: spec, logger: logger}, nil
+}
+
+// Spec отдаёт спецификацию API в формате JSON.
+func (h *OpenAPIHandler) Spec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(h.spec); err != nil {
+		h.logger.ErrorContext(r.Context(), "failed to write openapi spec", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,135 @@
+// Package problem — ответы об ошибках HTTP API в формате RFC 7807
+// (application/problem+json) со стабильными машиночитаемыми кодами.
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/requestid"
+)
+
+const ContentType = "application/problem+json"
+
+// Коды ошибок — часть контракта API: клиенты ветвятся по ним, поэтому коды
+// не переименовываются и не удаляются, только добавляются новые.
+const (
+	CodeValidationFailed      = "validation_failed"
+	CodeInvalidBody           = "invalid_body"
+	CodePayloadTooLarge       = "payload_too_large"
+	CodeUnsupportedMediaType  = "unsupported_media_type"
+	CodeNotifyNotFound        = "notify_not_found"
+	CodeRecipientListNotFound = "recipient_list_not_found"
+	CodeAttachmentNotFound    = "attachment_not_found"
+	CodeSuppressionNotFound   = "suppression_not_found"
+	CodeUnauthorized          = "unauthorized"
+	CodeRouteNotFound         = "route_not_found"
+	CodeMethodNotAllowed      = "method_not_allowed"
+	CodeInternal              = "internal_error"
+)
+
+var kinds = map[string]struct {
+	status int
+	title  string
+}{
+	CodeValidationFailed:      {http.StatusBadRequest, "Request validation failed"},
+	CodeInvalidBody:           {http.StatusBadRequest, "Malformed request body"},
+	CodePayloadTooLarge:       {http.StatusRequestEntityTooLarge, "Request body too large"},
+	CodeUnsupportedMediaType:  {http.StatusUnsupportedMediaType, "Unsupported media type"},
+	CodeNotifyNotFound:        {http.StatusNotFound, "Notify not found"},
+	CodeRecipientListNotFound: {http.StatusNotFound, "Recipient list not found"},
+	CodeAttachmentNotFound:    {http.StatusNotFound, "Attachment not found"},
+	CodeSuppressionNotFound:   {http.StatusNotFound, "Suppression not found"},
+	CodeUnauthorized:          {http.StatusUnauthorized, "Unauthorized"},
+	CodeRouteNotFound:         {http.StatusNotFound, "Route not found"},
+	CodeMethodNotAllowed:      {http.StatusMethodNotAllowed, "Method not allowed"},
+	CodeInternal:              {http.StatusInternalServerError, "Internal server error"},
+}
+
+// FieldError — ошибка в одном поле тела (in=body) или параметре запроса (query, path, header).
+type FieldError struct {
+	Field   string `json:"field"`
+	In      string `json:"in,omitempty"`
+	Message string `json:"message"`
+}
+
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Code — стабильный код ошибки, Type построен из него
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// New создаёт ответ по коду ошибки; статус и заголовок берутся из таблицы кодов.
+func New(code, detail string) *Problem {
+	kind, ok := kinds[code]
+	if !ok {
+		code, kind = CodeInternal, kinds[CodeInternal]
+	}
+	return &Problem{
+		Type:   "urn:delayed-notifier:problem:" + code,
+		Title:  kind.title,
+		Status: kind.status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// Validation — ответ validation_failed со списком ошибок по полям.
+func Validation(errs ...FieldError) *Problem {
+	p := New(CodeValidationFailed, "request has invalid fields")
+	p.Errors = errs
+	return p
+}
+
+// FromValidationError переводит ошибки entity в ошибки полей места in.
+func FromValidationError(in string, errs entity.ValidationError) *Problem {
+	fields := make([]FieldError, 0, len(errs))
+	for _, e := range errs {
+		fields = append(fields, FieldError{Field: e.Field, In: in, Message: e.Message})
+	}
+	return Validation(fields...)
+}
+
+// Body выбирает ответ на ошибку чтения тела: слишком большое тело отличается от неразборчивого.
+func Body(err error) *Problem {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return New(CodePayloadTooLarge, fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit))
+	}
+	return New(CodeInvalidBody, "invalid request body")
+}
+
+// Write отправляет ответ, дополняя его путём запроса и request ID.
+func Write(w http.ResponseWriter, r *http.Request, p *Problem, logger *slog.Logger) {
+	p.Instance = r.URL.Path
+	p.RequestID = requestid.FromContext(r.Context())
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	if err := json.NewEncoder(w).Encode(p); err != nil {
+		logger.ErrorContext(r.Context(), "failed to encode problem", slog.Any("error", err))
+	}
+}
+
+// NotFound и MethodNotAllowed заменяют текстовые ответы chi по умолчанию.
+func NotFound(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		Write(w, r, New(CodeRouteNotFound, "no route for "+r.URL.Path), logger)
+	}
+}
+
+func MethodNotAllowed(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		Write(w, r, New(CodeMethodNotAllowed, r.Method+" is not allowed for "+r.URL.Path), logger)
+	}
+}
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"delayed-notifier/internal/controller"
+	"delayed-notifier/internal/controller/http/problem"
+	"delayed-notifier/internal/entity"
+)
+
+type RecipientListHandler struct {
+	service controller.RecipientListService
+	logger  *slog.Logger
+}
+
+func NewRecipientListHandler(service controller.RecipientListService, logger *slog.Logger) *RecipientListHandler {
+	return &RecipientListHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *RecipientListHandler) CreateRecipientList(w http.ResponseWriter, r *http.Request) {
+	input, ok := h.decode(w, r)
+	if !ok {
+		return
+	}
+
+	created, err := h.service.CreateRecipientList(r.Context(), input)
+	if err != nil {
+		h.writeServiceError(w, r, "failed to create recipient list", err)
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "recipient list created", slog.String("id", created.ID))
+	h.writeJSON(w, r, http.StatusCreated, created)
+}
+
+func (h *RecipientListHandler) ListRecipientLists(w http.ResponseWriter, r *http.Request) {
+	lists, err := h.service.ListRecipientLists(r.Context())
+	if err != nil {
+		h.writeServiceError(w, r, "failed to list recipient lists", err)
+		return
+	}
+	if lists == nil {
+		lists = []entity.RecipientList{}
+	}
+	h.writeJSON(w, r, http.StatusOK, lists)
+}
+
+func (h *RecipientListHandler) GetRecipientList(w http.ResponseWriter, r *http.Request) {
+	list, err := h.service.GetRecipientList(r.Context(), chi.URLParam(r, "listID"))
+	if err != nil {
+		h.writeServiceError(w, r, "failed to get recipient list", err)
+		return
+	}
+	h.writeJSON(w, r, http.StatusOK, list)
+}
+
+// UpdateRecipientList заменяет название и участников списка целиком.
+func (h *RecipientListHandler) UpdateRecipientList(w http.ResponseWriter, r *http.Request) {
+	input, ok := h.decode(w, r)
+	if !ok {
+		return
+	}
+
+	input.ID = chi.URLParam(r, "listID")
+	updated, err := h.service.UpdateRecipientList(r.Context(), input)
+	if err != nil {
+		h.writeServiceError(w, r, "failed to update recipient list", err)
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "recipient list updated", slog.String("id", updated.ID))
+	h.writeJSON(w, r, http.StatusOK, updated)
+}
+
+func (h *RecipientListHandler) DeleteRecipientList(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "listID")
+	if err := h.service.DeleteRecipientList(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, "failed to delete recipient list", err)
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "recipient list deleted", slog.String("id", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decode читает и проверяет тело запроса; при ошибке ответ уже записан.
+func (h *RecipientListHandler) decode(w http.ResponseWriter, r *http.Request) (entity.RecipientList, bool) {
+	var input entity.RecipientList
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		h.logger.ErrorContext(r.Context(), "invalid request body", slog.Any("error", err))
+		problem.Write(w, r, problem.Body(err), h.logger)
+		return entity.RecipientList{}, false
+	}
+
+	if err := input.Validate(); err != nil {
+		h.logger.InfoContext(r.Context(), "validation error", slog.Any("error", err))
+		var verr entity.ValidationError
+		if errors.As(err, &verr) {
+			problem.Write(w, r, problem.FromValidationError("body", verr), h.logger)
+			return entity.RecipientList{}, false
+		}
+		problem.Write(w, r, problem.Validation(problem.FieldError{In: "body", Message: err.Error()}), h.logger)
+		return entity.RecipientList{}, false
+	}
+	return input, true
+}
+
+func (h *RecipientListHandler) writeServiceError(w http.ResponseWriter, r *http.Request, message string, err error) {
+	if errors.Is(err, entity.ErrRecipientListNotFound) {
+		problem.Write(w, r, problem.New(problem.CodeRecipientListNotFound, "recipient list not found"), h.logger)
+		return
+	}
+	h.logger.ErrorContext(r.Context(), message, slog.Any("error", err))
+	problem.Write(w, r, problem.New(problem.CodeInternal, message), h.logger)
+}
+
+func (h *RecipientListHandler) writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.ErrorContext(r.Context(), "failed to encode response", slog.Any("error", err))
+	}
+}
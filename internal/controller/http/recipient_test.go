@@ -0,0 +1,115 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/controller/http/problem"
+	"delayed-notifier/internal/entity"
+	mock_service "delayed-notifier/internal/service/mocks"
+)
+
+func setupRecipientListHandler(t *testing.T) (*RecipientListHandler, *mock_service.RecipientListService) {
+	mockService := mock_service.NewRecipientListService(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewRecipientListHandler(mockService, logger), mockService
+}
+
+func addListIDToCtx(req *http.Request, listID string) *http.Request {
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"listID"},
+			Values: []string{listID},
+		},
+	})
+	return req.WithContext(ctx)
+}
+
+func TestCreateRecipientList(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		handler, mockService := setupRecipientListHandler(t)
+
+		input := entity.RecipientList{Name: "ops", Members: []string{"a@example.com"}}
+		created := input
+		created.ID = "l1"
+		mockService.On("CreateRecipientList", mock.Anything, input).Return(created, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/recipient-lists", strings.NewReader(`{"name":"ops","members":["a@example.com"]}`))
+		rec := httptest.NewRecorder()
+		handler.CreateRecipientList(rec, req)
+
+		require.Equal(t, http.StatusCreated, rec.Code)
+		var actual entity.RecipientList
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&actual))
+		assert.Equal(t, "l1", actual.ID)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		handler, _ := setupRecipientListHandler(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/recipient-lists", strings.NewReader(`{"members":["a@example.com","bad"]}`))
+		rec := httptest.NewRecorder()
+		handler.CreateRecipientList(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		var resp problem.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, []problem.FieldError{
+			{Field: "name", In: "body", Message: "name is required"},
+			{Field: "members.1", In: "body", Message: "invalid email format"},
+		}, resp.Errors)
+	})
+}
+
+func TestUpdateRecipientList(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		handler, mockService := setupRecipientListHandler(t)
+
+		input := entity.RecipientList{ID: "l1", Name: "ops", Members: []string{"b@example.com"}}
+		mockService.On("UpdateRecipientList", mock.Anything, input).Return(input, nil).Once()
+
+		body := strings.NewReader(`{"name":"ops","members":["b@example.com"]}`)
+		req := addListIDToCtx(httptest.NewRequest(http.MethodPut, "/recipient-lists/l1", body), "l1")
+		rec := httptest.NewRecorder()
+		handler.UpdateRecipientList(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		handler, mockService := setupRecipientListHandler(t)
+
+		mockService.On("UpdateRecipientList", mock.Anything, mock.Anything).Return(entity.RecipientList{}, entity.ErrRecipientListNotFound).Once()
+
+		body := strings.NewReader(`{"name":"ops","members":["b@example.com"]}`)
+		req := addListIDToCtx(httptest.NewRequest(http.MethodPut, "/recipient-lists/l1", body), "l1")
+		rec := httptest.NewRecorder()
+		handler.UpdateRecipientList(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"recipient_list_not_found"`)
+	})
+}
+
+func TestDeleteRecipientList(t *testing.T) {
+	handler, mockService := setupRecipientListHandler(t)
+
+	mockService.On("DeleteRecipientList", mock.Anything, "l1").Return(nil).Once()
+
+	req := addListIDToCtx(httptest.NewRequest(http.MethodDelete, "/recipient-lists/l1", nil), "l1")
+	rec := httptest.NewRecorder()
+	handler.DeleteRecipientList(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
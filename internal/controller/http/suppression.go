@@ -0,0 +1,100 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"delayed-notifier/internal/controller"
+	"delayed-notifier/internal/controller/http/problem"
+	"delayed-notifier/internal/entity"
+)
+
+type SuppressionHandler struct {
+	service controller.SuppressionService
+	logger  *slog.Logger
+}
+
+func NewSuppressionHandler(service controller.SuppressionService, logger *slog.Logger) *SuppressionHandler {
+	return &SuppressionHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ListSuppressions принимает query-параметры email (подстрока адреса) и limit.
+func (h *SuppressionHandler) ListSuppressions(w http.ResponseWriter, r *http.Request) {
+	filter := entity.SuppressionFilter{Email: r.URL.Query().Get("email")}
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 0 {
+			problem.Write(w, r, problem.FromValidationError("query", entity.ValidationError{
+				{Field: "limit", Message: "invalid limit"},
+			}), h.logger)
+			return
+		}
+		filter.Limit = limit
+	}
+
+	suppressions, err := h.service.ListSuppressions(r.Context(), filter)
+	if err != nil {
+		h.writeServiceError(w, r, "failed to list suppressions", err)
+		return
+	}
+	if suppressions == nil {
+		suppressions = []entity.Suppression{}
+	}
+	h.writeJSON(w, r, http.StatusOK, suppressions)
+}
+
+func (h *SuppressionHandler) GetSuppression(w http.ResponseWriter, r *http.Request) {
+	suppression, err := h.service.GetSuppression(r.Context(), emailParam(r))
+	if err != nil {
+		h.writeServiceError(w, r, "failed to get suppression", err)
+		return
+	}
+	h.writeJSON(w, r, http.StatusOK, suppression)
+}
+
+// DeleteSuppression снова разрешает отправку писем на адрес.
+func (h *SuppressionHandler) DeleteSuppression(w http.ResponseWriter, r *http.Request) {
+	email := emailParam(r)
+	if err := h.service.DeleteSuppression(r.Context(), email); err != nil {
+		h.writeServiceError(w, r, "failed to delete suppression", err)
+		return
+	}
+
+	h.logger.InfoContext(r.Context(), "suppression deleted", slog.String("email", email))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// emailParam возвращает адрес из пути; клиенты могут передать @ как %40
+func emailParam(r *http.Request) string {
+	raw := chi.URLParam(r, "email")
+	if email, err := url.PathUnescape(raw); err == nil {
+		return email
+	}
+	return raw
+}
+
+func (h *SuppressionHandler) writeServiceError(w http.ResponseWriter, r *http.Request, message string, err error) {
+	if errors.Is(err, entity.ErrSuppressionNotFound) {
+		problem.Write(w, r, problem.New(problem.CodeSuppressionNotFound, "suppression not found"), h.logger)
+		return
+	}
+	h.logger.ErrorContext(r.Context(), message, slog.Any("error", err))
+	problem.Write(w, r, problem.New(problem.CodeInternal, message), h.logger)
+}
+
+func (h *SuppressionHandler) writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.ErrorContext(r.Context(), "failed to encode response", slog.Any("error", err))
+	}
+}
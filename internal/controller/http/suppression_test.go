@@ -0,0 +1,110 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/controller/http/problem"
+	"delayed-notifier/internal/entity"
+	mock_service "delayed-notifier/internal/service/mocks"
+)
+
+func setupSuppressionHandler(t *testing.T) (*SuppressionHandler, *mock_service.SuppressionService) {
+	mockService := mock_service.NewSuppressionService(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewSuppressionHandler(mockService, logger), mockService
+}
+
+func addEmailToCtx(req *http.Request, email string) *http.Request {
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, &chi.Context{
+		URLParams: chi.RouteParams{
+			Keys:   []string{"email"},
+			Values: []string{email},
+		},
+	})
+	return req.WithContext(ctx)
+}
+
+func TestListSuppressions(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		handler, mockService := setupSuppressionHandler(t)
+
+		filter := entity.SuppressionFilter{Email: "example.com", Limit: 10}
+		mockService.On("ListSuppressions", mock.Anything, filter).
+			Return([]entity.Suppression{{Email: "a@example.com", Reason: entity.SuppressionHardBounce, Code: 550}}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/suppressions?email=example.com&limit=10", nil)
+		rec := httptest.NewRecorder()
+		handler.ListSuppressions(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var actual []entity.Suppression
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&actual))
+		require.Len(t, actual, 1)
+		assert.Equal(t, "a@example.com", actual[0].Email)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		handler, _ := setupSuppressionHandler(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/suppressions?limit=-1", nil)
+		rec := httptest.NewRecorder()
+		handler.ListSuppressions(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestGetSuppression(t *testing.T) {
+	t.Run("escaped email", func(t *testing.T) {
+		handler, mockService := setupSuppressionHandler(t)
+
+		mockService.On("GetSuppression", mock.Anything, "a@example.com").
+			Return(entity.Suppression{Email: "a@example.com"}, nil).Once()
+
+		req := addEmailToCtx(httptest.NewRequest(http.MethodGet, "/suppressions/a%40example.com", nil), "a%40example.com")
+		rec := httptest.NewRecorder()
+		handler.GetSuppression(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		handler, mockService := setupSuppressionHandler(t)
+
+		mockService.On("GetSuppression", mock.Anything, "a@example.com").
+			Return(entity.Suppression{}, fmt.Errorf("GetSuppression: %w", entity.ErrSuppressionNotFound)).Once()
+
+		req := addEmailToCtx(httptest.NewRequest(http.MethodGet, "/suppressions/a@example.com", nil), "a@example.com")
+		rec := httptest.NewRecorder()
+		handler.GetSuppression(rec, req)
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		var resp problem.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, problem.CodeSuppressionNotFound, resp.Code)
+	})
+}
+
+func TestDeleteSuppression(t *testing.T) {
+	handler, mockService := setupSuppressionHandler(t)
+
+	mockService.On("DeleteSuppression", mock.Anything, "a@example.com").Return(nil).Once()
+
+	req := addEmailToCtx(httptest.NewRequest(http.MethodDelete, "/suppressions/a@example.com", nil), "a@example.com")
+	rec := httptest.NewRecorder()
+	handler.DeleteSuppression(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"delayed-notifier/internal/controller"
+)
+
+// Reaper периодически восстанавливает уведомления, зависшие в статусе queued.
+type Reaper struct {
+	service  controller.ReaperService
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+func NewReaper(service controller.ReaperService, interval time.Duration, logger *slog.Logger) *Reaper {
+	return &Reaper{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+func (r *Reaper) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("reaper stopped")
+			return
+		case <-ticker.C:
+			r.reap(ctx)
+		}
+	}
+}
+
+func (r *Reaper) reap(ctx context.Context) {
+	for {
+		report, err := r.service.ReapStuckNotifies(ctx)
+		if err != nil {
+			r.logger.Error("reap error", slog.Any("error", err))
+			return
+		}
+		if report.Requeued > 0 || report.Failed > 0 {
+			r.logger.Info("stuck notifies reaped", slog.Int("requeued", report.Requeued), slog.Int("failed", report.Failed))
+		}
+		if !report.HasMore || ctx.Err() != nil {
+			return
+		}
+	}
+}
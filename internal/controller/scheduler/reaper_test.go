@@ -0,0 +1,33 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"delayed-notifier/internal/entity"
+	mock_service "delayed-notifier/internal/service/mocks"
+)
+
+func TestReaper(t *testing.T) {
+	service := new(mock_service.ReaperService)
+	runs := make(chan struct{}, 10)
+	service.On("ReapStuckNotifies", mock.Anything).Return(entity.ReapReport{Requeued: 1, HasMore: true}, nil).Once()
+	service.On("ReapStuckNotifies", mock.Anything).Run(func(mock.Arguments) {
+		runs <- struct{}{}
+	}).Return(entity.ReapReport{}, nil)
+
+	r := NewReaper(service, 10*time.Millisecond, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Start(ctx)
+
+	assert.True(t, waitRun(t, runs, time.Second), "drains batches")
+	cancel()
+	service.AssertCalled(t, "ReapStuckNotifies", mock.Anything)
+}
@@ -0,0 +1,115 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"delayed-notifier/internal/controller"
+	"delayed-notifier/internal/entity"
+)
+
+// Scheduler ставит наступившие уведомления в очередь. Вместо опроса с фиксированным
+// интервалом он спит до ближайшего send_at (но не дольше maxInterval) и просыпается
+// раньше, если пришло событие о более раннем уведомлении.
+type Scheduler struct {
+	service controller.SchedulerService
+	wakeups <-chan entity.ScheduleEvent
+	cfg     Config
+	logger  *slog.Logger
+}
+
+type Config struct {
+	MinInterval time.Duration
+	MaxInterval time.Duration
+	// Grace — задержка относительно send_at. Когда включено колесо таймеров,
+	// планировщик служит страховкой и подбирает только то, что колесо пропустило.
+	Grace time.Duration
+}
+
+func NewScheduler(service controller.SchedulerService, wakeups <-chan entity.ScheduleEvent, cfg Config, logger *slog.Logger) *Scheduler {
+	return &Scheduler{
+		service: service,
+		wakeups: wakeups,
+		cfg:     cfg,
+		logger:  logger,
+	}
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	deadline := time.Now()
+
+	wakeups := s.wakeups
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("scheduler stopped")
+			return
+
+		case <-timer.C:
+			report, err := s.service.ScheduleReadyNotifies(ctx)
+			if err != nil {
+				s.logger.Error("schedule error", slog.Any("error", err))
+			}
+			if len(report.Skipped) > 0 || report.Rescheduled > 0 {
+				s.logger.Info("catch-up applied",
+					slog.Int("enqueued", report.Enqueued),
+					slog.Int("rescheduled", report.Rescheduled),
+					slog.Int("skipped", len(report.Skipped)),
+				)
+			}
+			// Пачка заполнена целиком — забираем следующую сразу
+			if report.HasMore {
+				deadline = time.Now()
+				timer.Reset(0)
+				continue
+			}
+			wait := s.nextWait(ctx)
+			deadline = time.Now().Add(wait)
+			timer.Reset(wait)
+
+		case event, ok := <-wakeups:
+			if !ok {
+				// Listener остановлен — остаёмся на опросе с maxInterval
+				wakeups = nil
+				continue
+			}
+			// Пустое событие — переподключение listener'а, расписание нужно перечитать
+			if event.SendAt.IsZero() {
+				deadline = time.Now()
+				timer.Reset(0)
+				continue
+			}
+			wakeAt := event.SendAt.Add(s.cfg.Grace)
+			if wakeAt.Before(deadline) {
+				s.logger.Debug("scheduler woken up", slog.String("id", event.ID), slog.Time("send_at", event.SendAt))
+				// Просроченное событие приходит и при возврате уведомления после ошибки
+				// Kafka — не чаще minInterval, чтобы не крутиться вхолостую
+				wait := max(time.Until(wakeAt), s.cfg.MinInterval)
+				deadline = time.Now().Add(wait)
+				timer.Reset(wait)
+			}
+		}
+	}
+}
+
+func (s *Scheduler) nextWait(ctx context.Context) time.Duration {
+	next, ok, err := s.service.NextSendAt(ctx)
+	if err != nil {
+		s.logger.Error("failed to get next send time", slog.Any("error", err))
+		return s.cfg.MaxInterval
+	}
+	if !ok {
+		return s.cfg.MaxInterval
+	}
+
+	// Просроченные уведомления остались после прогона (например, ошибка Kafka) —
+	// повторяем не чаще minInterval, чтобы не крутиться вхолостую
+	wait := time.Until(next.Add(s.cfg.Grace))
+	if wait <= 0 {
+		wait = s.cfg.MinInterval
+	}
+	return min(wait, s.cfg.MaxInterval)
+}
@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"delayed-notifier/internal/entity"
+	mock_service "delayed-notifier/internal/service/mocks"
+)
+
+func setupScheduler(t *testing.T, maxInterval time.Duration) (*mock_service.SchedulerService, chan entity.ScheduleEvent, chan struct{}, *Scheduler) {
+	t.Helper()
+
+	service := new(mock_service.SchedulerService)
+	wakeups := make(chan entity.ScheduleEvent, 1)
+	runs := make(chan struct{}, 10)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	service.On("ScheduleReadyNotifies", mock.Anything).Run(func(mock.Arguments) {
+		runs <- struct{}{}
+	}).Return(entity.ScheduleReport{}, nil)
+
+	return service, wakeups, runs, NewScheduler(service, wakeups, Config{MinInterval: 10 * time.Millisecond, MaxInterval: maxInterval}, logger)
+}
+
+func waitRun(t *testing.T, runs <-chan struct{}, timeout time.Duration) bool {
+	t.Helper()
+	select {
+	case <-runs:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+func TestSchedulerWakeup(t *testing.T) {
+	t.Run("sleeps until next send_at", func(t *testing.T) {
+		service, _, runs, s := setupScheduler(t, time.Hour)
+		service.On("NextSendAt", mock.Anything).Return(time.Now().Add(50*time.Millisecond), true, nil).Once()
+		service.On("NextSendAt", mock.Anything).Return(time.Time{}, false, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go s.Start(ctx)
+
+		assert.True(t, waitRun(t, runs, time.Second), "initial run")
+		assert.True(t, waitRun(t, runs, time.Second), "run at next send_at")
+		assert.False(t, waitRun(t, runs, 100*time.Millisecond), "no runs while idle")
+	})
+
+	t.Run("woken up by earlier notify", func(t *testing.T) {
+		service, wakeups, runs, s := setupScheduler(t, time.Hour)
+		service.On("NextSendAt", mock.Anything).Return(time.Time{}, false, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go s.Start(ctx)
+
+		assert.True(t, waitRun(t, runs, time.Second), "initial run")
+		wakeups <- entity.ScheduleEvent{ID: "id1", SendAt: time.Now().Add(20 * time.Millisecond)}
+		assert.True(t, waitRun(t, runs, time.Second), "run after wakeup")
+	})
+
+	t.Run("later notify does not delay", func(t *testing.T) {
+		service, wakeups, runs, s := setupScheduler(t, time.Hour)
+		service.On("NextSendAt", mock.Anything).Return(time.Now().Add(50*time.Millisecond), true, nil).Once()
+		service.On("NextSendAt", mock.Anything).Return(time.Time{}, false, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go s.Start(ctx)
+
+		assert.True(t, waitRun(t, runs, time.Second), "initial run")
+		wakeups <- entity.ScheduleEvent{ID: "id2", SendAt: time.Now().Add(time.Hour)}
+		assert.True(t, waitRun(t, runs, time.Second), "run at earlier send_at")
+	})
+}
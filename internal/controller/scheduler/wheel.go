@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"delayed-notifier/internal/controller"
+	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/timingwheel"
+)
+
+const (
+	wheelTick = time.Second
+	wheelSize = 60
+
+	// WheelGrace — задержка планировщика относительно send_at при включённом колесе
+	WheelGrace = 2 * time.Second
+)
+
+// WheelScheduler держит в памяти уведомления на ближайшие Horizon и ставит их в очередь
+// точно в свою секунду. Колесо не является источником истины: оно перечитывается из БД
+// при старте и раз в ReloadInterval, а повторную отправку исключает захват в БД.
+type WheelScheduler struct {
+	service controller.WheelService
+	events  <-chan entity.ScheduleEvent
+	cfg     WheelConfig
+	wheel   *timingwheel.Wheel
+	logger  *slog.Logger
+}
+
+type WheelConfig struct {
+	Horizon        time.Duration
+	ReloadInterval time.Duration
+}
+
+func NewWheelScheduler(service controller.WheelService, events <-chan entity.ScheduleEvent, cfg WheelConfig, logger *slog.Logger) *WheelScheduler {
+	return &WheelScheduler{
+		service: service,
+		events:  events,
+		cfg:     cfg,
+		logger:  logger.With(slog.String("component", "timing wheel")),
+	}
+}
+
+func (w *WheelScheduler) Start(ctx context.Context) {
+	w.reload(ctx)
+
+	tick := time.NewTimer(untilNextTick(time.Now()))
+	defer tick.Stop()
+	reload := time.NewTicker(w.cfg.ReloadInterval)
+	defer reload.Stop()
+
+	events := w.events
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("timing wheel stopped")
+			return
+
+		case now := <-tick.C:
+			for _, id := range w.wheel.Advance(now) {
+				w.dispatch(ctx, id)
+			}
+			tick.Reset(untilNextTick(time.Now()))
+
+		case <-reload.C:
+			w.reload(ctx)
+
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			if event.SendAt.IsZero() {
+				w.reload(ctx)
+				continue
+			}
+			w.add(ctx, event.ID, event.SendAt)
+		}
+	}
+}
+
+// reload строит колесо заново по данным БД, отбрасывая устаревшие элементы.
+func (w *WheelScheduler) reload(ctx context.Context) {
+	now := time.Now()
+	w.wheel = newWheel(now, w.cfg.Horizon)
+
+	notifies, err := w.service.GetUpcomingNotifies(ctx, now.Add(w.cfg.Horizon))
+	if err != nil {
+		w.logger.Error("failed to load upcoming notifies", slog.Any("error", err))
+		return
+	}
+	for _, notify := range notifies {
+		w.add(ctx, notify.ID, notify.SendAt)
+	}
+	w.logger.Debug("timing wheel reloaded", slog.Int("size", w.wheel.Len()))
+}
+
+func (w *WheelScheduler) add(ctx context.Context, id string, sendAt time.Time) {
+	// Уведомление перенесли за горизонт — его подберёт очередная перезагрузка
+	if time.Until(sendAt) > w.cfg.Horizon {
+		w.wheel.Remove(id)
+		return
+	}
+
+	if due, _ := w.wheel.Add(id, sendAt); due {
+		w.dispatch(ctx, id)
+	}
+}
+
+func (w *WheelScheduler) dispatch(ctx context.Context, id string) {
+	if err := w.service.DispatchNotify(ctx, id); err != nil {
+		w.logger.Error("failed to dispatch notify", slog.String("ID", id), slog.Any("error", err))
+	}
+}
+
+// newWheel подбирает число уровней так, чтобы колесо вмещало horizon.
+func newWheel(now time.Time, horizon time.Duration) *timingwheel.Wheel {
+	levels := 1
+	for {
+		wheel := timingwheel.New(wheelTick, wheelSize, levels, now)
+		if wheel.Horizon() >= horizon {
+			return wheel
+		}
+		levels++
+	}
+}
+
+func untilNextTick(now time.Time) time.Duration {
+	return now.Truncate(wheelTick).Add(wheelTick).Sub(now)
+}
@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"delayed-notifier/internal/entity"
+	mock_service "delayed-notifier/internal/service/mocks"
+)
+
+func setupWheel(t *testing.T, upcoming []entity.Notify) (chan entity.ScheduleEvent, chan string, *WheelScheduler) {
+	t.Helper()
+
+	service := new(mock_service.WheelService)
+	events := make(chan entity.ScheduleEvent, 1)
+	dispatched := make(chan string, 10)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	service.On("GetUpcomingNotifies", mock.Anything, mock.Anything).Return(upcoming, nil)
+	service.On("DispatchNotify", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		dispatched <- args.String(1)
+	}).Return(nil)
+
+	return events, dispatched, NewWheelScheduler(service, events, WheelConfig{Horizon: time.Minute, ReloadInterval: time.Hour}, logger)
+}
+
+func waitDispatch(t *testing.T, dispatched <-chan string, timeout time.Duration) string {
+	t.Helper()
+	select {
+	case id := <-dispatched:
+		return id
+	case <-time.After(timeout):
+		return ""
+	}
+}
+
+func TestWheelScheduler(t *testing.T) {
+	t.Run("dispatches loaded notifies", func(t *testing.T) {
+		now := time.Now()
+		_, dispatched, w := setupWheel(t, []entity.Notify{
+			{ID: "overdue", SendAt: now.Add(-time.Second)},
+			{ID: "soon", SendAt: now.Add(time.Second)},
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go w.Start(ctx)
+
+		assert.Equal(t, "overdue", waitDispatch(t, dispatched, time.Second))
+		assert.Equal(t, "", waitDispatch(t, dispatched, 500*time.Millisecond), "not before send_at")
+		assert.Equal(t, "soon", waitDispatch(t, dispatched, 3*time.Second))
+	})
+
+	t.Run("adds notify from event", func(t *testing.T) {
+		events, dispatched, w := setupWheel(t, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go w.Start(ctx)
+
+		events <- entity.ScheduleEvent{ID: "far", SendAt: time.Now().Add(time.Hour)}
+		events <- entity.ScheduleEvent{ID: "soon", SendAt: time.Now().Add(time.Second)}
+		assert.Equal(t, "soon", waitDispatch(t, dispatched, 3*time.Second))
+		assert.Equal(t, "", waitDispatch(t, dispatched, 1500*time.Millisecond), "beyond horizon")
+	})
+}
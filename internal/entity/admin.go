@@ -0,0 +1,69 @@
+package entity
+
+import "time"
+
+// Действия администратора над уведомлениями
+const (
+	AdminActionCanceled     = "canceled"
+	AdminActionRescheduled  = "rescheduled"
+	AdminActionResent       = "resent"
+	AdminActionRequeued     = "requeued"
+	AdminActionWouldRequeue = "would_requeue"
+	AdminActionSkipped      = "skipped"
+	AdminActionFailed       = "failed"
+)
+
+// NotifyFilter отбирает уведомления для notifyctl и admin API. Пустые поля не фильтруют.
+type NotifyFilter struct {
+	Status string `json:"status,omitempty"`
+	Email  string `json:"email,omitempty"`
+	// From и To ограничивают send_at: From <= send_at < To
+	From time.Time `json:"from,omitempty"`
+	To   time.Time `json:"to,omitempty"`
+	// Error — подстрока текста последней ошибки отправки
+	Error string `json:"error,omitempty"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+// NotifyDetails — уведомление вместе с историей событий.
+type NotifyDetails struct {
+	Notify
+	QueuedAt  *time.Time    `json:"queued_at,omitempty"`
+	ReapCount int           `json:"reap_count"`
+	LastError string        `json:"last_error,omitempty"`
+	Events    []NotifyEvent `json:"events"`
+	// Deliveries пуст, пока уведомление не отправлялось
+	Deliveries []Delivery `json:"deliveries"`
+}
+
+type NotifyStats struct {
+	// ByStatus — число уведомлений в каждом статусе
+	ByStatus map[string]int `json:"by_status"`
+	// Due — запланированные уведомления, время отправки которых уже наступило (бэклог планировщика)
+	Due       int        `json:"due"`
+	OldestDue *time.Time `json:"oldest_due,omitempty"`
+	// NextSendAt — ближайшее будущее время отправки
+	NextSendAt *time.Time `json:"next_send_at,omitempty"`
+	// OldestQueued — с какого момента самое старое уведомление ждёт в очереди
+	OldestQueued *time.Time `json:"oldest_queued,omitempty"`
+}
+
+type AdminActionRequest struct {
+	// SendAt — новое время отправки для reschedule
+	SendAt time.Time `json:"send_at,omitempty"`
+	Actor  string    `json:"actor"`
+}
+
+// RequeueRequest возвращает в работу уведомления в статусе failed, отобранные фильтром.
+type RequeueRequest struct {
+	Filter NotifyFilter `json:"filter"`
+	DryRun bool         `json:"dry_run"`
+	Actor  string       `json:"actor"`
+}
+
+type AdminResult struct {
+	NotifyID string `json:"notify_id"`
+	Action   string `json:"action"`
+	Status   string `json:"status,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
@@ -0,0 +1,118 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+// Attachment — файл, приложенный к уведомлению. Содержимое лежит в хранилище
+// вложений под ID, с уведомлением хранится только описание.
+type Attachment struct {
+	ID          string `json:"id,omitempty"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size,omitempty"`
+	// Content — содержимое (base64 в JSON); приходит только в запросе на создание
+	Content []byte `json:"content,omitempty"`
+}
+
+// MaxFilenameLength — предел длины имени файла в байтах, как в большинстве файловых систем
+const MaxFilenameLength = 255
+
+// validate проверяет поля, не зависящие от настроек хранилища; i — позиция в attachments.
+func (a *Attachment) validate(i int) ValidationError {
+	var errs ValidationError
+	field := indexed("attachments", i)
+	switch {
+	case a.Filename == "":
+		errs = append(errs, FieldError{Field: field + ".filename", Message: "filename is required"})
+	case len(a.Filename) > MaxFilenameLength:
+		errs = append(errs, FieldError{Field: field + ".filename", Message: "filename is too long"})
+	case strings.ContainsAny(a.Filename, `/\`) || !validHeaderValue(a.Filename):
+		errs = append(errs, FieldError{Field: field + ".filename", Message: "filename must not contain path separators or control characters"})
+	}
+	if _, _, err := mime.ParseMediaType(a.ContentType); err != nil {
+		errs = append(errs, FieldError{Field: field + ".content_type", Message: "invalid content type"})
+	}
+	if len(a.Content) == 0 {
+		errs = append(errs, FieldError{Field: field + ".content", Message: "content is required"})
+	}
+	return errs
+}
+
+// AttachmentPolicy — ограничения на вложения одного уведомления.
+type AttachmentPolicy struct {
+	MaxCount     int
+	MaxSize      int64
+	MaxTotalSize int64
+	// ContentTypes — разрешённые типы без параметров (application/pdf, text/csv)
+	ContentTypes []string
+	// Retention — сколько хранить вложения завершённых уведомлений после send_at
+	Retention time.Duration
+}
+
+// Check проверяет вложения по ограничениям политики. Заявленный тип сверяется
+// с содержимым, чтобы под видом PDF нельзя было отправить HTML или исполняемый файл.
+func (p AttachmentPolicy) Check(attachments []Attachment) ValidationError {
+	var errs ValidationError
+	if len(attachments) > p.MaxCount {
+		errs = append(errs, FieldError{Field: "attachments", Message: fmt.Sprintf("at most %d attachments are allowed", p.MaxCount)})
+	}
+
+	var total int64
+	for i, a := range attachments {
+		field := indexed("attachments", i)
+		size := int64(len(a.Content))
+		total += size
+		if size > p.MaxSize {
+			errs = append(errs, FieldError{Field: field + ".content", Message: fmt.Sprintf("attachment is larger than %d bytes", p.MaxSize)})
+		}
+
+		declared, _, _ := mime.ParseMediaType(a.ContentType)
+		if !slices.Contains(p.ContentTypes, declared) {
+			errs = append(errs, FieldError{Field: field + ".content_type", Message: "content type " + declared + " is not allowed"})
+			continue
+		}
+		sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(a.Content))
+		if !contentMatches(declared, sniffed) {
+			errs = append(errs, FieldError{Field: field + ".content", Message: "content does not match content type " + declared})
+		}
+	}
+	if total > p.MaxTotalSize {
+		errs = append(errs, FieldError{Field: "attachments", Message: fmt.Sprintf("attachments are larger than %d bytes in total", p.MaxTotalSize)})
+	}
+	return errs
+}
+
+// sniffable — типы, которые http.DetectContentType узнаёт по сигнатуре; для них
+// содержимое обязано совпасть с заявленным типом.
+var sniffable = map[string]bool{
+	"application/pdf": true, "application/zip": true, "application/x-gzip": true,
+	"image/png": true, "image/jpeg": true, "image/gif": true, "image/webp": true, "image/bmp": true,
+}
+
+// contentMatches сравнивает заявленный тип с определённым по содержимому
+// (http.DetectContentType). Для типов, которые так не определить, допускается только
+// неузнанное содержимое (octet-stream), но не текст, HTML или известный формат.
+func contentMatches(declared, sniffed string) bool {
+	switch {
+	case declared == sniffed:
+		return true
+	case strings.HasPrefix(declared, "text/"):
+		// CSV и прочий текст определяются как text/plain
+		return sniffed == "text/plain"
+	case strings.HasPrefix(declared, "application/vnd.openxmlformats-officedocument."):
+		// Документы Office — zip-архивы
+		return sniffed == "application/zip"
+	case sniffable[declared]:
+		return false
+	}
+	return sniffed == "application/octet-stream"
+}
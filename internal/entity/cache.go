@@ -0,0 +1,19 @@
+package entity
+
+// CacheStats — статистика чтения уведомлений через кэш.
+type CacheStats struct {
+	// Hits — найдено в кэше, NegativeHits — в кэше записано, что уведомления нет
+	Hits         int64
+	NegativeHits int64
+	// Misses — пришлось идти в БД; Coalesced — из них запросов, дождавшихся чужого чтения из БД
+	Misses    int64
+	Coalesced int64
+}
+
+// LocalCacheStats — статистика in-process LRU-кэша.
+type LocalCacheStats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+	Size      int
+}
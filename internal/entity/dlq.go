@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+const (
+	DLQReasonInvalidJSON      = "invalid_json"
+	DLQReasonProcessingFailed = "processing_failed"
+)
+
+const (
+	DLQActionReplayed        = "replayed"
+	DLQActionRescheduled     = "rescheduled"
+	DLQActionWouldReplay     = "would_replay"
+	DLQActionWouldReschedule = "would_reschedule"
+	DLQActionSkipped         = "skipped"
+	DLQActionFailed          = "failed"
+)
+
+type DLQEntry struct {
+	Partition    int       `json:"partition"`
+	Offset       int64     `json:"offset"`
+	NotifyID     string    `json:"notify_id,omitempty"`
+	Reason       string    `json:"reason"`
+	ErrorMessage string    `json:"error_message"`
+	Timestamp    time.Time `json:"timestamp"`
+	Payload      string    `json:"payload,omitempty"`
+}
+
+type DLQFilter struct {
+	Reason string
+	Limit  int
+}
+
+type DLQPosition struct {
+	Partition int   `json:"partition"`
+	Offset    int64 `json:"offset"`
+}
+
+type DLQReplayRequest struct {
+	Entries    []DLQPosition `json:"entries"`
+	All        bool          `json:"all"`
+	Reason     string        `json:"reason"`
+	DryRun     bool          `json:"dry_run"`
+	Reschedule bool          `json:"reschedule"`
+	Actor      string        `json:"actor"`
+}
+
+type DLQReplayResult struct {
+	Partition int    `json:"partition"`
+	Offset    int64  `json:"offset"`
+	NotifyID  string `json:"notify_id,omitempty"`
+	Action    string `json:"action"`
+	Status    string `json:"status,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+// Действия, записываемые в историю уведомления (таблица notify_events)
+const (
+	EventReaperRequeued = "reaper_requeued"
+	EventReaperFailed   = "reaper_failed"
+	EventReaperError    = "reaper_error"
+	// EventSendFailed хранит текст ошибки отправки в details
+	EventSendFailed = "send_failed"
+	// EventSuppressed — письмо получателю из списка подавления не отправлялось, в details — адрес
+	EventSuppressed = "suppressed"
+	// События действий администратора, в details — кто их выполнил
+	EventCanceled    = "canceled"
+	EventRescheduled = "rescheduled"
+	EventResent      = "resent"
+	EventRequeued    = "requeued"
+)
+
+type NotifyEvent struct {
+	NotifyID  string    `json:"notify_id"`
+	Action    string    `json:"action"`
+	Details   string    `json:"details,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// StuckNotify — уведомление, зависшее в статусе queued.
+type StuckNotify struct {
+	Notify
+	QueuedAt  time.Time
+	ReapCount int
+}
+
+// ReapPolicy задаёт, когда уведомление в статусе queued считается зависшим
+// и сколько раз его можно повторно поставить в очередь, прежде чем пометить failed.
+type ReapPolicy struct {
+	Threshold time.Duration
+	MaxReaps  int
+}
+
+type ReapReport struct {
+	Requeued int
+	Failed   int
+	HasMore  bool
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "regexp"
+
+// DefaultSender — отправитель из MAIL_FROM; используется, если в уведомлении sender не задан
+const DefaultSender = "default"
+
+// Identity — отправитель писем: адрес и имя в From, адреса для ответа по умолчанию.
+// Клиенты выбирают его по Name в поле sender уведомления.
+type Identity struct {
+	Name     string   `json:"name" yaml:"name"`
+	FromName string   `json:"from_name,omitempty" yaml:"from_name"`
+	Address  string   `json:"address" yaml:"address"`
+	ReplyTo  []string `json:"reply_to,omitempty" yaml:"reply_to"`
+}
+
+var identityNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// ValidIdentityName проверяет имя отправителя: строчные латинские буквы, цифры, точка, дефис и подчёркивание.
+func ValidIdentityName(name string) bool {
+	return identityNameRegex.MatchString(name)
+}
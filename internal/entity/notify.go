@@ -3,6 +3,9 @@ package entity
 import (
 	"errors"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -11,34 +14,200 @@ var ErrNotifyNotFound = errors.New("notify not found")
 const (
 	StatusScheduled = "scheduled"
 	StatusQueued    = "queued"
-	StatusSent      = "sent"
-	StatusFailed    = "failed"
+	// StatusSending — консьюмер захватил уведомление и отправляет письма
+	StatusSending = "sending"
+	StatusSent    = "sent"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+	// StatusCanceled — отменено администратором до отправки
+	StatusCanceled = "canceled"
 )
 
 type Notify struct {
-	ID      string    `json:"id"`
-	SendAt  time.Time `json:"send_at"`
-	Message string    `json:"message"`
-	Status  string    `json:"status,omitempty"`
-	Email   string    `json:"email"`
+	ID     string    `json:"id"`
+	SendAt time.Time `json:"send_at"`
+	// Message — текстовая часть письма; HTML-часть без HTML строится из него с экранированием
+	Message string `json:"message"`
+	Status  string `json:"status,omitempty"`
+	// Email — первый адрес из To; единственный получатель в запросах старого формата
+	Email   string `json:"email,omitempty"`
+	Version int64  `json:"version,omitempty"`
+	// RequestID — X-Request-ID запроса, создавшего уведомление; сквозной идентификатор для логов
+	RequestID string   `json:"request_id,omitempty"`
+	To        []string `json:"to,omitempty"`
+	Cc        []string `json:"cc,omitempty"`
+	Bcc       []string `json:"bcc,omitempty"`
+	// RecipientLists — ID сохранённых списков получателей; раскрываются в момент отправки
+	RecipientLists []string `json:"recipient_lists,omitempty"`
+	// Subject — тема письма; пустая заменяется темой по умолчанию
+	Subject string `json:"subject,omitempty"`
+	// HTML — готовая HTML-часть письма от клиента, отправляется как есть
+	HTML    string   `json:"html,omitempty"`
+	ReplyTo []string `json:"reply_to,omitempty"`
+	// Headers — дополнительные заголовки письма, кроме служебных (см. ValidHeaderName)
+	Headers map[string]string `json:"headers,omitempty"`
+	// Attachments — описания вложений; содержимое передаётся только при создании
+	Attachments []Attachment `json:"attachments,omitempty"`
+	// Sender — имя отправителя (Identity); пустое — отправитель по умолчанию
+	Sender string `json:"sender,omitempty"`
 }
 
+// Normalize приводит старый формат с одним email к списку To и обратно,
+// чтобы Email всегда совпадал с первым получателем из To.
+func (n *Notify) Normalize() {
+	if len(n.To) == 0 && n.Email != "" {
+		n.To = []string{n.Email}
+	}
+	if len(n.To) > 0 {
+		n.Email = n.To[0]
+	}
+}
+
+// FieldError — нарушение правила валидации в одном поле.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError перечисляет все нарушения, а не только первое.
+type ValidationError []FieldError
+
+func (e ValidationError) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, f := range e {
+		messages = append(messages, f.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validate проверяет уведомление и возвращает ValidationError со всеми нарушениями.
 func (n *Notify) Validate() error {
+	var errs ValidationError
 	if n.Message == "" {
-		return errors.New("message is required")
+		errs = append(errs, FieldError{Field: "message", Message: "message is required"})
 	}
-	if n.SendAt.IsZero() {
-		return errors.New("send_at is required")
+	switch {
+	case n.SendAt.IsZero():
+		errs = append(errs, FieldError{Field: "send_at", Message: "send_at is required"})
+	case n.SendAt.Before(time.Now()):
+		errs = append(errs, FieldError{Field: "send_at", Message: "send_at must be in the future"})
 	}
-	if n.SendAt.Before(time.Now()) {
-		return errors.New("send_at must be in the future")
+	// Email проверяется, только если станет получателем To при нормализации
+	switch {
+	case len(n.To) == 0 && n.Email != "":
+		if !ValidEmail(n.Email) {
+			errs = append(errs, FieldError{Field: "email", Message: "invalid email format"})
+		}
+	case len(n.To)+len(n.Cc)+len(n.Bcc)+len(n.RecipientLists) == 0:
+		errs = append(errs, FieldError{Field: "to", Message: "at least one recipient is required"})
 	}
-	if n.Email == "" {
-		return errors.New("email is required")
+	errs = append(errs, validateEmails("to", n.To)...)
+	errs = append(errs, validateEmails("cc", n.Cc)...)
+	errs = append(errs, validateEmails("bcc", n.Bcc)...)
+	for i, id := range n.RecipientLists {
+		if id == "" {
+			errs = append(errs, FieldError{Field: indexed("recipient_lists", i), Message: "recipient list id is required"})
+		}
 	}
-	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-	if !emailRegex.MatchString(n.Email) {
-		return errors.New("invalid email format")
+	switch {
+	case !validHeaderValue(n.Subject):
+		errs = append(errs, FieldError{Field: "subject", Message: "subject must not contain line breaks or control characters"})
+	case len(n.Subject) > MaxSubjectLength:
+		errs = append(errs, FieldError{Field: "subject", Message: "subject is too long"})
+	}
+	if n.Sender != "" && !ValidIdentityName(n.Sender) {
+		errs = append(errs, FieldError{Field: "sender", Message: "invalid sender name"})
+	}
+	errs = append(errs, validateEmails("reply_to", n.ReplyTo)...)
+	errs = append(errs, validateHeaders(n.Headers)...)
+	for i := range n.Attachments {
+		errs = append(errs, n.Attachments[i].validate(i)...)
+	}
+	if len(errs) > 0 {
+		return errs
 	}
 	return nil
 }
+
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+// ValidEmail проверяет формат адреса получателя.
+func ValidEmail(email string) bool {
+	return emailRegex.MatchString(email)
+}
+
+func validateEmails(field string, emails []string) ValidationError {
+	var errs ValidationError
+	for i, email := range emails {
+		if !ValidEmail(email) {
+			errs = append(errs, FieldError{Field: indexed(field, i), Message: "invalid email format"})
+		}
+	}
+	return errs
+}
+
+// MaxSubjectLength — предел длины темы в байтах; длинные темы обрезают почтовые клиенты
+const MaxSubjectLength = 255
+
+// reservedHeaders заполняет сам отправитель; клиент не может их переопределить.
+var reservedHeaders = map[string]bool{
+	"from": true, "sender": true, "to": true, "cc": true, "bcc": true, "reply-to": true,
+	"subject": true, "date": true, "message-id": true, "return-path": true,
+	"mime-version": true, "content-type": true, "content-transfer-encoding": true,
+	"dkim-signature": true,
+}
+
+var headerNameRegex = regexp.MustCompile(`^[!-9;-~]+$`)
+
+// ValidHeaderName проверяет, что имя заголовка допустимо по RFC 5322 и не служебное.
+func ValidHeaderName(name string) bool {
+	return headerNameRegex.MatchString(name) && !reservedHeaders[strings.ToLower(name)]
+}
+
+// validHeaderValue не пропускает переводы строк и управляющие символы, через которые
+// можно дописать в письмо свои заголовки.
+func validHeaderValue(value string) bool {
+	for _, r := range value {
+		if r == '\t' {
+			continue
+		}
+		if r < 0x20 || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+func validateHeaders(headers map[string]string) ValidationError {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	// Порядок ошибок не должен зависеть от обхода map
+	slices.Sort(names)
+
+	var errs ValidationError
+	for _, name := range names {
+		field := "headers." + name
+		switch {
+		case !ValidHeaderName(name):
+			errs = append(errs, FieldError{Field: field, Message: "header name is invalid or reserved"})
+		case !validHeaderValue(headers[name]):
+			errs = append(errs, FieldError{Field: field, Message: "header value must not contain line breaks or control characters"})
+		}
+	}
+	return errs
+}
+
+// indexed возвращает путь к элементу массива через точку (to.1), как в ошибках
+// проверки по OpenAPI-спецификации
+func indexed(field string, i int) string {
+	return field + "." + strconv.Itoa(i)
+}
+
+// ScheduleEvent приходит из PostgreSQL (LISTEN notify_scheduled), когда уведомление
+// запланировано или перенесено.
+type ScheduleEvent struct {
+	ID     string    `json:"id"`
+	SendAt time.Time `json:"send_at"`
+}
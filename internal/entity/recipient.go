@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrRecipientListNotFound = errors.New("recipient list not found")
+
+// Роль получателя в письме
+const (
+	RecipientTo  = "to"
+	RecipientCc  = "cc"
+	RecipientBcc = "bcc"
+)
+
+// DeliveryPending — письмо получателю ещё не отправлялось или ждёт повторной попытки.
+// Отправленные и упавшие доставки получают StatusSent и StatusFailed.
+const DeliveryPending = "pending"
+
+// Доставки, которые не повторяются
+const (
+	// DeliveryBounced — сервер получателя окончательно отклонил письмо
+	DeliveryBounced = "bounced"
+	// DeliverySuppressed — адрес в списке подавления, письмо не отправлялось
+	DeliverySuppressed = "suppressed"
+)
+
+// RecipientList — сохранённый список адресов, на который можно сослаться из уведомления.
+type RecipientList struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Members   []string  `json:"members"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate проверяет список и возвращает ValidationError со всеми нарушениями.
+func (l *RecipientList) Validate() error {
+	var errs ValidationError
+	if strings.TrimSpace(l.Name) == "" {
+		errs = append(errs, FieldError{Field: "name", Message: "name is required"})
+	}
+	if len(l.Members) == 0 {
+		errs = append(errs, FieldError{Field: "members", Message: "at least one member is required"})
+	}
+	errs = append(errs, validateEmails("members", l.Members)...)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Delivery — отправка уведомления одному получателю. Доставки создаются при первой
+// попытке отправки, дальше у каждого получателя свои статус, попытки и ошибка.
+type Delivery struct {
+	NotifyID string `json:"notify_id"`
+	Email    string `json:"email"`
+	Kind     string `json:"kind"`
+	// ListID — список, из которого пришёл адрес; пусто для адресов из самого уведомления
+	ListID    string    `json:"list_id,omitempty"`
+	Status    string    `json:"status"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Deliveries раскрывает получателей уведомления и участников списков lists в доставки
+// без повторов. Адрес, указанный несколько раз, получает письмо один раз в первой роли
+// по порядку to, cc, bcc; участники списков получают письмо как скрытые копии.
+func (n *Notify) Deliveries(lists []RecipientList) []Delivery {
+	var deliveries []Delivery
+	seen := make(map[string]struct{})
+	add := func(kind, listID string, emails []string) {
+		for _, email := range emails {
+			key := strings.ToLower(email)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			deliveries = append(deliveries, Delivery{
+				NotifyID: n.ID,
+				Email:    email,
+				Kind:     kind,
+				ListID:   listID,
+				Status:   DeliveryPending,
+			})
+		}
+	}
+	add(RecipientTo, "", n.To)
+	add(RecipientCc, "", n.Cc)
+	add(RecipientBcc, "", n.Bcc)
+	for _, list := range lists {
+		add(RecipientBcc, list.ID, list.Members)
+	}
+	return deliveries
+}
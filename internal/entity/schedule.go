@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+// Режимы догоняющей отправки после простоя планировщика
+const (
+	CatchUpAll         = "all"
+	CatchUpMaxLateness = "max_lateness"
+	CatchUpCollapse    = "collapse"
+	CatchUpSpread      = "spread"
+)
+
+const (
+	SkipReasonTooLate   = "too_late"
+	SkipReasonDuplicate = "duplicate"
+)
+
+// CatchUpPolicy определяет, что делать с опоздавшими уведомлениями — теми, чьё
+// send_at прошло больше LateAfter назад. Уведомления, пришедшие вовремя, всегда отправляются.
+type CatchUpPolicy struct {
+	Mode string
+	// LateAfter — опоздание, после которого к уведомлению применяется политика
+	LateAfter time.Duration
+	// MaxLateness — для max_lateness: более старые уведомления пропускаются
+	MaxLateness time.Duration
+	// SpreadWindow — для spread: окно, на которое равномерно переносится накопившийся хвост
+	SpreadWindow time.Duration
+}
+
+type SkippedNotify struct {
+	ID       string
+	Reason   string
+	Lateness time.Duration
+}
+
+// ScheduleReport — итог одного прогона планировщика.
+type ScheduleReport struct {
+	Enqueued    int
+	Rescheduled int
+	Skipped     []SkippedNotify
+	// HasMore — пачка заполнена целиком, планировщику стоит сразу запустить следующий прогон
+	HasMore bool
+}
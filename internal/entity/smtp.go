@@ -0,0 +1,42 @@
+package entity
+
+import "errors"
+
+// SMTPPoolStats — статистика пула соединений с SMTP-сервером.
+type SMTPPoolStats struct {
+	// Open — открытые соединения, Idle — из них простаивающие в пуле
+	Open int64
+	Idle int
+	// Dials — подключения (с TLS и AUTH), DialErrors — из них неудачные
+	Dials      int64
+	DialErrors int64
+	// Reuses — письма, отправленные через уже открытое соединение
+	Reuses int64
+	// Broken — соединения, закрытые из-за сетевой ошибки или отмены контекста
+	Broken int64
+	// Waits — отправки, ждавшие свободного соединения из-за предела пула
+	Waits int64
+}
+
+// ErrPermanentFailure — уведомление не доставлено части получателей, и повторные
+// попытки этого не изменят; консьюмеры не отправляют такие уведомления на ретраи.
+var ErrPermanentFailure = errors.New("permanent delivery failure")
+
+// SendError — отказ SMTP-сервера принять письмо. Permanent — ответ 5xx, повтор не
+// поможет; Bounce — постоянный отказ из-за адреса получателя (нет ящика или домена).
+type SendError struct {
+	Code int
+	// EnhancedCode — расширенный код ответа (RFC 3463), например 5.1.1
+	EnhancedCode string
+	Permanent    bool
+	Bounce       bool
+	Err          error
+}
+
+func (e *SendError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *SendError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrSuppressionNotFound = errors.New("suppression not found")
+
+// SuppressionHardBounce — сервер получателя окончательно отклонил адрес
+const SuppressionHardBounce = "hard_bounce"
+
+// Suppression — адрес из списка подавления: письма на него не отправляются, а
+// уведомления с ним в to, cc или bcc отклоняются. Адреса сравниваются без учёта регистра.
+type Suppression struct {
+	Email  string `json:"email"`
+	Reason string `json:"reason"`
+	// Code и Details — ответ SMTP-сервера, из-за которого адрес попал в список
+	Code    int    `json:"code,omitempty"`
+	Details string `json:"details,omitempty"`
+	// NotifyID — уведомление, при отправке которого пришёл отказ
+	NotifyID  string    `json:"notify_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SuppressionFilter отбирает записи списка подавления. Пустые поля не фильтруют.
+type SuppressionFilter struct {
+	// Email — подстрока адреса
+	Email string `json:"email,omitempty"`
+	Limit int    `json:"limit,omitempty"`
+}
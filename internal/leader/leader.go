@@ -0,0 +1,130 @@
+package leader
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+type Lock interface {
+	TryAcquire(ctx context.Context) (bool, error)
+	Check(ctx context.Context) error
+	Release(ctx context.Context) error
+}
+
+type Config struct {
+	// RetryInterval — как часто ведомый пытается захватить блокировку
+	RetryInterval time.Duration
+	// CheckInterval — как часто лидер проверяет, что блокировка всё ещё у него
+	CheckInterval time.Duration
+}
+
+// Standalone — статус единственного экземпляра, запущенного без выборов лидера.
+type Standalone struct{}
+
+func (Standalone) IsLeader() bool {
+	return true
+}
+
+// Elector выбирает единственный экземпляр, на котором выполняется работа лидера
+// (планировщик, колесо таймеров). Остальные экземпляры ждут и подхватывают
+// лидерство, как только блокировка освобождается.
+type Elector struct {
+	lock        Lock
+	cfg         Config
+	leader      atomic.Bool
+	transitions atomic.Int64
+	logger      *slog.Logger
+}
+
+func NewElector(lock Lock, cfg Config, logger *slog.Logger) *Elector {
+	return &Elector{
+		lock:   lock,
+		cfg:    cfg,
+		logger: logger.With(slog.String("component", "leader election")),
+	}
+}
+
+func (e *Elector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Transitions возвращает число смен лидерства этого экземпляра (захватов и потерь).
+func (e *Elector) Transitions() int64 {
+	return e.transitions.Load()
+}
+
+// Run пытается стать лидером и, пока лидерство удерживается, выполняет fn.
+// Контекст fn отменяется при потере лидерства; после завершения fn экземпляр
+// снова встаёт в очередь. Run возвращается после отмены ctx.
+func (e *Elector) Run(ctx context.Context, fn func(ctx context.Context)) {
+	for {
+		acquired, err := e.lock.TryAcquire(ctx)
+		if err != nil && ctx.Err() == nil {
+			e.logger.Error("failed to acquire leadership", slog.Any("error", err))
+		}
+		if acquired {
+			e.lead(ctx, fn)
+		}
+
+		timer := time.NewTimer(e.cfg.RetryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (e *Elector) lead(ctx context.Context, fn func(ctx context.Context)) {
+	e.setLeader(true)
+	e.logger.Info("leadership acquired")
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(leaderCtx)
+	}()
+
+	ticker := time.NewTicker(e.cfg.CheckInterval)
+	defer ticker.Stop()
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-done:
+			break loop
+		case <-ticker.C:
+			checkCtx, checkCancel := context.WithTimeout(ctx, e.cfg.CheckInterval)
+			err := e.lock.Check(checkCtx)
+			checkCancel()
+			if err != nil {
+				e.logger.Error("leadership lost", slog.Any("error", err))
+				break loop
+			}
+		}
+	}
+
+	// Сначала останавливаем работу лидера, затем отдаём блокировку
+	cancel()
+	<-done
+	e.setLeader(false)
+
+	releaseCtx, releaseCancel := context.WithTimeout(context.Background(), e.cfg.CheckInterval)
+	defer releaseCancel()
+	if err := e.lock.Release(releaseCtx); err != nil {
+		e.logger.Warn("failed to release leadership", slog.Any("error", err))
+	}
+	e.logger.Info("leadership released")
+}
+
+func (e *Elector) setLeader(leader bool) {
+	if e.leader.Swap(leader) != leader {
+		e.transitions.Add(1)
+	}
+}
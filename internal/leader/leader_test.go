@@ -0,0 +1,96 @@
+package leader
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	mock_lock "delayed-notifier/internal/repository/postgres/mocks"
+)
+
+func setupElector(t *testing.T) (*mock_lock.Lock, *Elector) {
+	t.Helper()
+
+	lock := new(mock_lock.Lock)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := Config{RetryInterval: 10 * time.Millisecond, CheckInterval: 10 * time.Millisecond}
+
+	return lock, NewElector(lock, cfg, logger)
+}
+
+func TestElector(t *testing.T) {
+	t.Run("runs fn while leader", func(t *testing.T) {
+		lock, e := setupElector(t)
+		lock.On("TryAcquire", mock.Anything).Return(true, nil)
+		lock.On("Check", mock.Anything).Return(nil)
+		lock.On("Release", mock.Anything).Return(nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		started := make(chan struct{})
+		stopped := make(chan struct{})
+		go e.Run(ctx, func(ctx context.Context) {
+			close(started)
+			<-ctx.Done()
+			close(stopped)
+		})
+
+		<-started
+		assert.True(t, e.IsLeader())
+
+		cancel()
+		<-stopped
+		assert.Eventually(t, func() bool { return !e.IsLeader() }, time.Second, 5*time.Millisecond)
+		assert.Eventually(t, func() bool { return e.Transitions() == 2 }, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("follower waits for lock", func(t *testing.T) {
+		lock, e := setupElector(t)
+		lock.On("TryAcquire", mock.Anything).Return(false, nil).Times(3)
+		lock.On("TryAcquire", mock.Anything).Return(true, nil)
+		lock.On("Check", mock.Anything).Return(nil)
+		lock.On("Release", mock.Anything).Return(nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		started := make(chan struct{})
+		go e.Run(ctx, func(ctx context.Context) {
+			close(started)
+			<-ctx.Done()
+		})
+
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			t.Fatal("leadership not acquired")
+		}
+		lock.AssertNumberOfCalls(t, "TryAcquire", 4)
+	})
+
+	t.Run("steps down when lock lost", func(t *testing.T) {
+		lock, e := setupElector(t)
+		lock.On("TryAcquire", mock.Anything).Return(true, nil).Once()
+		lock.On("TryAcquire", mock.Anything).Return(false, nil)
+		lock.On("Check", mock.Anything).Return(assert.AnError).Once()
+		lock.On("Release", mock.Anything).Return(nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stopped := make(chan struct{})
+		go e.Run(ctx, func(ctx context.Context) {
+			<-ctx.Done()
+			close(stopped)
+		})
+
+		select {
+		case <-stopped:
+		case <-time.After(time.Second):
+			t.Fatal("leader work not stopped")
+		}
+		assert.Eventually(t, func() bool { return !e.IsLeader() }, time.Second, 5*time.Millisecond)
+	})
+}
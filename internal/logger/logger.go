@@ -1,12 +1,29 @@
 package logger
 
 import (
+	"context"
+	"io"
 	"log/slog"
 	"os"
 	"time"
+
+	"delayed-notifier/internal/redact"
+	"delayed-notifier/internal/requestid"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
 )
 
-func New(logLevel string) *slog.Logger {
+func New(logLevel, format string, redaction redact.Policy) *slog.Logger {
+	return NewWithWriter(os.Stdout, logLevel, format, redaction)
+}
+
+// NewWithWriter создаёт логгер в формате text или json. В обоих форматах к записи
+// добавляется request_id из контекста, если логировать через *Context-методы,
+// а персональные данные маскируются по redaction.
+func NewWithWriter(w io.Writer, logLevel, format string, redaction redact.Policy) *slog.Logger {
 	var slogLevel slog.Level
 	switch logLevel {
 	case "debug":
@@ -21,18 +38,44 @@ func New(logLevel string) *slog.Logger {
 		slogLevel = slog.LevelInfo
 	}
 
-	handler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slogLevel,
-		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
-			switch a.Key {
-			case slog.TimeKey:
-				a.Value = slog.StringValue(time.Now().Format("2006-01-02 15:04:05"))
-			case slog.LevelKey:
-				a.Value = slog.StringValue(a.Value.String())
-			}
-			return a
-		},
-	})
-
-	return slog.New(handler)
+	var handler slog.Handler
+	if format == FormatJSON {
+		// В JSON время остаётся в RFC 3339 с миллисекундами, как ждут сборщики логов
+		handler = slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slogLevel, ReplaceAttr: redaction.ReplaceAttr})
+	} else {
+		handler = slog.NewTextHandler(w, &slog.HandlerOptions{
+			Level: slogLevel,
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				switch a.Key {
+				case slog.TimeKey:
+					a.Value = slog.StringValue(a.Value.Time().Format(time.DateTime))
+				case slog.LevelKey:
+					a.Value = slog.StringValue(a.Value.String())
+				}
+				return redaction.ReplaceAttr(groups, a)
+			},
+		})
+	}
+
+	return slog.New(contextHandler{handler})
+}
+
+// contextHandler дописывает request_id из контекста записи.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := requestid.FromContext(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
 }
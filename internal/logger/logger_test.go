@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/redact"
+	"delayed-notifier/internal/requestid"
+)
+
+func TestNewWithWriter(t *testing.T) {
+	t.Run("json with request id", func(t *testing.T) {
+		var buf bytes.Buffer
+		logg := NewWithWriter(&buf, "info", FormatJSON, redact.Policy{}).With("component", "test")
+
+		logg.InfoContext(requestid.NewContext(context.Background(), "req-1"), "hello")
+
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+		assert.Equal(t, "hello", line["msg"])
+		assert.Equal(t, "INFO", line["level"])
+		assert.Equal(t, "test", line["component"])
+		assert.Equal(t, "req-1", line["request_id"])
+	})
+
+	t.Run("text without request id", func(t *testing.T) {
+		var buf bytes.Buffer
+		logg := NewWithWriter(&buf, "warn", FormatText, redact.Policy{})
+
+		logg.Info("skipped")
+		logg.Warn("kept")
+
+		assert.NotContains(t, buf.String(), "skipped")
+		assert.Contains(t, buf.String(), "msg=kept")
+		assert.NotContains(t, buf.String(), "request_id")
+	})
+
+	t.Run("redacts notify", func(t *testing.T) {
+		var buf bytes.Buffer
+		logg := NewWithWriter(&buf, "debug", FormatText, redact.Policy{Email: true, Message: true})
+
+		logg.Debug("handling", slog.Any("message", entity.Notify{ID: "id1", Email: "john@example.com", Message: "secret"}),
+			slog.Any("error", errors.New("550 john@example.com: mailbox unavailable")))
+
+		assert.NotContains(t, buf.String(), "john@example.com")
+		assert.NotContains(t, buf.String(), "secret")
+		assert.Contains(t, buf.String(), "j***@example.com")
+		assert.Contains(t, buf.String(), "id1")
+	})
+
+	t.Run("redacts mail text", func(t *testing.T) {
+		var buf bytes.Buffer
+		logg := NewWithWriter(&buf, "info", FormatJSON, redact.Policy{Email: true, Message: true})
+
+		// Так пишет письмо LogMailer
+		logg.Info("mail delivered to log",
+			slog.String("email", "john@example.com"),
+			slog.String("subject", "Invoice"),
+			slog.String("message", "secret body"))
+
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+		assert.Equal(t, "mail delivered to log", line["msg"])
+		assert.Equal(t, "j***@example.com", line["email"])
+		assert.Equal(t, "[redacted 7 bytes]", line["subject"])
+		assert.Equal(t, "[redacted 11 bytes]", line["message"])
+	})
+}
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	kindGauge   = "gauge"
+	kindCounter = "counter"
+)
+
+type series struct {
+	labels string
+	value  func() float64
+}
+
+type family struct {
+	name   string
+	help   string
+	kind   string
+	series []series
+}
+
+// Registry отдаёт метрики в текстовом формате Prometheus. Значения не хранятся
+// в реестре, а читаются функциями в момент запроса.
+type Registry struct {
+	mu       sync.Mutex
+	families []*family
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Gauge регистрирует метрику; labels — пары имя/значение.
+func (r *Registry) Gauge(name, help string, value func() float64, labels ...string) {
+	r.register(name, help, kindGauge, value, labels)
+}
+
+func (r *Registry) Counter(name, help string, value func() float64, labels ...string) {
+	r.register(name, help, kindCounter, value, labels)
+}
+
+func (r *Registry) register(name, help, kind string, value func() float64, labels []string) {
+	if len(labels)%2 != 0 {
+		panic(fmt.Sprintf("metrics: odd number of labels for %s", name))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := series{labels: formatLabels(labels), value: value}
+	for _, f := range r.families {
+		if f.name == name {
+			f.series = append(f.series, s)
+			return
+		}
+	}
+	r.families = append(r.families, &family{name: name, help: help, kind: kind, series: []series{s}})
+}
+
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b strings.Builder
+	for _, f := range r.families {
+		fmt.Fprintf(&b, "# HELP %s %s\n", f.name, f.help)
+		fmt.Fprintf(&b, "# TYPE %s %s\n", f.name, f.kind)
+		for _, s := range f.series {
+			fmt.Fprintf(&b, "%s%s %s\n", f.name, s.labels, strconv.FormatFloat(s.value(), 'g', -1, 64))
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(b.String()))
+}
+
+func formatLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i < len(labels); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", labels[i], labels[i+1]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// Bool переводит флаг в значение метрики.
+func Bool(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
+}
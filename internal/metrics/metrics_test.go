@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	r.Gauge("notifier_leader", "Is leader", func() float64 { return 1 })
+	r.Counter("notifier_hits_total", "Hits", func() float64 { return 3 }, "tier", "redis")
+	r.Counter("notifier_hits_total", "Hits", func() float64 { return 5 }, "tier", "memory")
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, `# HELP notifier_leader Is leader
+# TYPE notifier_leader gauge
+notifier_leader 1
+# HELP notifier_hits_total Hits
+# TYPE notifier_hits_total counter
+notifier_hits_total{tier="redis"} 3
+notifier_hits_total{tier="memory"} 5
+`, rec.Body.String())
+}
@@ -0,0 +1,208 @@
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"delayed-notifier/internal/entity"
+)
+
+const (
+	FieldEmail   = "email"
+	FieldMessage = "message"
+
+	// attrSubject — атрибут лога с темой письма, маскируется вместе с текстом
+	attrSubject = "subject"
+)
+
+var emailPattern = regexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`)
+
+// Policy определяет, какие персональные данные маскировать.
+type Policy struct {
+	Email   bool
+	Message bool
+}
+
+// ParsePolicy разбирает список полей через запятую, например "email,message".
+// Пустая строка или "none" выключают маскирование.
+func ParsePolicy(s string) (Policy, error) {
+	var p Policy
+	for _, field := range strings.Split(s, ",") {
+		switch strings.TrimSpace(field) {
+		case "", "none":
+		case FieldEmail:
+			p.Email = true
+		case FieldMessage:
+			p.Message = true
+		default:
+			return Policy{}, fmt.Errorf("unknown redact field %q", field)
+		}
+	}
+	return p, nil
+}
+
+func (p *Policy) UnmarshalText(text []byte) error {
+	parsed, err := ParsePolicy(string(text))
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+func (p Policy) MarshalText() ([]byte, error) {
+	var fields []string
+	if p.Email {
+		fields = append(fields, FieldEmail)
+	}
+	if p.Message {
+		fields = append(fields, FieldMessage)
+	}
+	if len(fields) == 0 {
+		return []byte("none"), nil
+	}
+	return []byte(strings.Join(fields, ",")), nil
+}
+
+func (p Policy) Enabled() bool {
+	return p.Email || p.Message
+}
+
+// Email оставляет первый символ локальной части и домен: j***@example.com.
+func Email(email string) string {
+	at := strings.LastIndexByte(email, '@')
+	if at <= 0 {
+		return "***"
+	}
+	return email[:1] + "***" + email[at:]
+}
+
+// Message заменяет текст на его длину, чтобы по логам можно было отличить пустые сообщения.
+func Message(message string) string {
+	return fmt.Sprintf("[redacted %d bytes]", len(message))
+}
+
+// Notify возвращает копию уведомления с замаскированными полями.
+func (p Policy) Notify(notify entity.Notify) entity.Notify {
+	if p.Email {
+		notify.Email = Email(notify.Email)
+		notify.To = emails(notify.To)
+		notify.Cc = emails(notify.Cc)
+		notify.Bcc = emails(notify.Bcc)
+		notify.ReplyTo = emails(notify.ReplyTo)
+	}
+	if p.Message {
+		notify.Message = Message(notify.Message)
+		// Тема и HTML-часть несут тот же текст, что и сообщение
+		if notify.Subject != "" {
+			notify.Subject = Message(notify.Subject)
+		}
+		if notify.HTML != "" {
+			notify.HTML = Message(notify.HTML)
+		}
+		notify.Headers = headers(notify.Headers)
+		notify.Attachments = attachments(notify.Attachments)
+	}
+	return notify
+}
+
+// headers маскирует значения заголовков в копии map; имена остаются для отладки.
+func headers(h map[string]string) map[string]string {
+	if len(h) == 0 {
+		return h
+	}
+	masked := make(map[string]string, len(h))
+	for name, value := range h {
+		masked[name] = Message(value)
+	}
+	return masked
+}
+
+// attachments маскирует имена файлов и убирает содержимое в копии списка вложений.
+func attachments(list []entity.Attachment) []entity.Attachment {
+	if len(list) == 0 {
+		return list
+	}
+	masked := make([]entity.Attachment, len(list))
+	for i, a := range list {
+		a.Filename = Message(a.Filename)
+		a.Content = nil
+		masked[i] = a
+	}
+	return masked
+}
+
+// emails маскирует копию списка адресов, не трогая исходный срез.
+func emails(list []string) []string {
+	if len(list) == 0 {
+		return list
+	}
+	masked := make([]string, len(list))
+	for i, e := range list {
+		masked[i] = Email(e)
+	}
+	return masked
+}
+
+// Text маскирует адреса в произвольной строке, например в тексте ошибки SMTP.
+func (p Policy) Text(s string) string {
+	if !p.Email {
+		return s
+	}
+	return emailPattern.ReplaceAllStringFunc(s, Email)
+}
+
+// Payload маскирует JSON уведомления из сообщения Kafka. Если payload не разбирается,
+// при маскировании текста он заменяется целиком, иначе в нём маскируются адреса.
+func (p Policy) Payload(payload []byte) []byte {
+	if !p.Enabled() {
+		return payload
+	}
+
+	var notify entity.Notify
+	if err := json.Unmarshal(payload, &notify); err != nil {
+		if p.Message {
+			return []byte(Message(string(payload)))
+		}
+		return []byte(p.Text(string(payload)))
+	}
+
+	masked, err := json.Marshal(p.Notify(notify))
+	if err != nil {
+		return []byte(Message(string(payload)))
+	}
+	return masked
+}
+
+// ReplaceAttr для slog.HandlerOptions: маскирует уведомления, атрибуты email,
+// message и subject и адреса в ошибках.
+func (p Policy) ReplaceAttr(_ []string, a slog.Attr) slog.Attr {
+	if !p.Enabled() {
+		return a
+	}
+
+	switch v := a.Value.Any().(type) {
+	case entity.Notify:
+		a.Value = slog.AnyValue(p.Notify(v))
+	case *entity.Notify:
+		if v != nil {
+			masked := p.Notify(*v)
+			a.Value = slog.AnyValue(&masked)
+		}
+	case error:
+		if p.Email {
+			a.Value = slog.StringValue(p.Text(v.Error()))
+		}
+	case string:
+		switch {
+		case a.Key == FieldEmail && p.Email:
+			a.Value = slog.StringValue(Email(v))
+		case (a.Key == FieldMessage || a.Key == attrSubject) && p.Message:
+			a.Value = slog.StringValue(Message(v))
+		}
+	}
+	return a
+}
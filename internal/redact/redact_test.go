@@ -0,0 +1,120 @@
+package redact
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/entity"
+)
+
+func TestParsePolicy(t *testing.T) {
+	p, err := ParsePolicy("email, message")
+	require.NoError(t, err)
+	assert.Equal(t, Policy{Email: true, Message: true}, p)
+
+	p, err = ParsePolicy("none")
+	require.NoError(t, err)
+	assert.False(t, p.Enabled())
+
+	_, err = ParsePolicy("phone")
+	assert.Error(t, err)
+}
+
+func TestEmail(t *testing.T) {
+	assert.Equal(t, "j***@example.com", Email("john@example.com"))
+	assert.Equal(t, "***", Email("broken"))
+}
+
+func TestPayload(t *testing.T) {
+	notify := entity.Notify{
+		ID:          "id1",
+		Email:       "john@example.com",
+		To:          []string{"john@example.com"},
+		Bcc:         []string{"ann@example.com"},
+		ReplyTo:     []string{"support@example.com"},
+		Message:     "hello",
+		Subject:     "Invoice",
+		HTML:        "<p>hello</p>",
+		Headers:     map[string]string{"X-Customer": "John Smith"},
+		Attachments: []entity.Attachment{{ID: "a1", Filename: "john-smith.pdf", ContentType: "application/pdf", Size: 3, Content: []byte("pdf")}},
+	}
+	payload, err := json.Marshal(notify)
+	require.NoError(t, err)
+
+	t.Run("notify", func(t *testing.T) {
+		var masked entity.Notify
+		require.NoError(t, json.Unmarshal(Policy{Email: true, Message: true}.Payload(payload), &masked))
+
+		tests := []struct {
+			field string
+			got   any
+			want  any
+		}{
+			{"id", masked.ID, "id1"},
+			{"email", masked.Email, "j***@example.com"},
+			{"to", masked.To, []string{"j***@example.com"}},
+			{"bcc", masked.Bcc, []string{"a***@example.com"}},
+			{"reply_to", masked.ReplyTo, []string{"s***@example.com"}},
+			{"message", masked.Message, "[redacted 5 bytes]"},
+			{"subject", masked.Subject, "[redacted 7 bytes]"},
+			{"html", masked.HTML, "[redacted 12 bytes]"},
+			{"headers", masked.Headers, map[string]string{"X-Customer": "[redacted 10 bytes]"}},
+			{"attachments", masked.Attachments, []entity.Attachment{{ID: "a1", Filename: "[redacted 14 bytes]", ContentType: "application/pdf", Size: 3}}},
+		}
+		for _, tt := range tests {
+			assert.Equal(t, tt.want, tt.got, tt.field)
+		}
+	})
+
+	t.Run("source not modified", func(t *testing.T) {
+		Policy{Email: true, Message: true}.Notify(notify)
+		assert.Equal(t, "John Smith", notify.Headers["X-Customer"])
+		assert.Equal(t, "john-smith.pdf", notify.Attachments[0].Filename)
+		assert.Equal(t, []string{"support@example.com"}, notify.ReplyTo)
+	})
+
+	t.Run("email only", func(t *testing.T) {
+		var masked entity.Notify
+		require.NoError(t, json.Unmarshal(Policy{Email: true}.Payload(payload), &masked))
+		assert.Equal(t, []string{"s***@example.com"}, masked.ReplyTo)
+		assert.Equal(t, "Invoice", masked.Subject)
+		assert.Equal(t, "John Smith", masked.Headers["X-Customer"])
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Equal(t, payload, Policy{}.Payload(payload))
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		raw := []byte(`{"email":"john@example.com",`)
+		assert.Equal(t, `{"email":"j***@example.com",`, string(Policy{Email: true}.Payload(raw)))
+		assert.Equal(t, "[redacted 28 bytes]", string(Policy{Message: true}.Payload(raw)))
+	})
+}
+
+func TestReplaceAttr(t *testing.T) {
+	p := Policy{Email: true}
+
+	a := p.ReplaceAttr(nil, slog.String("email", "john@example.com"))
+	assert.Equal(t, "j***@example.com", a.Value.String())
+
+	a = p.ReplaceAttr(nil, slog.Any("error", errors.New("rejected john@example.com")))
+	assert.Equal(t, "rejected j***@example.com", a.Value.String())
+
+	a = p.ReplaceAttr(nil, slog.Any("message", entity.Notify{Email: "john@example.com", Message: "hi"}))
+	assert.Equal(t, entity.Notify{Email: "j***@example.com", Message: "hi"}, a.Value.Any())
+
+	a = p.ReplaceAttr(nil, slog.String("message", "hi"))
+	assert.Equal(t, "hi", a.Value.String())
+
+	p = Policy{Message: true}
+	a = p.ReplaceAttr(nil, slog.String("message", "hi"))
+	assert.Equal(t, "[redacted 2 bytes]", a.Value.String())
+	a = p.ReplaceAttr(nil, slog.String("subject", "Счёт"))
+	assert.Equal(t, "[redacted 8 bytes]", a.Value.String())
+}
@@ -0,0 +1,93 @@
+// Package blob — хранилище содержимого вложений в каталоге файловой системы.
+package blob
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"delayed-notifier/internal/entity"
+)
+
+var idRegex = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// FileStore хранит каждое вложение в отдельном файле dir/<id>. Каталог должен быть
+// общим для API и воркера (том Docker, NFS).
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore создаёт каталог dir, если его нет.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("NewFileStore: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// Put записывает содержимое во временный файл и переименовывает его, чтобы
+// читатели не увидели недописанный файл.
+func (s *FileStore) Put(_ context.Context, content []byte) (string, error) {
+	var raw [16]byte
+	if _, err := rand.Read(raw[:]); err != nil {
+		return "", fmt.Errorf("FileStore.Put: %w", err)
+	}
+	id := hex.EncodeToString(raw[:])
+
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("FileStore.Put: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return "", fmt.Errorf("FileStore.Put: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return "", fmt.Errorf("FileStore.Put: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("FileStore.Put: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(id)); err != nil {
+		return "", fmt.Errorf("FileStore.Put: %w", err)
+	}
+	return id, nil
+}
+
+func (s *FileStore) Get(_ context.Context, id string) ([]byte, error) {
+	// ID приходит в том числе из URL — в путь попадает только то, что выдал Put
+	if !idRegex.MatchString(id) {
+		return nil, fmt.Errorf("FileStore.Get: %w", entity.ErrAttachmentNotFound)
+	}
+	content, err := os.ReadFile(s.path(id))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("FileStore.Get: %w", entity.ErrAttachmentNotFound)
+		}
+		return nil, fmt.Errorf("FileStore.Get: %w", err)
+	}
+	return content, nil
+}
+
+func (s *FileStore) Delete(_ context.Context, id string) error {
+	if !idRegex.MatchString(id) {
+		return nil
+	}
+	if err := os.Remove(s.path(id)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("FileStore.Delete: %w", err)
+	}
+	return nil
+}
+
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.dir, id)
+}
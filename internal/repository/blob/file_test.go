@@ -0,0 +1,45 @@
+package blob
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/entity"
+)
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "attachments")
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	id, err := store.Put(ctx, []byte("report"))
+	require.NoError(t, err)
+
+	content, err := store.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("report"), content)
+
+	// Во временных файлах ничего не остаётся
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	t.Run("path traversal", func(t *testing.T) {
+		_, err := store.Get(ctx, "../"+filepath.Base(dir)+"/"+id)
+		assert.ErrorIs(t, err, entity.ErrAttachmentNotFound)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(ctx, id))
+		require.NoError(t, store.Delete(ctx, id))
+
+		_, err := store.Get(ctx, id)
+		assert.ErrorIs(t, err, entity.ErrAttachmentNotFound)
+	})
+}
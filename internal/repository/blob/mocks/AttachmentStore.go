@@ -0,0 +1,199 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AttachmentStore is an autogenerated mock type for the AttachmentStore type
+type AttachmentStore struct {
+	mock.Mock
+}
+
+type AttachmentStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AttachmentStore) EXPECT() *AttachmentStore_Expecter {
+	return &AttachmentStore_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *AttachmentStore) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AttachmentStore_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type AttachmentStore_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *AttachmentStore_Expecter) Delete(ctx interface{}, id interface{}) *AttachmentStore_Delete_Call {
+	return &AttachmentStore_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *AttachmentStore_Delete_Call) Run(run func(ctx context.Context, id string)) *AttachmentStore_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AttachmentStore_Delete_Call) Return(_a0 error) *AttachmentStore_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *AttachmentStore_Delete_Call) RunAndReturn(run func(context.Context, string) error) *AttachmentStore_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *AttachmentStore) Get(ctx context.Context, id string) ([]byte, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttachmentStore_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type AttachmentStore_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *AttachmentStore_Expecter) Get(ctx interface{}, id interface{}) *AttachmentStore_Get_Call {
+	return &AttachmentStore_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *AttachmentStore_Get_Call) Run(run func(ctx context.Context, id string)) *AttachmentStore_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AttachmentStore_Get_Call) Return(_a0 []byte, _a1 error) *AttachmentStore_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttachmentStore_Get_Call) RunAndReturn(run func(context.Context, string) ([]byte, error)) *AttachmentStore_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Put provides a mock function with given fields: ctx, content
+func (_m *AttachmentStore) Put(ctx context.Context, content []byte) (string, error) {
+	ret := _m.Called(ctx, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) (string, error)); ok {
+		return rf(ctx, content)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) string); ok {
+		r0 = rf(ctx, content)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttachmentStore_Put_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Put'
+type AttachmentStore_Put_Call struct {
+	*mock.Call
+}
+
+// Put is a helper method to define mock.On call
+//   - ctx context.Context
+//   - content []byte
+func (_e *AttachmentStore_Expecter) Put(ctx interface{}, content interface{}) *AttachmentStore_Put_Call {
+	return &AttachmentStore_Put_Call{Call: _e.mock.On("Put", ctx, content)}
+}
+
+func (_c *AttachmentStore_Put_Call) Run(run func(ctx context.Context, content []byte)) *AttachmentStore_Put_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]byte))
+	})
+	return _c
+}
+
+func (_c *AttachmentStore_Put_Call) Return(_a0 string, _a1 error) *AttachmentStore_Put_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AttachmentStore_Put_Call) RunAndReturn(run func(context.Context, []byte) (string, error)) *AttachmentStore_Put_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAttachmentStore creates a new instance of AttachmentStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttachmentStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttachmentStore {
+	mock := &AttachmentStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
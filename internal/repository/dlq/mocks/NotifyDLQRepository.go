@@ -0,0 +1,96 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "delayed-notifier/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NotifyDLQRepository is an autogenerated mock type for the NotifyDLQRepository type
+type NotifyDLQRepository struct {
+	mock.Mock
+}
+
+type NotifyDLQRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *NotifyDLQRepository) EXPECT() *NotifyDLQRepository_Expecter {
+	return &NotifyDLQRepository_Expecter{mock: &_m.Mock}
+}
+
+// ListEntries provides a mock function with given fields: ctx, filter
+func (_m *NotifyDLQRepository) ListEntries(ctx context.Context, filter entity.DLQFilter) ([]entity.DLQEntry, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEntries")
+	}
+
+	var r0 []entity.DLQEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DLQFilter) ([]entity.DLQEntry, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DLQFilter) []entity.DLQEntry); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.DLQEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.DLQFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyDLQRepository_ListEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEntries'
+type NotifyDLQRepository_ListEntries_Call struct {
+	*mock.Call
+}
+
+// ListEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter entity.DLQFilter
+func (_e *NotifyDLQRepository_Expecter) ListEntries(ctx interface{}, filter interface{}) *NotifyDLQRepository_ListEntries_Call {
+	return &NotifyDLQRepository_ListEntries_Call{Call: _e.mock.On("ListEntries", ctx, filter)}
+}
+
+func (_c *NotifyDLQRepository_ListEntries_Call) Run(run func(ctx context.Context, filter entity.DLQFilter)) *NotifyDLQRepository_ListEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.DLQFilter))
+	})
+	return _c
+}
+
+func (_c *NotifyDLQRepository_ListEntries_Call) Return(_a0 []entity.DLQEntry, _a1 error) *NotifyDLQRepository_ListEntries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyDLQRepository_ListEntries_Call) RunAndReturn(run func(context.Context, entity.DLQFilter) ([]entity.DLQEntry, error)) *NotifyDLQRepository_ListEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewNotifyDLQRepository creates a new instance of NotifyDLQRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotifyDLQRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotifyDLQRepository {
+	mock := &NotifyDLQRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,147 @@
+package dlq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"delayed-notifier/internal/entity"
+)
+
+type dlqMessage struct {
+	OriginalMessage kafka.Message `json:"original_message"`
+	Reason          string        `json:"reason"`
+	ErrorMessage    string        `json:"error_message"`
+	Timestamp       time.Time     `json:"timestamp"`
+}
+
+type NotifyDLQRepository struct {
+	broker string
+	topic  string
+	logger *slog.Logger
+}
+
+func NewNotifyDLQRepository(brokerURL, topic string, logger *slog.Logger) *NotifyDLQRepository {
+	return &NotifyDLQRepository{
+		broker: brokerURL,
+		topic:  topic,
+		logger: logger,
+	}
+}
+
+func (r *NotifyDLQRepository) ListEntries(ctx context.Context, filter entity.DLQFilter) ([]entity.DLQEntry, error) {
+	conn, err := kafka.DialContext(ctx, "tcp", r.broker)
+	if err != nil {
+		return nil, fmt.Errorf("ListEntries: dial: %w", err)
+	}
+	partitions, err := conn.ReadPartitions(r.topic)
+	_ = conn.Close()
+	if err != nil {
+		return nil, fmt.Errorf("ListEntries: read partitions: %w", err)
+	}
+
+	var entries []entity.DLQEntry
+	for _, p := range partitions {
+		limit := 0
+		if filter.Limit > 0 {
+			limit = filter.Limit - len(entries)
+			if limit <= 0 {
+				break
+			}
+		}
+
+		partEntries, err := r.readPartition(ctx, p.ID, filter.Reason, limit)
+		if err != nil {
+			return nil, fmt.Errorf("ListEntries: partition %d: %w", p.ID, err)
+		}
+		entries = append(entries, partEntries...)
+	}
+
+	return entries, nil
+}
+
+func (r *NotifyDLQRepository) readPartition(ctx context.Context, partition int, reason string, limit int) ([]entity.DLQEntry, error) {
+	conn, err := kafka.DialLeader(ctx, "tcp", r.broker, r.topic, partition)
+	if err != nil {
+		return nil, fmt.Errorf("dial leader: %w", err)
+	}
+	first, last, err := conn.ReadOffsets()
+	_ = conn.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read offsets: %w", err)
+	}
+	if first >= last {
+		return nil, nil
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   []string{r.broker},
+		Topic:     r.topic,
+		Partition: partition,
+		MinBytes:  1,
+		MaxBytes:  10e6,
+	})
+	defer func() {
+		if err := reader.Close(); err != nil {
+			r.logger.Error("failed to close dlq reader", slog.Any("error", err))
+		}
+	}()
+
+	if err := reader.SetOffset(first); err != nil {
+		return nil, fmt.Errorf("set offset: %w", err)
+	}
+
+	var entries []entity.DLQEntry
+	for {
+		m, err := reader.ReadMessage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("read message: %w", err)
+		}
+
+		entry := decodeEntry(m)
+		if reason == "" || entry.Reason == reason {
+			entries = append(entries, entry)
+		}
+
+		if m.Offset+1 >= last || (limit > 0 && len(entries) >= limit) {
+			return entries, nil
+		}
+	}
+}
+
+func decodeEntry(m kafka.Message) entity.DLQEntry {
+	entry := entity.DLQEntry{
+		Partition: m.Partition,
+		Offset:    m.Offset,
+		NotifyID:  string(m.Key),
+		Timestamp: m.Time,
+	}
+
+	var msg dlqMessage
+	if err := json.Unmarshal(m.Value, &msg); err != nil {
+		entry.Reason = "unknown"
+		entry.ErrorMessage = fmt.Sprintf("undecodable dlq message: %v", err)
+		entry.Payload = string(m.Value)
+		return entry
+	}
+
+	entry.Reason = msg.Reason
+	entry.ErrorMessage = msg.ErrorMessage
+	entry.Payload = string(msg.OriginalMessage.Value)
+	if !msg.Timestamp.IsZero() {
+		entry.Timestamp = msg.Timestamp
+	}
+
+	if entry.NotifyID == "" {
+		var notify entity.Notify
+		if err := json.Unmarshal(msg.OriginalMessage.Value, &notify); err == nil {
+			entry.NotifyID = notify.ID
+		}
+	}
+
+	return entry
+}
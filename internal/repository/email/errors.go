@@ -0,0 +1,42 @@
+package email
+
+import (
+	"errors"
+	"net/textproto"
+	"regexp"
+	"slices"
+
+	"delayed-notifier/internal/entity"
+)
+
+// enhancedCodeRe — расширенный код в начале текста ответа: класс.тема.деталь
+var enhancedCodeRe = regexp.MustCompile(`^([245])\.(\d{1,3})\.(\d{1,3})\b`)
+
+// bounceCodes — расширенные коды отказов из-за адреса получателя: нет ящика, нет
+// домена, неверный синтаксис, адрес переехал, у домена null MX, ящик отключён.
+// 5.1.7 и 5.1.8 относятся к адресу отправителя и сюда не входят.
+var bounceCodes = []string{"1.1", "1.2", "1.3", "1.6", "1.10", "2.1"}
+
+// classify оборачивает ответ SMTP-сервера на команду command в entity.SendError.
+// Ответы 5xx постоянные. Постоянный отказ считается bounce по расширенному коду, а
+// без него — если сервер ответил 550, 551 или 553 на RCPT. Сетевые ошибки и
+// таймауты возвращаются как есть и считаются временными.
+func classify(command string, err error) error {
+	var reply *textproto.Error
+	if !errors.As(err, &reply) {
+		return err
+	}
+
+	sendErr := &entity.SendError{Code: reply.Code, Permanent: reply.Code >= 500, Err: err}
+	if m := enhancedCodeRe.FindStringSubmatch(reply.Msg); m != nil {
+		sendErr.EnhancedCode = m[0]
+		// Отказ на MAIL FROM относится к отправителю, а не к получателю
+		sendErr.Bounce = sendErr.Permanent && m[1] == "5" && command != "MAIL" && slices.Contains(bounceCodes, m[2]+"."+m[3])
+		return sendErr
+	}
+	switch reply.Code {
+	case 550, 551, 553:
+		sendErr.Bounce = command == "RCPT"
+	}
+	return sendErr
+}
@@ -0,0 +1,58 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+	"net/textproto"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/entity"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name      string
+		command   string
+		code      int
+		msg       string
+		enhanced  string
+		permanent bool
+		bounce    bool
+	}{
+		{"unknown mailbox", "RCPT", 550, "5.1.1 <a@example.com>: Recipient address rejected", "5.1.1", true, true},
+		{"unknown domain", "RCPT", 550, "5.1.2 Host unknown", "5.1.2", true, true},
+		{"mailbox disabled after data", "DATA", 550, "5.2.1 Mailbox disabled", "5.2.1", true, true},
+		{"mailbox full", "RCPT", 552, "5.2.2 Mailbox full", "5.2.2", true, false},
+		{"spam policy", "DATA", 554, "5.7.1 Message rejected as spam", "5.7.1", true, false},
+		{"bad sender", "MAIL", 553, "5.1.8 Sender domain does not exist", "5.1.8", true, false},
+		{"sender mailbox on MAIL", "MAIL", 550, "5.1.1 Sender unknown", "5.1.1", true, false},
+		{"plain 550 on RCPT", "RCPT", 550, "No such user here", "", true, true},
+		{"plain 550 on DATA", "DATA", 550, "Rejected", "", true, false},
+		{"greylisting", "RCPT", 451, "4.7.1 Try again later", "4.7.1", false, false},
+		{"service closing", "MAIL", 421, "Service not available", "", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classify(tt.command, fmt.Errorf("wrapped: %w", &textproto.Error{Code: tt.code, Msg: tt.msg}))
+
+			var sendErr *entity.SendError
+			require.ErrorAs(t, err, &sendErr)
+			assert.Equal(t, tt.code, sendErr.Code)
+			assert.Equal(t, tt.enhanced, sendErr.EnhancedCode)
+			assert.Equal(t, tt.permanent, sendErr.Permanent)
+			assert.Equal(t, tt.bounce, sendErr.Bounce)
+		})
+	}
+
+	t.Run("network errors stay transient", func(t *testing.T) {
+		err := classify("RCPT", os.ErrDeadlineExceeded)
+		var sendErr *entity.SendError
+		assert.False(t, errors.As(err, &sendErr))
+		assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+		assert.NoError(t, classify("RCPT", nil))
+	})
+}
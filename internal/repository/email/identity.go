@@ -0,0 +1,116 @@
+package email
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/emersion/go-msgauth/dkim"
+
+	"delayed-notifier/internal/config"
+	"delayed-notifier/internal/entity"
+)
+
+// Identities — отправители, доступные мейлеру, и ключи DKIM по доменам.
+type Identities struct {
+	senders map[string]entity.Identity
+	dkim    map[string]*dkim.SignOptions
+}
+
+// NewIdentities читает ключи DKIM из файлов. Письма отправителей из доменов без
+// ключа уходят неподписанными.
+func NewIdentities(cfg config.Identities) (*Identities, error) {
+	ids := &Identities{
+		senders: make(map[string]entity.Identity, len(cfg.Senders)),
+		dkim:    make(map[string]*dkim.SignOptions, len(cfg.DKIM)),
+	}
+	for _, id := range cfg.Senders {
+		ids.senders[id.Name] = id
+	}
+	for _, key := range cfg.DKIM {
+		signer, err := readKey(key.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("NewIdentities: dkim key for %s: %w", key.Domain, err)
+		}
+		ids.dkim[strings.ToLower(key.Domain)] = &dkim.SignOptions{
+			Domain:                 key.Domain,
+			Selector:               key.Selector,
+			Signer:                 signer,
+			Hash:                   crypto.SHA256,
+			HeaderCanonicalization: dkim.CanonicalizationRelaxed,
+			BodyCanonicalization:   dkim.CanonicalizationRelaxed,
+			HeaderKeys:             signedHeaders,
+		}
+	}
+	return ids, nil
+}
+
+// signedHeaders — заголовки под подписью (RFC 6376, 5.4.1). From указан дважды,
+// чтобы по пути нельзя было добавить второй From, не сломав подпись.
+var signedHeaders = []string{
+	"From", "From", "Reply-To", "Subject", "Date", "To", "Cc", "Message-ID",
+	"MIME-Version", "Content-Type", "List-Unsubscribe", "List-Unsubscribe-Post",
+}
+
+// sender возвращает отправителя уведомления; пустое имя — отправитель по умолчанию.
+func (ids *Identities) sender(notify entity.Notify) (entity.Identity, error) {
+	if ids == nil {
+		return entity.Identity{}, errors.New("mailer has no sender identities")
+	}
+	name := notify.Sender
+	if name == "" {
+		name = entity.DefaultSender
+	}
+	id, ok := ids.senders[name]
+	if !ok {
+		return entity.Identity{}, fmt.Errorf("unknown sender %q", name)
+	}
+	return id, nil
+}
+
+// sign подписывает письмо ключом домена отправителя, если ключ для него есть.
+func (ids *Identities) sign(id entity.Identity, msg *bytes.Buffer) (*bytes.Buffer, error) {
+	_, domain, _ := strings.Cut(id.Address, "@")
+	options, ok := ids.dkim[strings.ToLower(domain)]
+	if !ok {
+		return msg, nil
+	}
+	var signed bytes.Buffer
+	if err := dkim.Sign(&signed, msg, options); err != nil {
+		return nil, fmt.Errorf("dkim sign: %w", err)
+	}
+	return &signed, nil
+}
+
+// readKey читает закрытый ключ в PEM: PKCS#1 (BEGIN RSA PRIVATE KEY) или PKCS#8 с RSA и Ed25519.
+func readKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %T, expected RSA or Ed25519", key)
+}
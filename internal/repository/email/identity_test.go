@@ -0,0 +1,112 @@
+package email
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/emersion/go-msgauth/dkim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/config"
+	"delayed-notifier/internal/entity"
+)
+
+func writeKey(t *testing.T, key any) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "dkim.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	return path
+}
+
+func TestIdentities(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	billing := entity.Identity{Name: "billing", Address: "billing@Example.com"}
+	ids, err := NewIdentities(config.Identities{
+		Senders: []entity.Identity{noreply, billing, {Name: "other", Address: "noreply@other.org"}},
+		DKIM:    []config.DKIMKey{{Domain: "example.com", Selector: "s1", KeyFile: writeKey(t, priv)}},
+	})
+	require.NoError(t, err)
+
+	t.Run("sender", func(t *testing.T) {
+		sender, err := ids.sender(entity.Notify{})
+		require.NoError(t, err)
+		assert.Equal(t, noreply, sender)
+
+		sender, err = ids.sender(entity.Notify{Sender: "billing"})
+		require.NoError(t, err)
+		assert.Equal(t, billing, sender)
+
+		_, err = ids.sender(entity.Notify{Sender: "removed"})
+		assert.ErrorContains(t, err, `unknown sender "removed"`)
+	})
+
+	t.Run("dkim signature verifies", func(t *testing.T) {
+		m, err := newMessage(billing, entity.Notify{ID: "id1", To: []string{"a@example.com"}, Subject: "Счёт", Message: "text"}, "a@example.com")
+		require.NoError(t, err)
+		var raw bytes.Buffer
+		_, err = m.WriteTo(&raw)
+		require.NoError(t, err)
+
+		signed, err := ids.sign(billing, &raw)
+		require.NoError(t, err)
+
+		verifications, err := dkim.VerifyWithOptions(bytes.NewReader(signed.Bytes()), &dkim.VerifyOptions{
+			LookupTXT: func(domain string) ([]string, error) {
+				require.Equal(t, "s1._domainkey.example.com", domain)
+				return []string{"v=DKIM1; k=ed25519; p=" + base64.StdEncoding.EncodeToString(pub)}, nil
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, verifications, 1)
+		assert.NoError(t, verifications[0].Err)
+		assert.Equal(t, "example.com", verifications[0].Domain)
+	})
+
+	t.Run("domain without key stays unsigned", func(t *testing.T) {
+		raw := bytes.NewBufferString("From: noreply@other.org\r\n\r\nbody\r\n")
+		signed, err := ids.sign(entity.Identity{Address: "noreply@other.org"}, raw)
+		require.NoError(t, err)
+		assert.NotContains(t, signed.String(), "DKIM-Signature")
+	})
+}
+
+func TestReadKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	t.Run("pkcs1 rsa", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rsa.pem")
+		block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}
+		require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(block), 0o600))
+
+		signer, err := readKey(path)
+		require.NoError(t, err)
+		assert.Equal(t, rsaKey.Public(), signer.Public())
+	})
+
+	t.Run("pkcs8 rsa", func(t *testing.T) {
+		signer, err := readKey(writeKey(t, rsaKey))
+		require.NoError(t, err)
+		assert.Equal(t, rsaKey.Public(), signer.Public())
+	})
+
+	t.Run("not pem", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "key.txt")
+		require.NoError(t, os.WriteFile(path, []byte("secret"), 0o600))
+
+		_, err := readKey(path)
+		assert.Error(t, err)
+	})
+}
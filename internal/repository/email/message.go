@@ -0,0 +1,104 @@
+package email
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/gomail.v2"
+
+	"delayed-notifier/internal/entity"
+)
+
+// DefaultSubject — тема письма, если у уведомления она не задана
+const DefaultSubject = "Уведомление"
+
+// htmlTemplate оформляет текст уведомления, когда клиент не прислал свою HTML-часть.
+// html/template экранирует сообщение, поэтому разметка из него не попадает в письмо.
+var htmlTemplate = template.Must(template.New("notify").Parse(`<html>
+  <body style="font-family: Arial, sans-serif; line-height: 1.6;">
+    <p style="white-space: pre-wrap;">{{.Message}}</p>
+    <hr/>
+    <p style="font-size: 12px; color: #999;">ID уведомления: {{.ID}}</p>
+  </body>
+</html>`))
+
+// newMessage собирает письмо для одного получателя от имени отправителя sender:
+// текстовая часть и HTML-часть идут как multipart/alternative, дополнительные
+// заголовки — после служебных. Содержимое вложений к этому моменту уже загружено из хранилища.
+func newMessage(sender entity.Identity, notify entity.Notify, recipient string) (*gomail.Message, error) {
+	html, err := htmlBody(notify)
+	if err != nil {
+		return nil, err
+	}
+
+	m := gomail.NewMessage()
+	m.SetHeader("From", m.FormatAddress(sender.Address, sender.FromName))
+	m.SetHeader("To", headerTo(notify, recipient)...)
+	if len(notify.Cc) > 0 {
+		m.SetHeader("Cc", notify.Cc...)
+	}
+	if replyTo := replyTo(sender, notify); len(replyTo) > 0 {
+		m.SetHeader("Reply-To", replyTo...)
+	}
+	m.SetHeader("Subject", subject(notify))
+	// Письмо без Message-ID спам-фильтры оценивают хуже
+	if _, domain, ok := strings.Cut(sender.Address, "@"); ok && notify.ID != "" {
+		m.SetHeader("Message-ID", "<"+notify.ID+"@"+domain+">")
+	}
+	for _, name := range slices.Sorted(maps.Keys(notify.Headers)) {
+		m.SetHeader(name, notify.Headers[name])
+	}
+
+	m.SetBody("text/plain", notify.Message)
+	m.AddAlternative("text/html", html)
+	for _, a := range notify.Attachments {
+		content := a.Content
+		m.Attach(a.Filename,
+			gomail.SetHeader(map[string][]string{"Content-Type": {a.ContentType}}),
+			gomail.SetCopyFunc(func(w io.Writer) error {
+				_, err := w.Write(content)
+				return err
+			}),
+		)
+	}
+	return m, nil
+}
+
+func htmlBody(notify entity.Notify) (string, error) {
+	if notify.HTML != "" {
+		return notify.HTML, nil
+	}
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, notify); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func subject(notify entity.Notify) string {
+	if notify.Subject != "" {
+		return notify.Subject
+	}
+	return DefaultSubject
+}
+
+// replyTo возвращает адреса для ответа из уведомления, а без них — адреса отправителя.
+func replyTo(sender entity.Identity, notify entity.Notify) []string {
+	if len(notify.ReplyTo) > 0 {
+		return notify.ReplyTo
+	}
+	return sender.ReplyTo
+}
+
+// headerTo возвращает адреса для заголовка To. Если у уведомления нет получателей To
+// (только копии или списки), в заголовке указывается сам получатель.
+func headerTo(notify entity.Notify, recipient string) []string {
+	if len(notify.To) > 0 {
+		return notify.To
+	}
+	return []string{recipient}
+}
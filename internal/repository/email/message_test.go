@@ -0,0 +1,128 @@
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/entity"
+)
+
+var noreply = entity.Identity{Name: entity.DefaultSender, Address: "noreply@example.com"}
+
+// parts разбирает письмо и возвращает его заголовки и части multipart/alternative по типу
+func parts(t *testing.T, sender entity.Identity, notify entity.Notify) (mail.Header, map[string]string) {
+	m, err := newMessage(sender, notify, "a@example.com")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	_, err = m.WriteTo(&buf)
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(&buf)
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	bodies := map[string]string{}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		var body bytes.Buffer
+		_, err = body.ReadFrom(part)
+		require.NoError(t, err)
+		bodies[contentType] = body.String()
+	}
+	return msg.Header, bodies
+}
+
+func TestNewMessage(t *testing.T) {
+	t.Run("defaults and escaping", func(t *testing.T) {
+		header, bodies := parts(t, noreply, entity.Notify{ID: "id1", To: []string{"a@example.com"}, Message: `<script>alert("x")</script>`})
+
+		subject, err := new(mime.WordDecoder).DecodeHeader(header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, DefaultSubject, subject)
+		assert.Equal(t, `<script>alert("x")</script>`, bodies["text/plain"])
+		assert.NotContains(t, bodies["text/html"], "<script>")
+		assert.Contains(t, bodies["text/html"], "&lt;script&gt;")
+	})
+
+	t.Run("custom fields", func(t *testing.T) {
+		header, bodies := parts(t, noreply, entity.Notify{
+			ID:      "id1",
+			To:      []string{"a@example.com"},
+			Subject: "Счёт оплачен",
+			Message: "text",
+			HTML:    "<p><b>html</b></p>",
+			ReplyTo: []string{"support@example.com"},
+			Headers: map[string]string{"X-Campaign": "spring", "List-Unsubscribe": "<mailto:u@example.com>"},
+		})
+
+		subject, err := new(mime.WordDecoder).DecodeHeader(header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, "Счёт оплачен", subject)
+		assert.Equal(t, "support@example.com", header.Get("Reply-To"))
+		assert.Equal(t, "spring", header.Get("X-Campaign"))
+		assert.Equal(t, "<mailto:u@example.com>", header.Get("List-Unsubscribe"))
+		assert.Equal(t, "text", bodies["text/plain"])
+		assert.Equal(t, "<p><b>html</b></p>", strings.TrimSpace(bodies["text/html"]))
+	})
+
+	t.Run("sender identity", func(t *testing.T) {
+		billing := entity.Identity{Name: "billing", FromName: "Биллинг", Address: "billing@example.com", ReplyTo: []string{"support@example.com"}}
+
+		header, _ := parts(t, billing, entity.Notify{ID: "id1", To: []string{"a@example.com"}, Message: "text"})
+		from, err := header.AddressList("From")
+		require.NoError(t, err)
+		assert.Equal(t, []*mail.Address{{Name: "Биллинг", Address: "billing@example.com"}}, from)
+		assert.Equal(t, "support@example.com", header.Get("Reply-To"))
+		assert.Equal(t, "<id1@example.com>", header.Get("Message-ID"))
+
+		// Reply-To из уведомления важнее адресов отправителя
+		header, _ = parts(t, billing, entity.Notify{ID: "id1", To: []string{"a@example.com"}, Message: "text", ReplyTo: []string{"me@example.com"}})
+		assert.Equal(t, "me@example.com", header.Get("Reply-To"))
+	})
+
+	t.Run("attachments", func(t *testing.T) {
+		m, err := newMessage(noreply, entity.Notify{
+			ID:          "id1",
+			To:          []string{"a@example.com"},
+			Message:     "report attached",
+			Attachments: []entity.Attachment{{ID: "f1", Filename: "report.csv", ContentType: "text/csv", Content: []byte("a,b\n1,2\n")}},
+		}, "a@example.com")
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		_, err = m.WriteTo(&buf)
+		require.NoError(t, err)
+
+		msg, err := mail.ReadMessage(&buf)
+		require.NoError(t, err)
+		mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		require.Equal(t, "multipart/mixed", mediaType)
+
+		reader := multipart.NewReader(msg.Body, params["boundary"])
+		_, err = reader.NextPart() // multipart/alternative с текстом письма
+		require.NoError(t, err)
+		part, err := reader.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, "report.csv", part.FileName())
+		assert.Equal(t, "text/csv", part.Header.Get("Content-Type"))
+		content, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, part))
+		require.NoError(t, err)
+		assert.Equal(t, "a,b\n1,2\n", string(content))
+	})
+}
@@ -23,17 +23,17 @@ func (_m *Notifier) EXPECT() *Notifier_Expecter {
 	return &Notifier_Expecter{mock: &_m.Mock}
 }
 
-// Send provides a mock function with given fields: ctx, notify
-func (_m *Notifier) Send(ctx context.Context, notify entity.Notify) error {
-	ret := _m.Called(ctx, notify)
+// Send provides a mock function with given fields: ctx, notify, recipient
+func (_m *Notifier) Send(ctx context.Context, notify entity.Notify, recipient string) error {
+	ret := _m.Called(ctx, notify, recipient)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Send")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Notify) error); ok {
-		r0 = rf(ctx, notify)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Notify, string) error); ok {
+		r0 = rf(ctx, notify, recipient)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -49,13 +49,14 @@ type Notifier_Send_Call struct {
 // Send is a helper method to define mock.On call
 //   - ctx context.Context
 //   - notify entity.Notify
-func (_e *Notifier_Expecter) Send(ctx interface{}, notify interface{}) *Notifier_Send_Call {
-	return &Notifier_Send_Call{Call: _e.mock.On("Send", ctx, notify)}
+//   - recipient string
+func (_e *Notifier_Expecter) Send(ctx interface{}, notify interface{}, recipient interface{}) *Notifier_Send_Call {
+	return &Notifier_Send_Call{Call: _e.mock.On("Send", ctx, notify, recipient)}
 }
 
-func (_c *Notifier_Send_Call) Run(run func(ctx context.Context, notify entity.Notify)) *Notifier_Send_Call {
+func (_c *Notifier_Send_Call) Run(run func(ctx context.Context, notify entity.Notify, recipient string)) *Notifier_Send_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(entity.Notify))
+		run(args[0].(context.Context), args[1].(entity.Notify), args[2].(string))
 	})
 	return _c
 }
@@ -65,7 +66,7 @@ func (_c *Notifier_Send_Call) Return(_a0 error) *Notifier_Send_Call {
 	return _c
 }
 
-func (_c *Notifier_Send_Call) RunAndReturn(run func(context.Context, entity.Notify) error) *Notifier_Send_Call {
+func (_c *Notifier_Send_Call) RunAndReturn(run func(context.Context, entity.Notify, string) error) *Notifier_Send_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1,51 +1,70 @@
 package email
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
-	"gopkg.in/gomail.v2"
-
 	"delayed-notifier/internal/config"
 	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/secret"
 )
 
 type Mailer struct {
-	dialer *gomail.Dialer
-	from   string
+	pool       *Pool
+	identities *Identities
 }
 
-func NewMailer(cfg config.MailConfig) *Mailer {
+// NewMailer создаёт отправителя с пулом SMTP-соединений; пароль SMTP читается из
+// password перед каждым подключением, чтобы ротация секрета не требовала перезапуска.
+// Без identities мейлер ничего не отправляет — так его получает API, которому не
+// нужны ключи DKIM.
+func NewMailer(cfg config.MailConfig, password *secret.Value, identities *Identities) *Mailer {
 	return &Mailer{
-		dialer: gomail.NewDialer(cfg.Host, cfg.Port, cfg.User, cfg.Password),
-		from:   cfg.User,
+		pool:       NewPool(cfg, password),
+		identities: identities,
 	}
 }
 
-func (s *Mailer) Send(ctx context.Context, notify entity.Notify) error {
-	to := notify.Email
-	if to == "" {
-		return fmt.Errorf("email not found in notify")
+// Send отправляет письмо одному получателю: в заголовках To и Cc видны адреса из
+// уведомления, а конверт SMTP адресован только recipient, поэтому скрытые копии
+// и участники списков не раскрываются остальным. Письмо собирает newMessage и
+// подписывает DKIM-ключ домена отправителя; адрес отправителя идёт и в конверт SMTP.
+// Дедлайн и отмена ctx прерывают ожидание соединения из пула и ответа сервера.
+func (s *Mailer) Send(ctx context.Context, notify entity.Notify, recipient string) error {
+	if recipient == "" {
+		return fmt.Errorf("recipient is empty")
+	}
+
+	sender, err := s.identities.sender(notify)
+	if err != nil {
+		return fmt.Errorf("Mailer.Send: %w", err)
+	}
+	m, err := newMessage(sender, notify, recipient)
+	if err != nil {
+		return fmt.Errorf("Mailer.Send: %w", err)
+	}
+	var raw bytes.Buffer
+	if _, err := m.WriteTo(&raw); err != nil {
+		return fmt.Errorf("Mailer.Send: %w", err)
+	}
+	msg, err := s.identities.sign(sender, &raw)
+	if err != nil {
+		return fmt.Errorf("Mailer.Send: %w", err)
 	}
 
-	m := gomail.NewMessage()
-	m.SetHeader("From", s.from)
-	m.SetHeader("To", to)
-	m.SetHeader("Subject", "Уведомление")
-
-	body := fmt.Sprintf(`
-		<html>
-		  <body style="font-family: Arial, sans-serif; line-height: 1.6;">
-			<p><strong>Сообщение:</strong> %s</p>
-			<hr/>
-			<p style="font-size: 12px; color: #999;">ID уведомления: %s</p>
-		  </body>
-		</html>`,
-		notify.Message,
-		notify.ID,
-	)
-
-	m.SetBody("text/html", body)
-
-	return s.dialer.DialAndSend(m)
+	if err := s.pool.Send(ctx, sender.Address, []string{recipient}, msg.Bytes()); err != nil {
+		return fmt.Errorf("Mailer.Send: %w", err)
+	}
+	return nil
+}
+
+// Stats возвращает статистику пула SMTP-соединений.
+func (s *Mailer) Stats() entity.SMTPPoolStats {
+	return s.pool.Stats()
+}
+
+// Close закрывает соединения с SMTP-сервером.
+func (s *Mailer) Close() error {
+	return s.pool.Close()
 }
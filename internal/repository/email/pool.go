@@ -0,0 +1,362 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"delayed-notifier/internal/config"
+	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/secret"
+)
+
+// smtpsPort — порт SMTP поверх TLS; на остальных портах TLS включается через STARTTLS
+const smtpsPort = 465
+
+// smtpConn — открытая и аутентифицированная сессия с SMTP-сервером.
+type smtpConn struct {
+	conn      net.Conn
+	client    *smtp.Client
+	messages  int
+	idleSince time.Time
+	// broken — состояние сессии неизвестно, соединение нельзя вернуть в пул
+	broken bool
+}
+
+// Pool держит открытые соединения с SMTP-сервером, чтобы не проходить TCP, TLS
+// и AUTH заново для каждого письма. Одновременно открыто не больше cfg.PoolSize
+// соединений, остальные отправки ждут свободного или отмены контекста.
+type Pool struct {
+	cfg      config.MailConfig
+	password *secret.Value
+	slots    chan struct{}
+
+	mu     sync.Mutex
+	idle   []*smtpConn
+	closed bool
+
+	open       atomic.Int64
+	dials      atomic.Int64
+	dialErrors atomic.Int64
+	reuses     atomic.Int64
+	broken     atomic.Int64
+	waits      atomic.Int64
+}
+
+// NewPool создаёт пул; подключения открываются при первой отправке. Пароль
+// читается из password при каждом подключении, открытые сессии ротация не трогает.
+func NewPool(cfg config.MailConfig, password *secret.Value) *Pool {
+	return &Pool{
+		cfg:      cfg,
+		password: password,
+		slots:    make(chan struct{}, cfg.PoolSize),
+	}
+}
+
+// Send отправляет письмо msg получателям to через соединение из пула. Если
+// соединение из пула успел закрыть сервер, письмо повторяется через новое.
+func (p *Pool) Send(ctx context.Context, from string, to []string, msg []byte) error {
+	if err := p.acquire(ctx); err != nil {
+		return err
+	}
+	defer func() { <-p.slots }()
+
+	c, reused, err := p.get(ctx)
+	if err != nil {
+		return err
+	}
+	retry, err := p.send(ctx, c, from, to, msg)
+	if retry && reused {
+		p.put(c)
+		if c, err = p.dial(ctx); err != nil {
+			return err
+		}
+		_, err = p.send(ctx, c, from, to, msg)
+	}
+	p.put(c)
+	return err
+}
+
+// Stats возвращает статистику пула.
+func (p *Pool) Stats() entity.SMTPPoolStats {
+	p.mu.Lock()
+	idle := len(p.idle)
+	p.mu.Unlock()
+	return entity.SMTPPoolStats{
+		Open:       p.open.Load(),
+		Idle:       idle,
+		Dials:      p.dials.Load(),
+		DialErrors: p.dialErrors.Load(),
+		Reuses:     p.reuses.Load(),
+		Broken:     p.broken.Load(),
+		Waits:      p.waits.Load(),
+	}
+}
+
+// Close закрывает простаивающие соединения; занятые закроются по возвращении.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle, p.closed = nil, true
+	p.mu.Unlock()
+
+	for _, c := range idle {
+		p.close(c)
+	}
+	return nil
+}
+
+func (p *Pool) acquire(ctx context.Context) error {
+	select {
+	case p.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	p.waits.Add(1)
+	select {
+	case p.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("smtp pool: %w", ctx.Err())
+	}
+}
+
+// get берёт простаивающее соединение (последнее возвращённое) или открывает новое.
+// Соединения, простоявшие дольше cfg.IdleTimeout, закрываются: сервер мог их уже бросить.
+func (p *Pool) get(ctx context.Context) (*smtpConn, bool, error) {
+	p.mu.Lock()
+	var stale []*smtpConn
+	fresh := p.idle[:0]
+	for _, c := range p.idle {
+		if time.Since(c.idleSince) >= p.cfg.IdleTimeout {
+			stale = append(stale, c)
+			continue
+		}
+		fresh = append(fresh, c)
+	}
+	p.idle = fresh
+	var c *smtpConn
+	if n := len(p.idle); n > 0 {
+		c, p.idle = p.idle[n-1], p.idle[:n-1]
+	}
+	p.mu.Unlock()
+
+	for _, s := range stale {
+		p.close(s)
+	}
+	if c != nil {
+		p.reuses.Add(1)
+		return c, true, nil
+	}
+	c, err := p.dial(ctx)
+	return c, false, err
+}
+
+// put возвращает соединение в пул или закрывает его, если оно сломано,
+// исчерпало cfg.MaxMessagesPerConn или пул закрыт.
+func (p *Pool) put(c *smtpConn) {
+	if !c.broken && c.messages < p.cfg.MaxMessagesPerConn {
+		p.mu.Lock()
+		if !p.closed {
+			c.idleSince = time.Now()
+			p.idle = append(p.idle, c)
+			p.mu.Unlock()
+			return
+		}
+		p.mu.Unlock()
+	}
+	p.close(c)
+}
+
+func (p *Pool) close(c *smtpConn) {
+	p.open.Add(-1)
+	if c.broken {
+		p.broken.Add(1)
+	} else {
+		_ = c.conn.SetDeadline(time.Now().Add(time.Second))
+		_ = c.client.Quit()
+	}
+	_ = c.conn.Close()
+}
+
+func (p *Pool) dial(ctx context.Context) (*smtpConn, error) {
+	p.dials.Add(1)
+	c, err := p.connect(ctx)
+	if err != nil {
+		p.dialErrors.Add(1)
+		return nil, fmt.Errorf("smtp dial: %w", err)
+	}
+	p.open.Add(1)
+	return c, nil
+}
+
+// connect открывает сессию: TLS сразу на порту 465, иначе STARTTLS, если сервер
+// его предлагает, и AUTH, если задан пользователь, — как gomail.Dialer.
+func (p *Pool) connect(ctx context.Context) (*smtpConn, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.Timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(p.cfg.Host, strconv.Itoa(p.cfg.Port)))
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{ServerName: p.cfg.Host, MinVersion: tls.VersionTLS12}
+	if p.cfg.Port == smtpsPort {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	c := &smtpConn{conn: conn}
+	err = p.do(ctx, c, func() error {
+		client, err := smtp.NewClient(conn, p.cfg.Host)
+		if err != nil {
+			return err
+		}
+		c.client = client
+		if ok, _ := client.Extension("STARTTLS"); ok && p.cfg.Port != smtpsPort {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				return err
+			}
+		}
+		if ok, mechanisms := client.Extension("AUTH"); ok && p.cfg.User != "" {
+			return client.Auth(smtpAuth(mechanisms, p.cfg.User, p.password.Get(), p.cfg.Host))
+		}
+		return nil
+	})
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// send передаёт письмо в открытой сессии. retry — сессия оборвалась ещё на
+// MAIL FROM, то есть сервер закрыл простаивавшее соединение и письмо не принял.
+func (p *Pool) send(ctx context.Context, c *smtpConn, from string, to []string, msg []byte) (retry bool, err error) {
+	err = p.do(ctx, c, func() error {
+		return classify("MAIL", c.client.Mail(from))
+	})
+	if err != nil {
+		return c.broken && ctx.Err() == nil && !errors.Is(err, os.ErrDeadlineExceeded), err
+	}
+
+	err = p.do(ctx, c, func() error {
+		for _, addr := range to {
+			if err := c.client.Rcpt(addr); err != nil {
+				return classify("RCPT", err)
+			}
+		}
+		w, err := c.client.Data()
+		if err != nil {
+			return classify("DATA", err)
+		}
+		if _, err := bytes.NewReader(msg).WriteTo(w); err != nil {
+			return err
+		}
+		return classify("DATA", w.Close())
+	})
+	if err == nil {
+		c.messages++
+		return false, nil
+	}
+	// После отказа сервера (например, 550 на RCPT) сессию можно продолжить, сбросив транзакцию
+	if !c.broken {
+		if resetErr := p.do(ctx, c, c.client.Reset); resetErr != nil {
+			c.broken = true
+		}
+	}
+	return false, err
+}
+
+// aLongTimeAgo — дедлайн, который прерывает текущий ввод-вывод соединения
+var aLongTimeAgo = time.Unix(1, 0)
+
+// do выполняет команды fn с дедлайном контекста, но не позже cfg.Timeout; отмена
+// контекста прерывает ожидание ответа сервера. Сетевая ошибка, отмена и ответ 421
+// помечают соединение сломанным.
+func (p *Pool) do(ctx context.Context, c *smtpConn, fn func() error) error {
+	deadline := time.Now().Add(p.cfg.Timeout)
+	ctxDeadline, fromCtx := ctx.Deadline()
+	if fromCtx && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	} else {
+		fromCtx = false
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		c.broken = true
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { _ = c.conn.SetDeadline(aLongTimeAgo) })
+	err := fn()
+	if !stop() {
+		// Дедлайн мог сбиться посреди команды, состояние сессии неизвестно
+		c.broken = true
+	}
+	if err == nil {
+		return nil
+	}
+
+	var reply *textproto.Error
+	if !errors.As(err, &reply) || reply.Code == 421 {
+		c.broken = true
+	}
+	switch {
+	case ctx.Err() != nil:
+		return fmt.Errorf("%w: %v", ctx.Err(), err)
+	case fromCtx && errors.Is(err, os.ErrDeadlineExceeded):
+		return fmt.Errorf("%w: %v", context.DeadlineExceeded, err)
+	}
+	return err
+}
+
+// smtpAuth выбирает механизм аутентификации по списку сервера, как gomail:
+// CRAM-MD5, затем LOGIN для серверов без PLAIN, иначе PLAIN.
+func smtpAuth(mechanisms, user, password, host string) smtp.Auth {
+	switch {
+	case strings.Contains(mechanisms, "CRAM-MD5"):
+		return smtp.CRAMMD5Auth(user, password)
+	case strings.Contains(mechanisms, "LOGIN") && !strings.Contains(mechanisms, "PLAIN"):
+		return &loginAuth{user: user, password: password, host: host}
+	}
+	return smtp.PlainAuth("", user, password, host)
+}
+
+// loginAuth — механизм LOGIN, которого нет в net/smtp.
+type loginAuth struct {
+	user, password, host string
+}
+
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	// Как и PlainAuth, не отправляем пароль открытым текстом
+	if !server.TLS && server.Name != "localhost" && server.Name != "127.0.0.1" && server.Name != "::1" {
+		return "", nil, errors.New("unencrypted connection")
+	}
+	if server.Name != a.host {
+		return "", nil, errors.New("wrong host name")
+	}
+	return "LOGIN", nil, nil
+}
+
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	switch strings.ToLower(strings.TrimSuffix(string(fromServer), ":")) {
+	case "username":
+		return []byte(a.user), nil
+	case "password":
+		return []byte(a.password), nil
+	}
+	return nil, fmt.Errorf("unexpected server challenge %q", fromServer)
+}
@@ -0,0 +1,293 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/config"
+	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/secret"
+)
+
+// fakeSMTP — минимальный SMTP-сервер: требует AUTH PLAIN, отвечает 550 на RCPT
+// reject@… и не отвечает на DATA для slow@….
+type fakeSMTP struct {
+	ln       net.Listener
+	accepted atomic.Int64
+
+	mu       sync.Mutex
+	conns    []net.Conn
+	messages []string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeSMTP{ln: ln}
+	t.Cleanup(func() {
+		_ = ln.Close()
+		s.drop()
+	})
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			s.accepted.Add(1)
+			s.mu.Lock()
+			s.conns = append(s.conns, conn)
+			s.mu.Unlock()
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTP) config(poolSize int) config.MailConfig {
+	addr := s.ln.Addr().(*net.TCPAddr)
+	return config.MailConfig{
+		Host:               "127.0.0.1",
+		Port:               addr.Port,
+		User:               "user",
+		PoolSize:           poolSize,
+		IdleTimeout:        time.Minute,
+		MaxMessagesPerConn: 100,
+		Timeout:            5 * time.Second,
+	}
+}
+
+// drop обрывает все открытые соединения, как сервер по таймауту простоя.
+func (s *fakeSMTP) drop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.conns = nil
+}
+
+func (s *fakeSMTP) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.messages...)
+}
+
+func (s *fakeSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(lines ...string) {
+		_, _ = conn.Write([]byte(strings.Join(lines, "\r\n") + "\r\n"))
+	}
+	reply("220 fake ESMTP")
+	var rcpt string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.TrimSpace(line)
+		switch verb := strings.ToUpper(strings.Fields(cmd + " ")[0]); verb {
+		case "EHLO":
+			reply("250-fake", "250 AUTH PLAIN")
+		case "AUTH":
+			creds, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(cmd, "AUTH PLAIN "))
+			if string(creds) != "\x00user\x00secret" {
+				reply("535 authentication failed")
+				continue
+			}
+			reply("235 ok")
+		case "MAIL", "RSET", "NOOP":
+			rcpt = ""
+			reply("250 ok")
+		case "RCPT":
+			rcpt = strings.TrimSuffix(strings.TrimPrefix(cmd, "RCPT TO:<"), ">")
+			if strings.HasPrefix(rcpt, "reject@") {
+				reply("550 no such user")
+				continue
+			}
+			reply("250 ok")
+		case "DATA":
+			if strings.HasPrefix(rcpt, "slow@") {
+				// Не отвечаем, пока клиент не закроет соединение
+				_, _ = r.ReadString(0)
+				return
+			}
+			reply("354 go ahead")
+			var body strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				body.WriteString(line)
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, rcpt+": "+strings.TrimSpace(body.String()))
+			s.mu.Unlock()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 unknown command")
+		}
+	}
+}
+
+func TestPool(t *testing.T) {
+	ctx := context.Background()
+	password := secret.Static("secret")
+
+	t.Run("reuses connection", func(t *testing.T) {
+		srv := newFakeSMTP(t)
+		pool := NewPool(srv.config(2), password)
+		defer pool.Close()
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, pool.Send(ctx, "noreply@example.com", []string{"a@example.com"}, []byte("msg "+strconv.Itoa(i))))
+		}
+		assert.Equal(t, []string{"a@example.com: msg 0", "a@example.com: msg 1", "a@example.com: msg 2"}, srv.received())
+		assert.Equal(t, int64(1), srv.accepted.Load())
+		assert.Equal(t, entity.SMTPPoolStats{Open: 1, Idle: 1, Dials: 1, Reuses: 2}, pool.Stats())
+	})
+
+	t.Run("reconnects after server dropped connection", func(t *testing.T) {
+		srv := newFakeSMTP(t)
+		pool := NewPool(srv.config(2), password)
+		defer pool.Close()
+
+		require.NoError(t, pool.Send(ctx, "noreply@example.com", []string{"a@example.com"}, []byte("first")))
+		srv.drop()
+		require.NoError(t, pool.Send(ctx, "noreply@example.com", []string{"a@example.com"}, []byte("second")))
+
+		assert.Equal(t, []string{"a@example.com: first", "a@example.com: second"}, srv.received())
+		stats := pool.Stats()
+		assert.Equal(t, int64(2), stats.Dials)
+		assert.Equal(t, int64(1), stats.Broken)
+		assert.Equal(t, int64(1), stats.Open)
+	})
+
+	t.Run("rejected recipient keeps connection", func(t *testing.T) {
+		srv := newFakeSMTP(t)
+		pool := NewPool(srv.config(2), password)
+		defer pool.Close()
+
+		err := pool.Send(ctx, "noreply@example.com", []string{"reject@example.com"}, []byte("msg"))
+		var reply *textproto.Error
+		require.ErrorAs(t, err, &reply)
+		assert.Equal(t, 550, reply.Code)
+		var sendErr *entity.SendError
+		require.ErrorAs(t, err, &sendErr)
+		assert.True(t, sendErr.Bounce)
+		require.NoError(t, pool.Send(ctx, "noreply@example.com", []string{"a@example.com"}, []byte("msg")))
+
+		assert.Equal(t, int64(1), srv.accepted.Load())
+		assert.Equal(t, int64(0), pool.Stats().Broken)
+	})
+
+	t.Run("context deadline interrupts send", func(t *testing.T) {
+		srv := newFakeSMTP(t)
+		pool := NewPool(srv.config(2), password)
+		defer pool.Close()
+
+		ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err := pool.Send(ctx, "noreply@example.com", []string{"slow@example.com"}, []byte("msg"))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 2*time.Second)
+		assert.Equal(t, entity.SMTPPoolStats{Dials: 1, Broken: 1}, pool.Stats())
+	})
+
+	t.Run("cancel interrupts send", func(t *testing.T) {
+		srv := newFakeSMTP(t)
+		pool := NewPool(srv.config(2), password)
+		defer pool.Close()
+
+		ctx, cancel := context.WithCancel(ctx)
+		time.AfterFunc(100*time.Millisecond, cancel)
+		err := pool.Send(ctx, "noreply@example.com", []string{"slow@example.com"}, []byte("msg"))
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("limits open connections", func(t *testing.T) {
+		srv := newFakeSMTP(t)
+		pool := NewPool(srv.config(1), password)
+		defer pool.Close()
+
+		// Единственное соединение занято письмом, на которое сервер не отвечает
+		slowCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+		defer cancel()
+		done := make(chan error)
+		go func() {
+			done <- pool.Send(slowCtx, "noreply@example.com", []string{"slow@example.com"}, []byte("msg"))
+		}()
+		require.Eventually(t, func() bool { return pool.Stats().Open == 1 }, time.Second, 10*time.Millisecond)
+
+		waitCtx, cancelWait := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancelWait()
+		err := pool.Send(waitCtx, "noreply@example.com", []string{"a@example.com"}, []byte("msg"))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// Освободившийся слот достаётся следующей отправке
+		require.NoError(t, pool.Send(ctx, "noreply@example.com", []string{"a@example.com"}, []byte("msg")))
+		assert.ErrorIs(t, <-done, context.DeadlineExceeded)
+		assert.Equal(t, int64(2), pool.Stats().Waits)
+		assert.Equal(t, int64(2), srv.accepted.Load())
+	})
+
+	t.Run("max messages per connection", func(t *testing.T) {
+		srv := newFakeSMTP(t)
+		cfg := srv.config(1)
+		cfg.MaxMessagesPerConn = 2
+		pool := NewPool(cfg, password)
+		defer pool.Close()
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, pool.Send(ctx, "noreply@example.com", []string{"a@example.com"}, []byte("msg")))
+		}
+		assert.Equal(t, int64(2), srv.accepted.Load())
+		assert.Equal(t, int64(2), pool.Stats().Dials)
+	})
+
+	t.Run("idle timeout", func(t *testing.T) {
+		srv := newFakeSMTP(t)
+		cfg := srv.config(1)
+		cfg.IdleTimeout = 10 * time.Millisecond
+		pool := NewPool(cfg, password)
+		defer pool.Close()
+
+		require.NoError(t, pool.Send(ctx, "noreply@example.com", []string{"a@example.com"}, []byte("msg")))
+		time.Sleep(20 * time.Millisecond)
+		require.NoError(t, pool.Send(ctx, "noreply@example.com", []string{"a@example.com"}, []byte("msg")))
+		assert.Equal(t, int64(2), srv.accepted.Load())
+		assert.Equal(t, entity.SMTPPoolStats{Open: 1, Idle: 1, Dials: 2}, pool.Stats())
+	})
+
+	t.Run("auth failure", func(t *testing.T) {
+		srv := newFakeSMTP(t)
+		pool := NewPool(srv.config(1), secret.Static("wrong"))
+		defer pool.Close()
+
+		err := pool.Send(ctx, "noreply@example.com", []string{"a@example.com"}, []byte("msg"))
+		assert.ErrorContains(t, err, "535")
+		assert.Equal(t, entity.SMTPPoolStats{Dials: 1, DialErrors: 1}, pool.Stats())
+	})
+}
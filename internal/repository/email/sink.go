@@ -0,0 +1,119 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"delayed-notifier/internal/entity"
+)
+
+// LogMailer вместо отправки письма пишет его в лог. Для локального запуска без SMTP.
+type LogMailer struct {
+	logger *slog.Logger
+}
+
+func NewLogMailer(logger *slog.Logger) *LogMailer {
+	return &LogMailer{logger: logger}
+}
+
+func (s *LogMailer) Send(ctx context.Context, notify entity.Notify, recipient string) error {
+	if recipient == "" {
+		return fmt.Errorf("recipient is empty")
+	}
+	s.logger.InfoContext(ctx, "mail delivered to log",
+		slog.String("notify_id", notify.ID),
+		slog.String("email", recipient),
+		slog.String("subject", subject(notify)),
+		slog.String("message", notify.Message),
+		slog.Int("attachments", len(notify.Attachments)),
+	)
+	return nil
+}
+
+// sentMail — строка файла FileMailer
+type sentMail struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	// Sender — имя отправителя из уведомления; пустое — отправитель по умолчанию
+	Sender  string            `json:"sender,omitempty"`
+	To      []string          `json:"to,omitempty"`
+	Cc      []string          `json:"cc,omitempty"`
+	ReplyTo []string          `json:"reply_to,omitempty"`
+	Subject string            `json:"subject"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Message string            `json:"message"`
+	HTML    string            `json:"html"`
+	// Attachments — описания вложений без содержимого
+	Attachments []entity.Attachment `json:"attachments,omitempty"`
+	RequestID   string              `json:"request_id,omitempty"`
+	SentAt      time.Time           `json:"sent_at"`
+}
+
+// FileMailer дописывает письма в файл по одному JSON-объекту на строку,
+// чтобы тесты и разработчик могли проверить, что и кому ушло.
+type FileMailer struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileMailer(path string) *FileMailer {
+	return &FileMailer{path: path}
+}
+
+func (s *FileMailer) Send(_ context.Context, notify entity.Notify, recipient string) error {
+	if recipient == "" {
+		return fmt.Errorf("recipient is empty")
+	}
+	html, err := htmlBody(notify)
+	if err != nil {
+		return fmt.Errorf("FileMailer.Send: %w", err)
+	}
+	line, err := json.Marshal(sentMail{
+		ID:          notify.ID,
+		Email:       recipient,
+		Sender:      notify.Sender,
+		To:          headerTo(notify, recipient),
+		Cc:          notify.Cc,
+		ReplyTo:     notify.ReplyTo,
+		Subject:     subject(notify),
+		Headers:     notify.Headers,
+		Message:     notify.Message,
+		HTML:        html,
+		Attachments: withoutContent(notify.Attachments),
+		RequestID:   notify.RequestID,
+		SentAt:      time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("FileMailer.Send: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("FileMailer.Send: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("FileMailer.Send: %w", err)
+	}
+	return f.Close()
+}
+
+func withoutContent(attachments []entity.Attachment) []entity.Attachment {
+	if len(attachments) == 0 {
+		return nil
+	}
+	stripped := make([]entity.Attachment, len(attachments))
+	for i, a := range attachments {
+		a.Content = nil
+		stripped[i] = a
+	}
+	return stripped
+}
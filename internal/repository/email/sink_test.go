@@ -0,0 +1,39 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/entity"
+)
+
+func TestFileMailer(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "mail.jsonl")
+	mailer := NewFileMailer(path)
+
+	require.NoError(t, mailer.Send(ctx, entity.Notify{ID: "id1", To: []string{"a@example.com"}, Message: "one"}, "a@example.com"))
+	require.NoError(t, mailer.Send(ctx, entity.Notify{ID: "id2", To: []string{"a@example.com"}, Cc: []string{"c@example.com"}, Message: "two"}, "b@example.com"))
+	assert.Error(t, mailer.Send(ctx, entity.Notify{ID: "id3"}, ""))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	var mail sentMail
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &mail))
+	assert.Equal(t, "id2", mail.ID)
+	// Скрытая копия видит заголовки письма, но не себя в них
+	assert.Equal(t, "b@example.com", mail.Email)
+	assert.Equal(t, []string{"a@example.com"}, mail.To)
+	assert.Equal(t, []string{"c@example.com"}, mail.Cc)
+	assert.Equal(t, "two", mail.Message)
+}
@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"delayed-notifier/internal/entity"
+)
+
+func (r *NotifyDBRepository) ListNotifies(_ context.Context, filter entity.NotifyFilter) ([]entity.Notify, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var notifies []entity.Notify
+	for id, rec := range r.notifies {
+		n := rec.notify
+		switch {
+		case filter.Status != "" && n.Status != filter.Status,
+			filter.Email != "" && !hasRecipient(n, filter.Email),
+			!filter.From.IsZero() && n.SendAt.Before(filter.From),
+			!filter.To.IsZero() && !n.SendAt.Before(filter.To),
+			filter.Error != "" && !strings.Contains(r.lastError(id), filter.Error):
+			continue
+		}
+		notifies = append(notifies, n)
+	}
+	sort.Slice(notifies, func(i, j int) bool {
+		if !notifies[i].SendAt.Equal(notifies[j].SendAt) {
+			return notifies[i].SendAt.Before(notifies[j].SendAt)
+		}
+		return notifies[i].ID < notifies[j].ID
+	})
+	if filter.Limit > 0 && len(notifies) > filter.Limit {
+		notifies = notifies[:filter.Limit]
+	}
+	return notifies, nil
+}
+
+func (r *NotifyDBRepository) GetNotifyDetails(_ context.Context, notifyID string) (entity.NotifyDetails, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.notifies[notifyID]
+	if !ok {
+		return entity.NotifyDetails{}, fmt.Errorf("GetNotifyDetails: %w", entity.ErrNotifyNotFound)
+	}
+	d := entity.NotifyDetails{
+		Notify:     rec.notify,
+		ReapCount:  rec.reapCount,
+		LastError:  r.lastError(notifyID),
+		Events:     []entity.NotifyEvent{},
+		Deliveries: slices.Clone(r.deliveries[notifyID]),
+	}
+	if d.Deliveries == nil {
+		d.Deliveries = []entity.Delivery{}
+	}
+	if !rec.queuedAt.IsZero() {
+		queuedAt := rec.queuedAt
+		d.QueuedAt = &queuedAt
+	}
+	for _, e := range r.events {
+		if e.NotifyID == notifyID {
+			d.Events = append(d.Events, e)
+		}
+	}
+	return d, nil
+}
+
+func (r *NotifyDBRepository) CancelNotify(_ context.Context, notifyID string) (entity.Notify, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.notifies[notifyID]
+	if !ok || rec.notify.Status != entity.StatusScheduled {
+		return entity.Notify{}, false, nil
+	}
+	return r.update(rec, func(n *entity.Notify) { n.Status = entity.StatusCanceled }), true, nil
+}
+
+func (r *NotifyDBRepository) ScheduleNotify(_ context.Context, notifyID string, sendAt time.Time) (entity.Notify, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.notifies[notifyID]
+	if !ok {
+		return entity.Notify{}, false, nil
+	}
+	switch rec.notify.Status {
+	case entity.StatusScheduled, entity.StatusFailed, entity.StatusSkipped, entity.StatusCanceled:
+	default:
+		return entity.Notify{}, false, nil
+	}
+	rec.queuedAt = time.Time{}
+	rec.reapCount = 0
+	return r.update(rec, func(n *entity.Notify) {
+		n.Status = entity.StatusScheduled
+		n.SendAt = sendAt
+	}), true, nil
+}
+
+func (r *NotifyDBRepository) GetNotifyStats(_ context.Context, now time.Time) (entity.NotifyStats, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := entity.NotifyStats{ByStatus: make(map[string]int)}
+	for _, rec := range r.notifies {
+		n := rec.notify
+		stats.ByStatus[n.Status]++
+		switch {
+		case n.Status == entity.StatusScheduled && !n.SendAt.After(now):
+			stats.Due++
+			stats.OldestDue = earliest(stats.OldestDue, n.SendAt)
+		case n.Status == entity.StatusScheduled:
+			stats.NextSendAt = earliest(stats.NextSendAt, n.SendAt)
+		case n.Status == entity.StatusQueued:
+			stats.OldestQueued = earliest(stats.OldestQueued, rec.queuedAt)
+		}
+	}
+	return stats, nil
+}
+
+// lastError возвращает текст последней ошибки отправки; вызывать под r.mu
+func (r *NotifyDBRepository) lastError(notifyID string) string {
+	for i := len(r.events) - 1; i >= 0; i-- {
+		if e := r.events[i]; e.NotifyID == notifyID && e.Action == entity.EventSendFailed {
+			return e.Details
+		}
+	}
+	return ""
+}
+
+// hasRecipient проверяет, есть ли email среди получателей, как фильтр в PostgreSQL
+func hasRecipient(n entity.Notify, email string) bool {
+	return n.Email == email || slices.Contains(n.To, email) || slices.Contains(n.Cc, email) || slices.Contains(n.Bcc, email)
+}
+
+func earliest(cur *time.Time, t time.Time) *time.Time {
+	if cur == nil || t.Before(*cur) {
+		return &t
+	}
+	return cur
+}
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"delayed-notifier/internal/entity"
+)
+
+func (r *NotifyDBRepository) GetExpiredAttachments(_ context.Context, before time.Time, limit int) ([]entity.Notify, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var notifies []entity.Notify
+	for _, rec := range r.notifies {
+		n := rec.notify
+		if len(n.Attachments) > 0 && !rec.attachmentsPurged && n.SendAt.Before(before) && isFinal(n.Status) {
+			notifies = append(notifies, n)
+		}
+	}
+	sort.Slice(notifies, func(i, j int) bool { return notifies[i].SendAt.Before(notifies[j].SendAt) })
+	if len(notifies) > limit {
+		notifies = notifies[:limit]
+	}
+	return notifies, nil
+}
+
+func (r *NotifyDBRepository) MarkAttachmentsPurged(_ context.Context, notifyID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if rec, ok := r.notifies[notifyID]; ok {
+		rec.attachmentsPurged = true
+	}
+	return nil
+}
+
+func isFinal(status string) bool {
+	switch status {
+	case entity.StatusSent, entity.StatusFailed, entity.StatusSkipped, entity.StatusCanceled:
+		return true
+	}
+	return false
+}
+
+// AttachmentStore — хранилище содержимого вложений в памяти процесса для
+// запуска cmd/notifier без PostgreSQL.
+type AttachmentStore struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func NewAttachmentStore() *AttachmentStore {
+	return &AttachmentStore{files: make(map[string][]byte)}
+}
+
+func (s *AttachmentStore) Put(_ context.Context, content []byte) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := newUUID()
+	s.files[id] = append([]byte(nil), content...)
+	return id, nil
+}
+
+func (s *AttachmentStore) Get(_ context.Context, id string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	content, ok := s.files[id]
+	if !ok {
+		return nil, fmt.Errorf("AttachmentStore.Get: %w", entity.ErrAttachmentNotFound)
+	}
+	return content, nil
+}
+
+func (s *AttachmentStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.files, id)
+	return nil
+}
@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"delayed-notifier/internal/entity"
+)
+
+type NotifyCache interface {
+	SetNotify(ctx context.Context, notify entity.Notify) error
+	GetNotify(ctx context.Context, notifyID string) (entity.Notify, error)
+	DeleteNotify(ctx context.Context, notifyID string) error
+	SetMissing(ctx context.Context, notifyID string) error
+}
+
+type cacheEntry struct {
+	id        string
+	notify    entity.Notify
+	missing   bool
+	expiresAt time.Time
+}
+
+// NotifyLRUCache — in-process LRU перед общим кэшем (Redis). Записи живут недолго (ttl),
+// потому что изменения, сделанные другими экземплярами, сюда не приходят.
+type NotifyLRUCache struct {
+	mu      sync.Mutex
+	next    NotifyCache
+	size    int
+	ttl     time.Duration
+	order   *list.List
+	entries map[string]*list.Element
+	stats   entity.LocalCacheStats
+}
+
+func NewNotifyLRUCache(next NotifyCache, size int, ttl time.Duration) *NotifyLRUCache {
+	return &NotifyLRUCache{
+		next:    next,
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, size),
+	}
+}
+
+func (c *NotifyLRUCache) GetNotify(ctx context.Context, notifyID string) (entity.Notify, error) {
+	if entry, ok := c.get(notifyID); ok {
+		if entry.missing {
+			return entity.Notify{}, entity.ErrNotifyNotFound
+		}
+		return entry.notify, nil
+	}
+
+	notify, err := c.next.GetNotify(ctx, notifyID)
+	switch {
+	case err == nil:
+		c.putNewer(notify)
+	case errors.Is(err, entity.ErrNotifyNotFound):
+		c.putMissing(notifyID)
+	}
+	return notify, err
+}
+
+func (c *NotifyLRUCache) SetNotify(ctx context.Context, notify entity.Notify) error {
+	c.putNewer(notify)
+	return c.next.SetNotify(ctx, notify)
+}
+
+func (c *NotifyLRUCache) SetMissing(ctx context.Context, notifyID string) error {
+	c.putMissing(notifyID)
+	return c.next.SetMissing(ctx, notifyID)
+}
+
+func (c *NotifyLRUCache) DeleteNotify(ctx context.Context, notifyID string) error {
+	c.mu.Lock()
+	if el, ok := c.entries[notifyID]; ok {
+		c.order.Remove(el)
+		delete(c.entries, notifyID)
+	}
+	c.mu.Unlock()
+
+	return c.next.DeleteNotify(ctx, notifyID)
+}
+
+func (c *NotifyLRUCache) Stats() entity.LocalCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Size = c.order.Len()
+	return stats
+}
+
+func (c *NotifyLRUCache) get(notifyID string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[notifyID]
+	if !ok {
+		c.stats.Misses++
+		return cacheEntry{}, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(el)
+		delete(c.entries, notifyID)
+		c.stats.Misses++
+		return cacheEntry{}, false
+	}
+	c.order.MoveToFront(el)
+	c.stats.Hits++
+	return *entry, true
+}
+
+// putNewer кэширует уведомление, если в кэше нет версии не старее. Сравнение и запись
+// идут под одной блокировкой, иначе параллельная запись старой версии может победить.
+func (c *NotifyLRUCache) putNewer(notify entity.Notify) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[notify.ID]; ok {
+		if entry := el.Value.(*cacheEntry); !entry.missing && entry.notify.Version >= notify.Version {
+			return
+		}
+	}
+	c.put(cacheEntry{id: notify.ID, notify: notify})
+}
+
+// putMissing запоминает отсутствие уведомления, не заменяя уже закэшированную запись.
+func (c *NotifyLRUCache) putMissing(notifyID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[notifyID]; !ok {
+		c.put(cacheEntry{id: notifyID, missing: true})
+	}
+}
+
+// put вызывается под c.mu.
+func (c *NotifyLRUCache) put(entry cacheEntry) {
+	entry.expiresAt = time.Now().Add(c.ttl)
+	if el, ok := c.entries[entry.id]; ok {
+		el.Value = &entry
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.entries[entry.id] = c.order.PushFront(&entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).id)
+		c.stats.Evictions++
+	}
+}
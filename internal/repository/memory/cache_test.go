@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"delayed-notifier/internal/entity"
+	mock_cache "delayed-notifier/internal/repository/redis/mocks"
+)
+
+func TestNotifyLRUCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("hit after load", func(t *testing.T) {
+		next := mock_cache.NewNotifyCacheRepository(t)
+		c := NewNotifyLRUCache(next, 2, time.Minute)
+
+		n := entity.Notify{ID: "id1", Version: 1}
+		next.On("GetNotify", ctx, n.ID).Return(n, nil).Once()
+
+		for range 2 {
+			result, err := c.GetNotify(ctx, n.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, n, result)
+		}
+		assert.Equal(t, entity.LocalCacheStats{Hits: 1, Misses: 1, Size: 1}, c.Stats())
+	})
+
+	t.Run("negative entry", func(t *testing.T) {
+		next := mock_cache.NewNotifyCacheRepository(t)
+		c := NewNotifyLRUCache(next, 2, time.Minute)
+
+		next.On("SetMissing", ctx, "id1").Return(nil).Once()
+		assert.NoError(t, c.SetMissing(ctx, "id1"))
+
+		_, err := c.GetNotify(ctx, "id1")
+		assert.ErrorIs(t, err, entity.ErrNotifyNotFound)
+		next.AssertNotCalled(t, "GetNotify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("stale version ignored", func(t *testing.T) {
+		next := mock_cache.NewNotifyCacheRepository(t)
+		c := NewNotifyLRUCache(next, 2, time.Minute)
+
+		fresh := entity.Notify{ID: "id1", Status: entity.StatusSent, Version: 3}
+		stale := entity.Notify{ID: "id1", Status: entity.StatusQueued, Version: 2}
+		next.On("SetNotify", ctx, mock.Anything).Return(nil).Twice()
+
+		assert.NoError(t, c.SetNotify(ctx, fresh))
+		assert.NoError(t, c.SetNotify(ctx, stale))
+
+		result, err := c.GetNotify(ctx, "id1")
+		assert.NoError(t, err)
+		assert.Equal(t, fresh, result)
+	})
+
+	t.Run("concurrent writes keep newest version", func(t *testing.T) {
+		next := mock_cache.NewNotifyCacheRepository(t)
+		next.On("SetNotify", ctx, mock.Anything).Return(nil)
+		const versions = 16
+
+		for range 50 {
+			c := NewNotifyLRUCache(next, 2, time.Minute)
+
+			var wg sync.WaitGroup
+			start := make(chan struct{})
+			for v := int64(versions); v > 0; v-- {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-start
+					assert.NoError(t, c.SetNotify(ctx, entity.Notify{ID: "id1", Version: v}))
+				}()
+			}
+			close(start)
+			wg.Wait()
+
+			result, err := c.GetNotify(ctx, "id1")
+			assert.NoError(t, err)
+			assert.Equal(t, int64(versions), result.Version)
+		}
+	})
+
+	t.Run("evicts least recently used", func(t *testing.T) {
+		next := mock_cache.NewNotifyCacheRepository(t)
+		c := NewNotifyLRUCache(next, 2, time.Minute)
+
+		next.On("SetNotify", ctx, mock.Anything).Return(nil)
+		assert.NoError(t, c.SetNotify(ctx, entity.Notify{ID: "id1"}))
+		assert.NoError(t, c.SetNotify(ctx, entity.Notify{ID: "id2"}))
+		_, _ = c.GetNotify(ctx, "id1")
+		assert.NoError(t, c.SetNotify(ctx, entity.Notify{ID: "id3"}))
+
+		next.On("GetNotify", ctx, "id2").Return(entity.Notify{}, assert.AnError).Once()
+		_, err := c.GetNotify(ctx, "id2")
+		assert.Error(t, err)
+
+		stats := c.Stats()
+		assert.Equal(t, int64(1), stats.Evictions)
+		assert.Equal(t, 2, stats.Size)
+	})
+
+	t.Run("expired entry reloaded", func(t *testing.T) {
+		next := mock_cache.NewNotifyCacheRepository(t)
+		c := NewNotifyLRUCache(next, 2, 0)
+
+		n := entity.Notify{ID: "id1"}
+		next.On("GetNotify", ctx, n.ID).Return(n, nil).Twice()
+
+		_, _ = c.GetNotify(ctx, n.ID)
+		_, _ = c.GetNotify(ctx, n.ID)
+		assert.Equal(t, int64(2), c.Stats().Misses)
+	})
+}
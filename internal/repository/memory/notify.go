@@ -0,0 +1,328 @@
+package memory
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"delayed-notifier/internal/entity"
+)
+
+type record struct {
+	notify    entity.Notify
+	queuedAt  time.Time
+	reapCount int
+	// attachmentsPurged — содержимое вложений удалено по сроку хранения
+	attachmentsPurged bool
+}
+
+// NotifyDBRepository — хранилище уведомлений в памяти процесса для локального
+// запуска и тестов. Повторяет семантику PostgreSQL-репозитория, включая версии
+// строк и события планирования, которые в БД шлёт триггер notify_scheduled.
+type NotifyDBRepository struct {
+	mu         sync.Mutex
+	notifies   map[string]*record
+	events     []entity.NotifyEvent
+	deliveries map[string][]entity.Delivery
+	lists      map[string]entity.RecipientList
+	// suppressions — список подавления по адресу в нижнем регистре
+	suppressions map[string]entity.Suppression
+	subscribers  map[chan entity.ScheduleEvent]struct{}
+}
+
+func NewNotifyDBRepository() *NotifyDBRepository {
+	return &NotifyDBRepository{
+		notifies:     make(map[string]*record),
+		deliveries:   make(map[string][]entity.Delivery),
+		lists:        make(map[string]entity.RecipientList),
+		suppressions: make(map[string]entity.Suppression),
+		subscribers:  make(map[chan entity.ScheduleEvent]struct{}),
+	}
+}
+
+func (r *NotifyDBRepository) CreateNotify(_ context.Context, notify entity.Notify) (entity.Notify, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	notify.ID = newUUID()
+	notify.Version = 1
+	r.notifies[notify.ID] = &record{notify: notify}
+	r.emit(notify)
+	return notify, nil
+}
+
+func (r *NotifyDBRepository) GetNotify(_ context.Context, notifyID string) (entity.Notify, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.notifies[notifyID]
+	if !ok {
+		return entity.Notify{}, fmt.Errorf("GetNotify: %w", entity.ErrNotifyNotFound)
+	}
+	return rec.notify, nil
+}
+
+func (r *NotifyDBRepository) DeleteNotify(_ context.Context, notifyID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.notifies, notifyID)
+	// История и доставки удаляются вместе с уведомлением, как ON DELETE CASCADE в БД
+	r.events = slices.DeleteFunc(r.events, func(e entity.NotifyEvent) bool { return e.NotifyID == notifyID })
+	delete(r.deliveries, notifyID)
+	return nil
+}
+
+func (r *NotifyDBRepository) GetReadyNotifies(_ context.Context, limit int) ([]entity.Notify, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	notifies := r.scheduled(time.Now())
+	if len(notifies) > limit {
+		notifies = notifies[:limit]
+	}
+	return notifies, nil
+}
+
+func (r *NotifyDBRepository) CountReadyNotifies(_ context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.scheduled(before)), nil
+}
+
+func (r *NotifyDBRepository) GetUpcomingNotifies(_ context.Context, until time.Time) ([]entity.Notify, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.scheduled(until), nil
+}
+
+func (r *NotifyDBRepository) GetScheduledSendTimes(_ context.Context) (map[string]time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sendTimes := make(map[string]time.Time)
+	for id, rec := range r.notifies {
+		if rec.notify.Status == entity.StatusScheduled {
+			sendTimes[id] = rec.notify.SendAt
+		}
+	}
+	return sendTimes, nil
+}
+
+func (r *NotifyDBRepository) GetNextSendAt(_ context.Context) (time.Time, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		next  time.Time
+		found bool
+	)
+	for _, rec := range r.notifies {
+		if rec.notify.Status == entity.StatusScheduled && (!found || rec.notify.SendAt.Before(next)) {
+			next, found = rec.notify.SendAt, true
+		}
+	}
+	return next, found, nil
+}
+
+func (r *NotifyDBRepository) UpdateNotifyStatus(_ context.Context, notifyID, status string) (entity.Notify, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.notifies[notifyID]
+	if !ok {
+		return entity.Notify{}, fmt.Errorf("UpdateNotifyStatus: no rows affected for ID=%s", notifyID)
+	}
+	if status == entity.StatusQueued {
+		rec.queuedAt = time.Now()
+	}
+	return r.update(rec, func(n *entity.Notify) { n.Status = status }), nil
+}
+
+func (r *NotifyDBRepository) ClaimNotify(_ context.Context, notifyID string) (entity.Notify, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.notifies[notifyID]
+	if !ok || rec.notify.Status != entity.StatusScheduled {
+		return entity.Notify{}, false, nil
+	}
+	rec.queuedAt = time.Now()
+	return r.update(rec, func(n *entity.Notify) { n.Status = entity.StatusQueued }), true, nil
+}
+
+func (r *NotifyDBRepository) ClaimSending(_ context.Context, notifyID string) (entity.Notify, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.notifies[notifyID]
+	if !ok || (rec.notify.Status != entity.StatusQueued && rec.notify.Status != entity.StatusFailed) {
+		return entity.Notify{}, false, nil
+	}
+	rec.queuedAt = time.Now()
+	return r.update(rec, func(n *entity.Notify) { n.Status = entity.StatusSending }), true, nil
+}
+
+func (r *NotifyDBRepository) SkipNotify(_ context.Context, notifyID string) (entity.Notify, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.notifies[notifyID]
+	if !ok || rec.notify.Status != entity.StatusScheduled {
+		return entity.Notify{}, false, nil
+	}
+	return r.update(rec, func(n *entity.Notify) { n.Status = entity.StatusSkipped }), true, nil
+}
+
+func (r *NotifyDBRepository) RescheduleNotify(_ context.Context, notifyID string, sendAt time.Time) (entity.Notify, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.notifies[notifyID]
+	if !ok || rec.notify.Status != entity.StatusScheduled {
+		return entity.Notify{}, false, nil
+	}
+	return r.update(rec, func(n *entity.Notify) { n.SendAt = sendAt }), true, nil
+}
+
+func (r *NotifyDBRepository) GetStuckNotifies(_ context.Context, before time.Time, limit int) ([]entity.StuckNotify, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var stuck []entity.StuckNotify
+	for _, rec := range r.notifies {
+		if inFlight(rec.notify.Status) && !rec.queuedAt.After(before) {
+			stuck = append(stuck, entity.StuckNotify{Notify: rec.notify, QueuedAt: rec.queuedAt, ReapCount: rec.reapCount})
+		}
+	}
+	sort.Slice(stuck, func(i, j int) bool { return stuck[i].QueuedAt.Before(stuck[j].QueuedAt) })
+	if len(stuck) > limit {
+		stuck = stuck[:limit]
+	}
+	return stuck, nil
+}
+
+func (r *NotifyDBRepository) RequeueStuckNotify(_ context.Context, notifyID string, before time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.notifies[notifyID]
+	if !ok || !inFlight(rec.notify.Status) || rec.queuedAt.After(before) {
+		return false, nil
+	}
+	rec.queuedAt = time.Now()
+	rec.reapCount++
+	r.update(rec, func(n *entity.Notify) { n.Status = entity.StatusQueued })
+	return true, nil
+}
+
+func (r *NotifyDBRepository) FailStuckNotify(_ context.Context, notifyID string, before time.Time) (entity.Notify, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.notifies[notifyID]
+	if !ok || !inFlight(rec.notify.Status) || rec.queuedAt.After(before) {
+		return entity.Notify{}, false, nil
+	}
+	return r.update(rec, func(n *entity.Notify) { n.Status = entity.StatusFailed }), true, nil
+}
+
+// inFlight сообщает, что уведомление поставлено в очередь, но результат отправки ещё не записан.
+func inFlight(status string) bool {
+	return status == entity.StatusQueued || status == entity.StatusSending
+}
+
+func (r *NotifyDBRepository) AddNotifyEvent(_ context.Context, event entity.NotifyEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	r.events = append(r.events, event)
+	return nil
+}
+
+// NewListener возвращает источник событий планирования с тем же интерфейсом,
+// что и postgres.NotifyListener.
+func (r *NotifyDBRepository) NewListener() *NotifyListener {
+	return &NotifyListener{db: r}
+}
+
+// update применяет изменение, увеличивает версию, как триггер notify_bump_version,
+// и рассылает событие, как триггер notify_scheduled.
+func (r *NotifyDBRepository) update(rec *record, change func(*entity.Notify)) entity.Notify {
+	change(&rec.notify)
+	rec.notify.Version++
+	r.emit(rec.notify)
+	return rec.notify
+}
+
+func (r *NotifyDBRepository) emit(notify entity.Notify) {
+	if notify.Status != entity.StatusScheduled {
+		return
+	}
+	event := entity.ScheduleEvent{ID: notify.ID, SendAt: notify.SendAt}
+	for events := range r.subscribers {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
+
+// scheduled возвращает запланированные уведомления с send_at <= before по возрастанию send_at.
+func (r *NotifyDBRepository) scheduled(before time.Time) []entity.Notify {
+	var notifies []entity.Notify
+	for _, rec := range r.notifies {
+		if rec.notify.Status == entity.StatusScheduled && !rec.notify.SendAt.After(before) {
+			notifies = append(notifies, rec.notify)
+		}
+	}
+	sort.Slice(notifies, func(i, j int) bool { return notifies[i].SendAt.Before(notifies[j].SendAt) })
+	return notifies
+}
+
+type NotifyListener struct {
+	db          *NotifyDBRepository
+	subscribers []chan entity.ScheduleEvent
+}
+
+// Subscribe возвращает канал событий; вызывать до Start.
+func (l *NotifyListener) Subscribe() <-chan entity.ScheduleEvent {
+	events := make(chan entity.ScheduleEvent, 64)
+	l.subscribers = append(l.subscribers, events)
+	return events
+}
+
+// Start раздаёт события подписчикам до отмены ctx и закрывает их каналы.
+func (l *NotifyListener) Start(ctx context.Context) {
+	l.db.mu.Lock()
+	for _, events := range l.subscribers {
+		l.db.subscribers[events] = struct{}{}
+	}
+	l.db.mu.Unlock()
+
+	<-ctx.Done()
+
+	l.db.mu.Lock()
+	for _, events := range l.subscribers {
+		delete(l.db.subscribers, events)
+		close(events)
+	}
+	l.db.mu.Unlock()
+}
+
+func newUUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,225 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/config"
+	"delayed-notifier/internal/entity"
+)
+
+func TestNotifyDBRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("claim and versions", func(t *testing.T) {
+		db := NewNotifyDBRepository()
+		created, err := db.CreateNotify(ctx, entity.Notify{Status: entity.StatusScheduled, SendAt: time.Now().Add(-time.Second)})
+		require.NoError(t, err)
+		assert.NotEmpty(t, created.ID)
+		assert.Equal(t, int64(1), created.Version)
+
+		ready, err := db.GetReadyNotifies(ctx, 10)
+		require.NoError(t, err)
+		assert.Len(t, ready, 1)
+
+		claimed, ok, err := db.ClaimNotify(ctx, created.ID)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, entity.StatusQueued, claimed.Status)
+		assert.Equal(t, int64(2), claimed.Version)
+
+		_, ok, err = db.ClaimNotify(ctx, created.ID)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		ready, err = db.GetReadyNotifies(ctx, 10)
+		require.NoError(t, err)
+		assert.Empty(t, ready)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db := NewNotifyDBRepository()
+		_, err := db.GetNotify(ctx, "missing")
+		assert.ErrorIs(t, err, entity.ErrNotifyNotFound)
+	})
+
+	t.Run("stuck notifies", func(t *testing.T) {
+		db := NewNotifyDBRepository()
+		created, err := db.CreateNotify(ctx, entity.Notify{Status: entity.StatusScheduled, SendAt: time.Now()})
+		require.NoError(t, err)
+		_, _, err = db.ClaimNotify(ctx, created.ID)
+		require.NoError(t, err)
+
+		before := time.Now().Add(time.Second)
+		stuck, err := db.GetStuckNotifies(ctx, before, 10)
+		require.NoError(t, err)
+		require.Len(t, stuck, 1)
+		assert.Equal(t, 0, stuck[0].ReapCount)
+
+		ok, err := db.RequeueStuckNotify(ctx, created.ID, before)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		stuck, err = db.GetStuckNotifies(ctx, before, 10)
+		require.NoError(t, err)
+		require.Len(t, stuck, 1)
+		assert.Equal(t, 1, stuck[0].ReapCount)
+	})
+
+	t.Run("claim sending", func(t *testing.T) {
+		db := NewNotifyDBRepository()
+		created, err := db.CreateNotify(ctx, entity.Notify{Status: entity.StatusScheduled, SendAt: time.Now()})
+		require.NoError(t, err)
+
+		_, ok, err := db.ClaimSending(ctx, created.ID)
+		require.NoError(t, err)
+		assert.False(t, ok, "scheduled notify is not sendable")
+
+		_, _, err = db.ClaimNotify(ctx, created.ID)
+		require.NoError(t, err)
+		sending, ok, err := db.ClaimSending(ctx, created.ID)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, entity.StatusSending, sending.Status)
+
+		_, ok, err = db.ClaimSending(ctx, created.ID)
+		require.NoError(t, err)
+		assert.False(t, ok, "second copy must not claim")
+
+		// Консьюмер упал во время отправки: reaper возвращает уведомление в очередь
+		before := time.Now().Add(time.Second)
+		ok, err = db.RequeueStuckNotify(ctx, created.ID, before)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		requeued, err := db.GetNotify(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.StatusQueued, requeued.Status)
+
+		_, err = db.UpdateNotifyStatus(ctx, created.ID, entity.StatusFailed)
+		require.NoError(t, err)
+		_, ok, err = db.ClaimSending(ctx, created.ID)
+		require.NoError(t, err)
+		assert.True(t, ok, "retry of failed notify")
+	})
+
+	t.Run("listener events", func(t *testing.T) {
+		db := NewNotifyDBRepository()
+		listener := db.NewListener()
+		events := listener.Subscribe()
+
+		listenCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			listener.Start(listenCtx)
+			close(done)
+		}()
+		// Подписка регистрируется в Start, ждём её
+		require.Eventually(t, func() bool {
+			db.mu.Lock()
+			defer db.mu.Unlock()
+			return len(db.subscribers) == 1
+		}, time.Second, time.Millisecond)
+
+		sendAt := time.Now().Add(time.Minute)
+		created, err := db.CreateNotify(ctx, entity.Notify{Status: entity.StatusScheduled, SendAt: sendAt})
+		require.NoError(t, err)
+		assert.Equal(t, entity.ScheduleEvent{ID: created.ID, SendAt: sendAt}, <-events)
+
+		// Переход в queued не планирует отправку
+		_, _, err = db.ClaimNotify(ctx, created.ID)
+		require.NoError(t, err)
+
+		cancel()
+		<-done
+		_, open := <-events
+		assert.False(t, open)
+	})
+}
+
+func TestNotifyCacheRepository(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.Default().Cache
+
+	t.Run("miss and stale version", func(t *testing.T) {
+		c := NewNotifyCacheRepository(cfg)
+		_, err := c.GetNotify(ctx, "id1")
+		assert.ErrorIs(t, err, ErrCacheMiss)
+
+		fresh := entity.Notify{ID: "id1", Status: entity.StatusSent, Version: 3}
+		require.NoError(t, c.SetNotify(ctx, fresh))
+		require.NoError(t, c.SetNotify(ctx, entity.Notify{ID: "id1", Status: entity.StatusQueued, Version: 2}))
+
+		result, err := c.GetNotify(ctx, "id1")
+		require.NoError(t, err)
+		assert.Equal(t, fresh, result)
+	})
+
+	t.Run("missing does not replace entry", func(t *testing.T) {
+		c := NewNotifyCacheRepository(cfg)
+		require.NoError(t, c.SetMissing(ctx, "id1"))
+		_, err := c.GetNotify(ctx, "id1")
+		assert.ErrorIs(t, err, entity.ErrNotifyNotFound)
+
+		n := entity.Notify{ID: "id2", Version: 1}
+		require.NoError(t, c.SetNotify(ctx, n))
+		require.NoError(t, c.SetMissing(ctx, "id2"))
+		result, err := c.GetNotify(ctx, "id2")
+		require.NoError(t, err)
+		assert.Equal(t, n, result)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		cfg := cfg
+		cfg.NegativeTTL = time.Millisecond
+		c := NewNotifyCacheRepository(cfg)
+		require.NoError(t, c.SetMissing(ctx, "id1"))
+		time.Sleep(5 * time.Millisecond)
+		_, err := c.GetNotify(ctx, "id1")
+		assert.ErrorIs(t, err, ErrCacheMiss)
+	})
+}
+
+func TestNotifyQueue(t *testing.T) {
+	ctx := context.Background()
+	q := NewNotifyQueue(1)
+
+	require.NoError(t, q.Send(ctx, entity.Notify{ID: "id1"}))
+	assert.ErrorIs(t, q.Send(ctx, entity.Notify{ID: "id2"}), ErrQueueFull)
+	assert.Equal(t, "id1", (<-q.Receive()).ID)
+}
+
+func TestNotifyDBRepositoryAdmin(t *testing.T) {
+	ctx := context.Background()
+	db := NewNotifyDBRepository()
+
+	now := time.Now()
+	failed, err := db.CreateNotify(ctx, entity.Notify{Status: entity.StatusFailed, SendAt: now.Add(-time.Hour)})
+	require.NoError(t, err)
+	require.NoError(t, db.AddNotifyEvent(ctx, entity.NotifyEvent{NotifyID: failed.ID, Action: entity.EventSendFailed, Details: "smtp: 421 try later"}))
+	_, err = db.CreateNotify(ctx, entity.Notify{Status: entity.StatusScheduled, SendAt: now.Add(-time.Minute)})
+	require.NoError(t, err)
+
+	notifies, err := db.ListNotifies(ctx, entity.NotifyFilter{Error: "421"})
+	require.NoError(t, err)
+	require.Len(t, notifies, 1)
+	assert.Equal(t, failed.ID, notifies[0].ID)
+
+	stats, err := db.GetNotifyStats(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{entity.StatusFailed: 1, entity.StatusScheduled: 1}, stats.ByStatus)
+	assert.Equal(t, 1, stats.Due)
+
+	scheduled, ok, err := db.ScheduleNotify(ctx, failed.ID, now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, entity.StatusScheduled, scheduled.Status)
+
+	details, err := db.GetNotifyDetails(ctx, failed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "smtp: 421 try later", details.LastError)
+	assert.Len(t, details.Events, 1)
+}
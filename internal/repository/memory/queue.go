@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"delayed-notifier/internal/entity"
+)
+
+// ErrQueueFull возвращается, когда буфер очереди заполнен. Уведомление при этом
+// остаётся в статусе queued, и его вернёт в работу reaper.
+var ErrQueueFull = errors.New("notify queue is full")
+
+// NotifyQueue — очередь уведомлений в памяти процесса вместо Kafka.
+type NotifyQueue struct {
+	notifies chan entity.Notify
+}
+
+func NewNotifyQueue(size int) *NotifyQueue {
+	return &NotifyQueue{notifies: make(chan entity.Notify, size)}
+}
+
+func (q *NotifyQueue) Send(ctx context.Context, notify entity.Notify) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case q.notifies <- notify:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Receive возвращает канал, из которого читает локальный консьюмер
+func (q *NotifyQueue) Receive() <-chan entity.Notify {
+	return q.notifies
+}
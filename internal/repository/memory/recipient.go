@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"delayed-notifier/internal/entity"
+)
+
+func (r *NotifyDBRepository) CreateRecipientList(_ context.Context, list entity.RecipientList) (entity.RecipientList, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	list.ID = newUUID()
+	list.Members = slices.Clone(list.Members)
+	list.CreatedAt, list.UpdatedAt = now, now
+	r.lists[list.ID] = list
+	return list, nil
+}
+
+func (r *NotifyDBRepository) GetRecipientList(_ context.Context, listID string) (entity.RecipientList, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list, ok := r.lists[listID]
+	if !ok {
+		return entity.RecipientList{}, fmt.Errorf("GetRecipientList: %w", entity.ErrRecipientListNotFound)
+	}
+	return list, nil
+}
+
+func (r *NotifyDBRepository) ListRecipientLists(_ context.Context) ([]entity.RecipientList, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lists := make([]entity.RecipientList, 0, len(r.lists))
+	for _, list := range r.lists {
+		lists = append(lists, list)
+	}
+	sort.Slice(lists, func(i, j int) bool {
+		if lists[i].Name != lists[j].Name {
+			return lists[i].Name < lists[j].Name
+		}
+		return lists[i].ID < lists[j].ID
+	})
+	return lists, nil
+}
+
+func (r *NotifyDBRepository) UpdateRecipientList(_ context.Context, list entity.RecipientList) (entity.RecipientList, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, ok := r.lists[list.ID]
+	if !ok {
+		return entity.RecipientList{}, fmt.Errorf("UpdateRecipientList: %w", entity.ErrRecipientListNotFound)
+	}
+	current.Name = list.Name
+	current.Members = slices.Clone(list.Members)
+	current.UpdatedAt = time.Now()
+	r.lists[list.ID] = current
+	return current, nil
+}
+
+func (r *NotifyDBRepository) DeleteRecipientList(_ context.Context, listID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.lists[listID]; !ok {
+		return fmt.Errorf("DeleteRecipientList: %w", entity.ErrRecipientListNotFound)
+	}
+	delete(r.lists, listID)
+	return nil
+}
+
+func (r *NotifyDBRepository) CreateDeliveries(_ context.Context, notifyID string, deliveries []entity.Delivery) ([]entity.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	current := r.deliveries[notifyID]
+	for _, d := range deliveries {
+		if slices.ContainsFunc(current, func(c entity.Delivery) bool { return strings.EqualFold(c.Email, d.Email) }) {
+			continue
+		}
+		d.NotifyID = notifyID
+		d.Status = entity.DeliveryPending
+		d.UpdatedAt = now
+		current = append(current, d)
+	}
+	r.deliveries[notifyID] = current
+	return slices.Clone(current), nil
+}
+
+func (r *NotifyDBRepository) GetDeliveries(_ context.Context, notifyID string) ([]entity.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deliveries := slices.Clone(r.deliveries[notifyID])
+	if deliveries == nil {
+		deliveries = []entity.Delivery{}
+	}
+	return deliveries, nil
+}
+
+func (r *NotifyDBRepository) UpdateDelivery(_ context.Context, notifyID, email, status, lastError string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.deliveries[notifyID] {
+		d := &r.deliveries[notifyID][i]
+		if strings.EqualFold(d.Email, email) {
+			d.Status = status
+			d.LastError = lastError
+			d.Attempts++
+			d.UpdatedAt = time.Now()
+		}
+	}
+	return nil
+}
+
+func (r *NotifyDBRepository) ResetDeliveries(_ context.Context, notifyID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.deliveries[notifyID] {
+		r.deliveries[notifyID][i].Status = entity.DeliveryPending
+		r.deliveries[notifyID][i].UpdatedAt = time.Now()
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/entity"
+)
+
+func TestRecipientLists(t *testing.T) {
+	ctx := context.Background()
+	db := NewNotifyDBRepository()
+
+	created, err := db.CreateRecipientList(ctx, entity.RecipientList{Name: "ops", Members: []string{"a@example.com"}})
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.ID)
+
+	updated, err := db.UpdateRecipientList(ctx, entity.RecipientList{ID: created.ID, Name: "ops", Members: []string{"b@example.com"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b@example.com"}, updated.Members)
+
+	require.NoError(t, db.DeleteRecipientList(ctx, created.ID))
+	_, err = db.GetRecipientList(ctx, created.ID)
+	assert.ErrorIs(t, err, entity.ErrRecipientListNotFound)
+	assert.ErrorIs(t, db.DeleteRecipientList(ctx, created.ID), entity.ErrRecipientListNotFound)
+}
+
+func TestDeliveries(t *testing.T) {
+	ctx := context.Background()
+	db := NewNotifyDBRepository()
+	n, err := db.CreateNotify(ctx, entity.Notify{Status: entity.StatusQueued, To: []string{"a@example.com", "b@example.com"}})
+	require.NoError(t, err)
+
+	deliveries, err := db.CreateDeliveries(ctx, n.ID, n.Deliveries(nil))
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+
+	// Повторное раскрытие не дублирует доставки, в том числе с адресом в другом регистре
+	deliveries, err = db.CreateDeliveries(ctx, n.ID, n.Deliveries(nil))
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	deliveries, err = db.CreateDeliveries(ctx, n.ID, []entity.Delivery{{Email: "A@Example.com", Kind: entity.RecipientCc}})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+
+	require.NoError(t, db.UpdateDelivery(ctx, n.ID, "B@example.com", entity.StatusFailed, "timeout"))
+	details, err := db.GetNotifyDetails(ctx, n.ID)
+	require.NoError(t, err)
+	require.Len(t, details.Deliveries, 2)
+	assert.Equal(t, entity.DeliveryPending, details.Deliveries[0].Status)
+	assert.Equal(t, entity.StatusFailed, details.Deliveries[1].Status)
+	assert.Equal(t, 1, details.Deliveries[1].Attempts)
+	assert.Equal(t, "timeout", details.Deliveries[1].LastError)
+
+	// Поиск по email находит уведомление по любому получателю
+	found, err := db.ListNotifies(ctx, entity.NotifyFilter{Email: "b@example.com"})
+	require.NoError(t, err)
+	assert.Len(t, found, 1)
+
+	require.NoError(t, db.DeleteNotify(ctx, n.ID))
+	deliveries, err = db.GetDeliveries(ctx, n.ID)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"delayed-notifier/internal/config"
+	"delayed-notifier/internal/entity"
+)
+
+// ErrCacheMiss возвращается NotifyCacheRepository, когда записи нет или она истекла
+var ErrCacheMiss = errors.New("cache miss")
+
+// NotifyCacheRepository — кэш уведомлений в памяти процесса вместо Redis.
+// Повторяет его правила: версия не откатывается, TTL зависит от статуса,
+// отметка об отсутствии живёт NegativeTTL и не затирает настоящую запись.
+type NotifyCacheRepository struct {
+	mu      sync.Mutex
+	cfg     config.CacheConfig
+	entries map[string]cacheEntry
+}
+
+func NewNotifyCacheRepository(cfg config.CacheConfig) *NotifyCacheRepository {
+	return &NotifyCacheRepository{
+		cfg:     cfg,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func (c *NotifyCacheRepository) SetNotify(_ context.Context, notify entity.Notify) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.lookup(notify.ID); ok && !entry.missing && entry.notify.Version >= notify.Version {
+		return nil
+	}
+	c.entries[notify.ID] = cacheEntry{id: notify.ID, notify: notify, expiresAt: time.Now().Add(c.ttl(notify.Status))}
+	return nil
+}
+
+func (c *NotifyCacheRepository) GetNotify(_ context.Context, notifyID string) (entity.Notify, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.lookup(notifyID)
+	switch {
+	case !ok:
+		return entity.Notify{}, ErrCacheMiss
+	case entry.missing:
+		return entity.Notify{}, entity.ErrNotifyNotFound
+	default:
+		return entry.notify, nil
+	}
+}
+
+func (c *NotifyCacheRepository) SetMissing(_ context.Context, notifyID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.lookup(notifyID); !ok {
+		c.entries[notifyID] = cacheEntry{id: notifyID, missing: true, expiresAt: time.Now().Add(c.cfg.NegativeTTL)}
+	}
+	return nil
+}
+
+func (c *NotifyCacheRepository) DeleteNotify(_ context.Context, notifyID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, notifyID)
+	return nil
+}
+
+// lookup возвращает живую запись, попутно удаляя истёкшую
+func (c *NotifyCacheRepository) lookup(notifyID string) (cacheEntry, bool) {
+	entry, ok := c.entries[notifyID]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, notifyID)
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *NotifyCacheRepository) ttl(status string) time.Duration {
+	switch status {
+	case entity.StatusSent, entity.StatusFailed, entity.StatusSkipped, entity.StatusCanceled:
+		return c.cfg.FinalTTL
+	default:
+		return c.cfg.TTL
+	}
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"delayed-notifier/internal/entity"
+)
+
+func (r *NotifyDBRepository) FindSuppressions(_ context.Context, emails []string) ([]entity.Suppression, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[string]bool, len(emails))
+	suppressions := []entity.Suppression{}
+	for _, email := range emails {
+		key := strings.ToLower(email)
+		if s, ok := r.suppressions[key]; ok && !seen[key] {
+			seen[key] = true
+			suppressions = append(suppressions, s)
+		}
+	}
+	sort.Slice(suppressions, func(i, j int) bool { return suppressions[i].Email < suppressions[j].Email })
+	return suppressions, nil
+}
+
+func (r *NotifyDBRepository) AddSuppression(_ context.Context, suppression entity.Suppression) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	suppression.Email = strings.ToLower(suppression.Email)
+	if _, ok := r.suppressions[suppression.Email]; ok {
+		return nil
+	}
+	suppression.CreatedAt = time.Now()
+	r.suppressions[suppression.Email] = suppression
+	return nil
+}
+
+func (r *NotifyDBRepository) ListSuppressions(_ context.Context, filter entity.SuppressionFilter) ([]entity.Suppression, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	substr := strings.ToLower(filter.Email)
+	suppressions := []entity.Suppression{}
+	for _, s := range r.suppressions {
+		if strings.Contains(s.Email, substr) {
+			suppressions = append(suppressions, s)
+		}
+	}
+	sort.Slice(suppressions, func(i, j int) bool {
+		if !suppressions[i].CreatedAt.Equal(suppressions[j].CreatedAt) {
+			return suppressions[i].CreatedAt.After(suppressions[j].CreatedAt)
+		}
+		return suppressions[i].Email < suppressions[j].Email
+	})
+	if filter.Limit > 0 && len(suppressions) > filter.Limit {
+		suppressions = suppressions[:filter.Limit]
+	}
+	return suppressions, nil
+}
+
+func (r *NotifyDBRepository) GetSuppression(_ context.Context, email string) (entity.Suppression, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.suppressions[strings.ToLower(email)]
+	if !ok {
+		return entity.Suppression{}, fmt.Errorf("GetSuppression: %w", entity.ErrSuppressionNotFound)
+	}
+	return s, nil
+}
+
+func (r *NotifyDBRepository) DeleteSuppression(_ context.Context, email string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := strings.ToLower(email)
+	if _, ok := r.suppressions[key]; !ok {
+		return fmt.Errorf("DeleteSuppression: %w", entity.ErrSuppressionNotFound)
+	}
+	delete(r.suppressions, key)
+	return nil
+}
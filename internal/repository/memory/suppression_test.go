@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/entity"
+)
+
+func TestSuppressions(t *testing.T) {
+	ctx := context.Background()
+	db := NewNotifyDBRepository()
+
+	require.NoError(t, db.AddSuppression(ctx, entity.Suppression{Email: "A@example.com", Reason: entity.SuppressionHardBounce, Code: 550}))
+	// Повторный отказ не перезаписывает первый
+	require.NoError(t, db.AddSuppression(ctx, entity.Suppression{Email: "a@example.com", Reason: entity.SuppressionHardBounce, Code: 551}))
+	require.NoError(t, db.AddSuppression(ctx, entity.Suppression{Email: "b@other.org", Reason: entity.SuppressionHardBounce}))
+
+	found, err := db.FindSuppressions(ctx, []string{"a@EXAMPLE.com", "c@example.com", "a@example.com"})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "a@example.com", found[0].Email)
+	assert.Equal(t, 550, found[0].Code)
+
+	listed, err := db.ListSuppressions(ctx, entity.SuppressionFilter{Email: "EXAMPLE"})
+	require.NoError(t, err)
+	assert.Len(t, listed, 1)
+	listed, err = db.ListSuppressions(ctx, entity.SuppressionFilter{Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, listed, 1)
+
+	require.NoError(t, db.DeleteSuppression(ctx, "A@Example.com"))
+	_, err = db.GetSuppression(ctx, "a@example.com")
+	assert.ErrorIs(t, err, entity.ErrSuppressionNotFound)
+	assert.ErrorIs(t, db.DeleteSuppression(ctx, "a@example.com"), entity.ErrSuppressionNotFound)
+}
@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"delayed-notifier/internal/entity"
+)
+
+// lastErrorExpr — текст последней ошибки отправки из истории уведомления
+const lastErrorExpr = `COALESCE((
+	SELECT e.details FROM notify_events e
+	WHERE e.notify_id = notify.id AND e.action = 'send_failed'
+	ORDER BY e.created_at DESC, e.id DESC
+	LIMIT 1
+), '')`
+
+// ListNotifies возвращает уведомления, подходящие под фильтр, по возрастанию send_at.
+func (r *NotifyDBRepository) ListNotifies(ctx context.Context, filter entity.NotifyFilter) ([]entity.Notify, error) {
+	var (
+		conds []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	if filter.Status != "" {
+		conds = append(conds, "status = "+arg(filter.Status))
+	}
+	if filter.Email != "" {
+		// Ищем по всем получателям, а не только по первому
+		email := arg(filter.Email)
+		conds = append(conds, "(email = "+email+" OR "+email+" = ANY(to_emails || cc_emails || bcc_emails))")
+	}
+	if !filter.From.IsZero() {
+		conds = append(conds, "send_at >= "+arg(filter.From))
+	}
+	if !filter.To.IsZero() {
+		conds = append(conds, "send_at < "+arg(filter.To))
+	}
+	if filter.Error != "" {
+		conds = append(conds, "strpos("+lastErrorExpr+", "+arg(filter.Error)+") > 0")
+	}
+
+	query := `
+		SELECT ` + notifyColumns + `
+		FROM notify`
+	if len(conds) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(conds, " AND ")
+	}
+	query += "\n\t\tORDER BY send_at, id"
+	if filter.Limit > 0 {
+		query += "\n\t\tLIMIT " + arg(filter.Limit)
+	}
+
+	rows, err := r.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ListNotifies query: %w", err)
+	}
+	defer rows.Close()
+
+	var notifies []entity.Notify
+	for rows.Next() {
+		var notify entity.Notify
+		if err := rows.Scan(notifyFields(&notify)...); err != nil {
+			return nil, fmt.Errorf("ListNotifies scan: %w", err)
+		}
+		notifies = append(notifies, notify)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ListNotifies iteration: %w", err)
+	}
+
+	return notifies, nil
+}
+
+// GetNotifyDetails возвращает уведомление вместе с историей событий.
+func (r *NotifyDBRepository) GetNotifyDetails(ctx context.Context, notifyID string) (entity.NotifyDetails, error) {
+	query := `
+		SELECT ` + notifyColumns + `, queued_at, reap_count, ` + lastErrorExpr + `
+		FROM notify
+		WHERE id = $1
+	`
+
+	var d entity.NotifyDetails
+	err := r.Pool.QueryRow(ctx, query, notifyID).Scan(append(notifyFields(&d.Notify), &d.QueuedAt, &d.ReapCount, &d.LastError)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.NotifyDetails{}, fmt.Errorf("GetNotifyDetails: %w", entity.ErrNotifyNotFound)
+		}
+		return entity.NotifyDetails{}, fmt.Errorf("GetNotifyDetails: %w", err)
+	}
+
+	eventsQuery := `
+		SELECT notify_id, action, details, created_at
+		FROM notify_events
+		WHERE notify_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.Pool.Query(ctx, eventsQuery, notifyID)
+	if err != nil {
+		return entity.NotifyDetails{}, fmt.Errorf("GetNotifyDetails events query: %w", err)
+	}
+	defer rows.Close()
+
+	d.Events = []entity.NotifyEvent{}
+	for rows.Next() {
+		var e entity.NotifyEvent
+		if err := rows.Scan(&e.NotifyID, &e.Action, &e.Details, &e.CreatedAt); err != nil {
+			return entity.NotifyDetails{}, fmt.Errorf("GetNotifyDetails events scan: %w", err)
+		}
+		d.Events = append(d.Events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return entity.NotifyDetails{}, fmt.Errorf("GetNotifyDetails events iteration: %w", err)
+	}
+
+	if d.Deliveries, err = r.GetDeliveries(ctx, notifyID); err != nil {
+		return entity.NotifyDetails{}, fmt.Errorf("GetNotifyDetails: %w", err)
+	}
+
+	return d, nil
+}
+
+// CancelNotify отменяет уведомление, только если оно ещё в статусе scheduled.
+func (r *NotifyDBRepository) CancelNotify(ctx context.Context, notifyID string) (entity.Notify, bool, error) {
+	query := `
+		UPDATE notify
+		SET status = $1
+		WHERE id = $2 AND status = $3
+		` + returningNotify
+
+	notify, ok, err := r.updateNotify(ctx, query, entity.StatusCanceled, notifyID, entity.StatusScheduled)
+	if err != nil {
+		return entity.Notify{}, false, fmt.Errorf("CancelNotify: %w", err)
+	}
+	return notify, ok, nil
+}
+
+// ScheduleNotify заново планирует уведомление на sendAt. Подходят запланированные
+// и завершённые без отправки (failed, skipped, canceled); счётчик reaper сбрасывается.
+func (r *NotifyDBRepository) ScheduleNotify(ctx context.Context, notifyID string, sendAt time.Time) (entity.Notify, bool, error) {
+	query := `
+		UPDATE notify
+		SET status = $1, send_at = $2, queued_at = NULL, reap_count = 0
+		WHERE id = $3 AND status IN ($1, $4, $5, $6)
+		` + returningNotify
+
+	notify, ok, err := r.updateNotify(ctx, query, entity.StatusScheduled, sendAt, notifyID,
+		entity.StatusFailed, entity.StatusSkipped, entity.StatusCanceled)
+	if err != nil {
+		return entity.Notify{}, false, fmt.Errorf("ScheduleNotify: %w", err)
+	}
+	return notify, ok, nil
+}
+
+// GetNotifyStats считает уведомления по статусам и бэклог планировщика на момент now.
+func (r *NotifyDBRepository) GetNotifyStats(ctx context.Context, now time.Time) (entity.NotifyStats, error) {
+	stats := entity.NotifyStats{ByStatus: make(map[string]int)}
+
+	rows, err := r.Pool.Query(ctx, `SELECT status, count(*) FROM notify GROUP BY status`)
+	if err != nil {
+		return entity.NotifyStats{}, fmt.Errorf("GetNotifyStats query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			status string
+			count  int
+		)
+		if err := rows.Scan(&status, &count); err != nil {
+			return entity.NotifyStats{}, fmt.Errorf("GetNotifyStats scan: %w", err)
+		}
+		stats.ByStatus[status] = count
+	}
+	if err := rows.Err(); err != nil {
+		return entity.NotifyStats{}, fmt.Errorf("GetNotifyStats iteration: %w", err)
+	}
+
+	query := `
+		SELECT
+			count(*) FILTER (WHERE status = $1 AND send_at <= $2),
+			min(send_at) FILTER (WHERE status = $1 AND send_at <= $2),
+			min(send_at) FILTER (WHERE status = $1 AND send_at > $2),
+			min(queued_at) FILTER (WHERE status = $3)
+		FROM notify
+	`
+	err = r.Pool.QueryRow(ctx, query, entity.StatusScheduled, now, entity.StatusQueued).Scan(
+		&stats.Due,
+		&stats.OldestDue,
+		&stats.NextSendAt,
+		&stats.OldestQueued,
+	)
+	if err != nil {
+		return entity.NotifyStats{}, fmt.Errorf("GetNotifyStats: %w", err)
+	}
+
+	return stats, nil
+}
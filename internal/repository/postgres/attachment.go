@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"delayed-notifier/internal/entity"
+)
+
+// finalStatuses — статусы, после которых уведомление само уже не отправится
+var finalStatuses = []string{entity.StatusSent, entity.StatusFailed, entity.StatusSkipped, entity.StatusCanceled}
+
+func (r *NotifyDBRepository) GetExpiredAttachments(ctx context.Context, before time.Time, limit int) ([]entity.Notify, error) {
+	query := `
+		SELECT ` + notifyColumns + `
+		FROM notify
+		WHERE send_at < $1 AND attachments <> '[]' AND attachments_purged_at IS NULL AND status = ANY($2)
+		ORDER BY send_at
+		LIMIT $3
+	`
+
+	rows, err := r.Pool.Query(ctx, query, before, finalStatuses, limit)
+	if err != nil {
+		return nil, fmt.Errorf("GetExpiredAttachments query: %w", err)
+	}
+	defer rows.Close()
+
+	var notifies []entity.Notify
+	for rows.Next() {
+		var notify entity.Notify
+		if err := rows.Scan(notifyFields(&notify)...); err != nil {
+			return nil, fmt.Errorf("GetExpiredAttachments scan: %w", err)
+		}
+		notifies = append(notifies, notify)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("GetExpiredAttachments iteration: %w", err)
+	}
+	return notifies, nil
+}
+
+func (r *NotifyDBRepository) MarkAttachmentsPurged(ctx context.Context, notifyID string) error {
+	query := `UPDATE notify SET attachments_purged_at = NOW() WHERE id = $1`
+
+	if _, err := r.Pool.Exec(ctx, query, notifyID); err != nil {
+		return fmt.Errorf("MarkAttachmentsPurged: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"delayed-notifier/internal/entity"
+)
+
+const (
+	scheduledChannel       = "notify_scheduled"
+	listenerReconnectDelay = time.Second
+)
+
+type NotifyListener struct {
+	pool        *pgxpool.Pool
+	subscribers []chan entity.ScheduleEvent
+	logger      *slog.Logger
+}
+
+func NewNotifyListener(pool *pgxpool.Pool, logger *slog.Logger) *NotifyListener {
+	return &NotifyListener{pool: pool, logger: logger}
+}
+
+// Subscribe возвращает канал событий; вызывать до Start.
+func (l *NotifyListener) Subscribe() <-chan entity.ScheduleEvent {
+	events := make(chan entity.ScheduleEvent, 64)
+	l.subscribers = append(l.subscribers, events)
+	return events
+}
+
+// Start подписывается на канал notify_scheduled и раздаёт события подписчикам до отмены ctx.
+// После (пере)подключения отправляется пустое событие: уведомления, пришедшие
+// во время разрыва, потеряны, и подписчик должен перечитать расписание.
+func (l *NotifyListener) Start(ctx context.Context) {
+	defer func() {
+		for _, events := range l.subscribers {
+			close(events)
+		}
+	}()
+
+	for {
+		if err := l.listen(ctx); err != nil && ctx.Err() == nil {
+			l.logger.Error("notify listener error", slog.Any("error", err))
+		}
+
+		timer := time.NewTimer(listenerReconnectDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			l.logger.Info("notify listener stopped")
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (l *NotifyListener) listen(ctx context.Context) error {
+	poolConn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	// Соединение с активным LISTEN нельзя возвращать в пул
+	conn := poolConn.Hijack()
+	defer func() {
+		_ = conn.Close(context.Background())
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+scheduledChannel); err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	l.logger.Info("notify listener started", slog.String("channel", scheduledChannel))
+
+	l.emit(entity.ScheduleEvent{})
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("wait for notification: %w", err)
+		}
+
+		var event entity.ScheduleEvent
+		if err := json.Unmarshal([]byte(n.Payload), &event); err != nil {
+			l.logger.Warn("invalid notify_scheduled payload", slog.String("payload", n.Payload), slog.Any("error", err))
+			continue
+		}
+		l.emit(event)
+	}
+}
+
+func (l *NotifyListener) emit(event entity.ScheduleEvent) {
+	for _, events := range l.subscribers {
+		select {
+		case events <- event:
+		default:
+			// Подписчик занят и после обработки сам перечитает расписание
+			l.logger.Debug("notify listener event dropped", slog.String("id", event.ID))
+		}
+	}
+}
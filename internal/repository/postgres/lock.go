@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AdvisoryLock — сессионная advisory-блокировка PostgreSQL на выделенном соединении.
+// Блокировка живёт, пока живёт соединение: если процесс-владелец падает, PostgreSQL
+// закрывает сессию и блокировка сразу освобождается.
+type AdvisoryLock struct {
+	pool *pgxpool.Pool
+	key  int64
+	conn *pgx.Conn
+}
+
+func NewAdvisoryLock(pool *pgxpool.Pool, key int64) *AdvisoryLock {
+	return &AdvisoryLock{pool: pool, key: key}
+}
+
+func (l *AdvisoryLock) TryAcquire(ctx context.Context) (bool, error) {
+	if l.conn == nil {
+		poolConn, err := l.pool.Acquire(ctx)
+		if err != nil {
+			return false, fmt.Errorf("TryAcquire: acquire connection: %w", err)
+		}
+		// Блокировка привязана к сессии, поэтому соединение забираем из пула насовсем
+		l.conn = poolConn.Hijack()
+	}
+
+	var acquired bool
+	if err := l.conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&acquired); err != nil {
+		l.close()
+		return false, fmt.Errorf("TryAcquire: %w", err)
+	}
+	return acquired, nil
+}
+
+// Check проверяет, что сессия, держащая блокировку, жива.
+func (l *AdvisoryLock) Check(ctx context.Context) error {
+	if l.conn == nil {
+		return fmt.Errorf("Check: lock is not held")
+	}
+	if err := l.conn.Ping(ctx); err != nil {
+		l.close()
+		return fmt.Errorf("Check: %w", err)
+	}
+	return nil
+}
+
+func (l *AdvisoryLock) Release(ctx context.Context) error {
+	if l.conn == nil {
+		return nil
+	}
+	defer l.close()
+
+	if _, err := l.conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", l.key); err != nil {
+		return fmt.Errorf("Release: %w", err)
+	}
+	return nil
+}
+
+func (l *AdvisoryLock) close() {
+	_ = l.conn.Close(context.Background())
+	l.conn = nil
+}
@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+
+	"delayed-notifier/migrations"
+)
+
+// Migrate применяет встроенные миграции goose. Таблица версий та же, что у
+// `make migrate-up`, поэтому способы можно смешивать.
+func Migrate(ctx context.Context, pool *pgxpool.Pool, logger *slog.Logger) error {
+	db := stdlib.OpenDBFromPool(pool)
+	defer func() {
+		_ = db.Close()
+	}()
+
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, migrations.FS)
+	if err != nil {
+		return fmt.Errorf("Migrate: %w", err)
+	}
+
+	results, err := provider.Up(ctx)
+	if err != nil {
+		return fmt.Errorf("Migrate: %w", err)
+	}
+	for _, r := range results {
+		logger.Info("migration applied", slog.String("source", r.Source.Path), slog.Duration("duration", r.Duration))
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Lock is an autogenerated mock type for the Lock type
+type Lock struct {
+	mock.Mock
+}
+
+type Lock_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Lock) EXPECT() *Lock_Expecter {
+	return &Lock_Expecter{mock: &_m.Mock}
+}
+
+// Check provides a mock function with given fields: ctx
+func (_m *Lock) Check(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Lock_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
+type Lock_Check_Call struct {
+	*mock.Call
+}
+
+// Check is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Lock_Expecter) Check(ctx interface{}) *Lock_Check_Call {
+	return &Lock_Check_Call{Call: _e.mock.On("Check", ctx)}
+}
+
+func (_c *Lock_Check_Call) Run(run func(ctx context.Context)) *Lock_Check_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Lock_Check_Call) Return(_a0 error) *Lock_Check_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Lock_Check_Call) RunAndReturn(run func(context.Context) error) *Lock_Check_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function with given fields: ctx
+func (_m *Lock) Release(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Lock_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type Lock_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Lock_Expecter) Release(ctx interface{}) *Lock_Release_Call {
+	return &Lock_Release_Call{Call: _e.mock.On("Release", ctx)}
+}
+
+func (_c *Lock_Release_Call) Run(run func(ctx context.Context)) *Lock_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Lock_Release_Call) Return(_a0 error) *Lock_Release_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Lock_Release_Call) RunAndReturn(run func(context.Context) error) *Lock_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TryAcquire provides a mock function with given fields: ctx
+func (_m *Lock) TryAcquire(ctx context.Context) (bool, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TryAcquire")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Lock_TryAcquire_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TryAcquire'
+type Lock_TryAcquire_Call struct {
+	*mock.Call
+}
+
+// TryAcquire is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Lock_Expecter) TryAcquire(ctx interface{}) *Lock_TryAcquire_Call {
+	return &Lock_TryAcquire_Call{Call: _e.mock.On("TryAcquire", ctx)}
+}
+
+func (_c *Lock_TryAcquire_Call) Run(run func(ctx context.Context)) *Lock_TryAcquire_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Lock_TryAcquire_Call) Return(_a0 bool, _a1 error) *Lock_TryAcquire_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Lock_TryAcquire_Call) RunAndReturn(run func(context.Context) (bool, error)) *Lock_TryAcquire_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLock creates a new instance of Lock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Lock {
+	mock := &Lock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,341 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+
+	entity "delayed-notifier/internal/entity"
+)
+
+// NotifyAdminRepository is an autogenerated mock type for the NotifyAdminRepository type
+type NotifyAdminRepository struct {
+	mock.Mock
+}
+
+type NotifyAdminRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *NotifyAdminRepository) EXPECT() *NotifyAdminRepository_Expecter {
+	return &NotifyAdminRepository_Expecter{mock: &_m.Mock}
+}
+
+// CancelNotify provides a mock function with given fields: ctx, notifyID
+func (_m *NotifyAdminRepository) CancelNotify(ctx context.Context, notifyID string) (entity.Notify, bool, error) {
+	ret := _m.Called(ctx, notifyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelNotify")
+	}
+
+	var r0 entity.Notify
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.Notify, bool, error)); ok {
+		return rf(ctx, notifyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.Notify); ok {
+		r0 = rf(ctx, notifyID)
+	} else {
+		r0 = ret.Get(0).(entity.Notify)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, notifyID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, notifyID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NotifyAdminRepository_CancelNotify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelNotify'
+type NotifyAdminRepository_CancelNotify_Call struct {
+	*mock.Call
+}
+
+// CancelNotify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+func (_e *NotifyAdminRepository_Expecter) CancelNotify(ctx interface{}, notifyID interface{}) *NotifyAdminRepository_CancelNotify_Call {
+	return &NotifyAdminRepository_CancelNotify_Call{Call: _e.mock.On("CancelNotify", ctx, notifyID)}
+}
+
+func (_c *NotifyAdminRepository_CancelNotify_Call) Run(run func(ctx context.Context, notifyID string)) *NotifyAdminRepository_CancelNotify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotifyAdminRepository_CancelNotify_Call) Return(_a0 entity.Notify, _a1 bool, _a2 error) *NotifyAdminRepository_CancelNotify_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *NotifyAdminRepository_CancelNotify_Call) RunAndReturn(run func(context.Context, string) (entity.Notify, bool, error)) *NotifyAdminRepository_CancelNotify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotifyDetails provides a mock function with given fields: ctx, notifyID
+func (_m *NotifyAdminRepository) GetNotifyDetails(ctx context.Context, notifyID string) (entity.NotifyDetails, error) {
+	ret := _m.Called(ctx, notifyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotifyDetails")
+	}
+
+	var r0 entity.NotifyDetails
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.NotifyDetails, error)); ok {
+		return rf(ctx, notifyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.NotifyDetails); ok {
+		r0 = rf(ctx, notifyID)
+	} else {
+		r0 = ret.Get(0).(entity.NotifyDetails)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, notifyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyAdminRepository_GetNotifyDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotifyDetails'
+type NotifyAdminRepository_GetNotifyDetails_Call struct {
+	*mock.Call
+}
+
+// GetNotifyDetails is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+func (_e *NotifyAdminRepository_Expecter) GetNotifyDetails(ctx interface{}, notifyID interface{}) *NotifyAdminRepository_GetNotifyDetails_Call {
+	return &NotifyAdminRepository_GetNotifyDetails_Call{Call: _e.mock.On("GetNotifyDetails", ctx, notifyID)}
+}
+
+func (_c *NotifyAdminRepository_GetNotifyDetails_Call) Run(run func(ctx context.Context, notifyID string)) *NotifyAdminRepository_GetNotifyDetails_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotifyAdminRepository_GetNotifyDetails_Call) Return(_a0 entity.NotifyDetails, _a1 error) *NotifyAdminRepository_GetNotifyDetails_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyAdminRepository_GetNotifyDetails_Call) RunAndReturn(run func(context.Context, string) (entity.NotifyDetails, error)) *NotifyAdminRepository_GetNotifyDetails_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotifyStats provides a mock function with given fields: ctx, now
+func (_m *NotifyAdminRepository) GetNotifyStats(ctx context.Context, now time.Time) (entity.NotifyStats, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotifyStats")
+	}
+
+	var r0 entity.NotifyStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (entity.NotifyStats, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) entity.NotifyStats); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(entity.NotifyStats)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyAdminRepository_GetNotifyStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotifyStats'
+type NotifyAdminRepository_GetNotifyStats_Call struct {
+	*mock.Call
+}
+
+// GetNotifyStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *NotifyAdminRepository_Expecter) GetNotifyStats(ctx interface{}, now interface{}) *NotifyAdminRepository_GetNotifyStats_Call {
+	return &NotifyAdminRepository_GetNotifyStats_Call{Call: _e.mock.On("GetNotifyStats", ctx, now)}
+}
+
+func (_c *NotifyAdminRepository_GetNotifyStats_Call) Run(run func(ctx context.Context, now time.Time)) *NotifyAdminRepository_GetNotifyStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *NotifyAdminRepository_GetNotifyStats_Call) Return(_a0 entity.NotifyStats, _a1 error) *NotifyAdminRepository_GetNotifyStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyAdminRepository_GetNotifyStats_Call) RunAndReturn(run func(context.Context, time.Time) (entity.NotifyStats, error)) *NotifyAdminRepository_GetNotifyStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNotifies provides a mock function with given fields: ctx, filter
+func (_m *NotifyAdminRepository) ListNotifies(ctx context.Context, filter entity.NotifyFilter) ([]entity.Notify, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNotifies")
+	}
+
+	var r0 []entity.Notify
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.NotifyFilter) ([]entity.Notify, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.NotifyFilter) []entity.Notify); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Notify)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.NotifyFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyAdminRepository_ListNotifies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNotifies'
+type NotifyAdminRepository_ListNotifies_Call struct {
+	*mock.Call
+}
+
+// ListNotifies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter entity.NotifyFilter
+func (_e *NotifyAdminRepository_Expecter) ListNotifies(ctx interface{}, filter interface{}) *NotifyAdminRepository_ListNotifies_Call {
+	return &NotifyAdminRepository_ListNotifies_Call{Call: _e.mock.On("ListNotifies", ctx, filter)}
+}
+
+func (_c *NotifyAdminRepository_ListNotifies_Call) Run(run func(ctx context.Context, filter entity.NotifyFilter)) *NotifyAdminRepository_ListNotifies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.NotifyFilter))
+	})
+	return _c
+}
+
+func (_c *NotifyAdminRepository_ListNotifies_Call) Return(_a0 []entity.Notify, _a1 error) *NotifyAdminRepository_ListNotifies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyAdminRepository_ListNotifies_Call) RunAndReturn(run func(context.Context, entity.NotifyFilter) ([]entity.Notify, error)) *NotifyAdminRepository_ListNotifies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleNotify provides a mock function with given fields: ctx, notifyID, sendAt
+func (_m *NotifyAdminRepository) ScheduleNotify(ctx context.Context, notifyID string, sendAt time.Time) (entity.Notify, bool, error) {
+	ret := _m.Called(ctx, notifyID, sendAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleNotify")
+	}
+
+	var r0 entity.Notify
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (entity.Notify, bool, error)); ok {
+		return rf(ctx, notifyID, sendAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) entity.Notify); ok {
+		r0 = rf(ctx, notifyID, sendAt)
+	} else {
+		r0 = ret.Get(0).(entity.Notify)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) bool); ok {
+		r1 = rf(ctx, notifyID, sendAt)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Time) error); ok {
+		r2 = rf(ctx, notifyID, sendAt)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NotifyAdminRepository_ScheduleNotify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleNotify'
+type NotifyAdminRepository_ScheduleNotify_Call struct {
+	*mock.Call
+}
+
+// ScheduleNotify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+//   - sendAt time.Time
+func (_e *NotifyAdminRepository_Expecter) ScheduleNotify(ctx interface{}, notifyID interface{}, sendAt interface{}) *NotifyAdminRepository_ScheduleNotify_Call {
+	return &NotifyAdminRepository_ScheduleNotify_Call{Call: _e.mock.On("ScheduleNotify", ctx, notifyID, sendAt)}
+}
+
+func (_c *NotifyAdminRepository_ScheduleNotify_Call) Run(run func(ctx context.Context, notifyID string, sendAt time.Time)) *NotifyAdminRepository_ScheduleNotify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *NotifyAdminRepository_ScheduleNotify_Call) Return(_a0 entity.Notify, _a1 bool, _a2 error) *NotifyAdminRepository_ScheduleNotify_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *NotifyAdminRepository_ScheduleNotify_Call) RunAndReturn(run func(context.Context, string, time.Time) (entity.Notify, bool, error)) *NotifyAdminRepository_ScheduleNotify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewNotifyAdminRepository creates a new instance of NotifyAdminRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotifyAdminRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotifyAdminRepository {
+	mock := &NotifyAdminRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 
@@ -23,6 +24,298 @@ func (_m *NotifyDBRepository) EXPECT() *NotifyDBRepository_Expecter {
 	return &NotifyDBRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddNotifyEvent provides a mock function with given fields: ctx, event
+func (_m *NotifyDBRepository) AddNotifyEvent(ctx context.Context, event entity.NotifyEvent) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddNotifyEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.NotifyEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyDBRepository_AddNotifyEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddNotifyEvent'
+type NotifyDBRepository_AddNotifyEvent_Call struct {
+	*mock.Call
+}
+
+// AddNotifyEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event entity.NotifyEvent
+func (_e *NotifyDBRepository_Expecter) AddNotifyEvent(ctx interface{}, event interface{}) *NotifyDBRepository_AddNotifyEvent_Call {
+	return &NotifyDBRepository_AddNotifyEvent_Call{Call: _e.mock.On("AddNotifyEvent", ctx, event)}
+}
+
+func (_c *NotifyDBRepository_AddNotifyEvent_Call) Run(run func(ctx context.Context, event entity.NotifyEvent)) *NotifyDBRepository_AddNotifyEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.NotifyEvent))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_AddNotifyEvent_Call) Return(_a0 error) *NotifyDBRepository_AddNotifyEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotifyDBRepository_AddNotifyEvent_Call) RunAndReturn(run func(context.Context, entity.NotifyEvent) error) *NotifyDBRepository_AddNotifyEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimNotify provides a mock function with given fields: ctx, notifyID
+func (_m *NotifyDBRepository) ClaimNotify(ctx context.Context, notifyID string) (entity.Notify, bool, error) {
+	ret := _m.Called(ctx, notifyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimNotify")
+	}
+
+	var r0 entity.Notify
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.Notify, bool, error)); ok {
+		return rf(ctx, notifyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.Notify); ok {
+		r0 = rf(ctx, notifyID)
+	} else {
+		r0 = ret.Get(0).(entity.Notify)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, notifyID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, notifyID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NotifyDBRepository_ClaimNotify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimNotify'
+type NotifyDBRepository_ClaimNotify_Call struct {
+	*mock.Call
+}
+
+// ClaimNotify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+func (_e *NotifyDBRepository_Expecter) ClaimNotify(ctx interface{}, notifyID interface{}) *NotifyDBRepository_ClaimNotify_Call {
+	return &NotifyDBRepository_ClaimNotify_Call{Call: _e.mock.On("ClaimNotify", ctx, notifyID)}
+}
+
+func (_c *NotifyDBRepository_ClaimNotify_Call) Run(run func(ctx context.Context, notifyID string)) *NotifyDBRepository_ClaimNotify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_ClaimNotify_Call) Return(_a0 entity.Notify, _a1 bool, _a2 error) *NotifyDBRepository_ClaimNotify_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *NotifyDBRepository_ClaimNotify_Call) RunAndReturn(run func(context.Context, string) (entity.Notify, bool, error)) *NotifyDBRepository_ClaimNotify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimSending provides a mock function with given fields: ctx, notifyID
+func (_m *NotifyDBRepository) ClaimSending(ctx context.Context, notifyID string) (entity.Notify, bool, error) {
+	ret := _m.Called(ctx, notifyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimSending")
+	}
+
+	var r0 entity.Notify
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.Notify, bool, error)); ok {
+		return rf(ctx, notifyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.Notify); ok {
+		r0 = rf(ctx, notifyID)
+	} else {
+		r0 = ret.Get(0).(entity.Notify)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, notifyID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, notifyID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NotifyDBRepository_ClaimSending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimSending'
+type NotifyDBRepository_ClaimSending_Call struct {
+	*mock.Call
+}
+
+// ClaimSending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+func (_e *NotifyDBRepository_Expecter) ClaimSending(ctx interface{}, notifyID interface{}) *NotifyDBRepository_ClaimSending_Call {
+	return &NotifyDBRepository_ClaimSending_Call{Call: _e.mock.On("ClaimSending", ctx, notifyID)}
+}
+
+func (_c *NotifyDBRepository_ClaimSending_Call) Run(run func(ctx context.Context, notifyID string)) *NotifyDBRepository_ClaimSending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_ClaimSending_Call) Return(_a0 entity.Notify, _a1 bool, _a2 error) *NotifyDBRepository_ClaimSending_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *NotifyDBRepository_ClaimSending_Call) RunAndReturn(run func(context.Context, string) (entity.Notify, bool, error)) *NotifyDBRepository_ClaimSending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountReadyNotifies provides a mock function with given fields: ctx, before
+func (_m *NotifyDBRepository) CountReadyNotifies(ctx context.Context, before time.Time) (int, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountReadyNotifies")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyDBRepository_CountReadyNotifies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountReadyNotifies'
+type NotifyDBRepository_CountReadyNotifies_Call struct {
+	*mock.Call
+}
+
+// CountReadyNotifies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *NotifyDBRepository_Expecter) CountReadyNotifies(ctx interface{}, before interface{}) *NotifyDBRepository_CountReadyNotifies_Call {
+	return &NotifyDBRepository_CountReadyNotifies_Call{Call: _e.mock.On("CountReadyNotifies", ctx, before)}
+}
+
+func (_c *NotifyDBRepository_CountReadyNotifies_Call) Run(run func(ctx context.Context, before time.Time)) *NotifyDBRepository_CountReadyNotifies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_CountReadyNotifies_Call) Return(_a0 int, _a1 error) *NotifyDBRepository_CountReadyNotifies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyDBRepository_CountReadyNotifies_Call) RunAndReturn(run func(context.Context, time.Time) (int, error)) *NotifyDBRepository_CountReadyNotifies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateDeliveries provides a mock function with given fields: ctx, notifyID, deliveries
+func (_m *NotifyDBRepository) CreateDeliveries(ctx context.Context, notifyID string, deliveries []entity.Delivery) ([]entity.Delivery, error) {
+	ret := _m.Called(ctx, notifyID, deliveries)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDeliveries")
+	}
+
+	var r0 []entity.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []entity.Delivery) ([]entity.Delivery, error)); ok {
+		return rf(ctx, notifyID, deliveries)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []entity.Delivery) []entity.Delivery); ok {
+		r0 = rf(ctx, notifyID, deliveries)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []entity.Delivery) error); ok {
+		r1 = rf(ctx, notifyID, deliveries)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyDBRepository_CreateDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDeliveries'
+type NotifyDBRepository_CreateDeliveries_Call struct {
+	*mock.Call
+}
+
+// CreateDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+//   - deliveries []entity.Delivery
+func (_e *NotifyDBRepository_Expecter) CreateDeliveries(ctx interface{}, notifyID interface{}, deliveries interface{}) *NotifyDBRepository_CreateDeliveries_Call {
+	return &NotifyDBRepository_CreateDeliveries_Call{Call: _e.mock.On("CreateDeliveries", ctx, notifyID, deliveries)}
+}
+
+func (_c *NotifyDBRepository_CreateDeliveries_Call) Run(run func(ctx context.Context, notifyID string, deliveries []entity.Delivery)) *NotifyDBRepository_CreateDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]entity.Delivery))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_CreateDeliveries_Call) Return(_a0 []entity.Delivery, _a1 error) *NotifyDBRepository_CreateDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyDBRepository_CreateDeliveries_Call) RunAndReturn(run func(context.Context, string, []entity.Delivery) ([]entity.Delivery, error)) *NotifyDBRepository_CreateDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateNotify provides a mock function with given fields: ctx, notify
 func (_m *NotifyDBRepository) CreateNotify(ctx context.Context, notify entity.Notify) (entity.Notify, error) {
 	ret := _m.Called(ctx, notify)
@@ -127,86 +420,94 @@ func (_c *NotifyDBRepository_DeleteNotify_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
-// GetNotify provides a mock function with given fields: ctx, notifyID
-func (_m *NotifyDBRepository) GetNotify(ctx context.Context, notifyID string) (entity.Notify, error) {
-	ret := _m.Called(ctx, notifyID)
+// FailStuckNotify provides a mock function with given fields: ctx, notifyID, before
+func (_m *NotifyDBRepository) FailStuckNotify(ctx context.Context, notifyID string, before time.Time) (entity.Notify, bool, error) {
+	ret := _m.Called(ctx, notifyID, before)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNotify")
+		panic("no return value specified for FailStuckNotify")
 	}
 
 	var r0 entity.Notify
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.Notify, error)); ok {
-		return rf(ctx, notifyID)
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (entity.Notify, bool, error)); ok {
+		return rf(ctx, notifyID, before)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) entity.Notify); ok {
-		r0 = rf(ctx, notifyID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) entity.Notify); ok {
+		r0 = rf(ctx, notifyID, before)
 	} else {
 		r0 = ret.Get(0).(entity.Notify)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, notifyID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) bool); ok {
+		r1 = rf(ctx, notifyID, before)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(bool)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Time) error); ok {
+		r2 = rf(ctx, notifyID, before)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// NotifyDBRepository_GetNotify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotify'
-type NotifyDBRepository_GetNotify_Call struct {
+// NotifyDBRepository_FailStuckNotify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailStuckNotify'
+type NotifyDBRepository_FailStuckNotify_Call struct {
 	*mock.Call
 }
 
-// GetNotify is a helper method to define mock.On call
+// FailStuckNotify is a helper method to define mock.On call
 //   - ctx context.Context
 //   - notifyID string
-func (_e *NotifyDBRepository_Expecter) GetNotify(ctx interface{}, notifyID interface{}) *NotifyDBRepository_GetNotify_Call {
-	return &NotifyDBRepository_GetNotify_Call{Call: _e.mock.On("GetNotify", ctx, notifyID)}
+//   - before time.Time
+func (_e *NotifyDBRepository_Expecter) FailStuckNotify(ctx interface{}, notifyID interface{}, before interface{}) *NotifyDBRepository_FailStuckNotify_Call {
+	return &NotifyDBRepository_FailStuckNotify_Call{Call: _e.mock.On("FailStuckNotify", ctx, notifyID, before)}
 }
 
-func (_c *NotifyDBRepository_GetNotify_Call) Run(run func(ctx context.Context, notifyID string)) *NotifyDBRepository_GetNotify_Call {
+func (_c *NotifyDBRepository_FailStuckNotify_Call) Run(run func(ctx context.Context, notifyID string, before time.Time)) *NotifyDBRepository_FailStuckNotify_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
 	})
 	return _c
 }
 
-func (_c *NotifyDBRepository_GetNotify_Call) Return(_a0 entity.Notify, _a1 error) *NotifyDBRepository_GetNotify_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *NotifyDBRepository_FailStuckNotify_Call) Return(_a0 entity.Notify, _a1 bool, _a2 error) *NotifyDBRepository_FailStuckNotify_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *NotifyDBRepository_GetNotify_Call) RunAndReturn(run func(context.Context, string) (entity.Notify, error)) *NotifyDBRepository_GetNotify_Call {
+func (_c *NotifyDBRepository_FailStuckNotify_Call) RunAndReturn(run func(context.Context, string, time.Time) (entity.Notify, bool, error)) *NotifyDBRepository_FailStuckNotify_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetReadyNotifies provides a mock function with given fields: ctx
-func (_m *NotifyDBRepository) GetReadyNotifies(ctx context.Context) ([]entity.Notify, error) {
-	ret := _m.Called(ctx)
+// GetDeliveries provides a mock function with given fields: ctx, notifyID
+func (_m *NotifyDBRepository) GetDeliveries(ctx context.Context, notifyID string) ([]entity.Delivery, error) {
+	ret := _m.Called(ctx, notifyID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetReadyNotifies")
+		panic("no return value specified for GetDeliveries")
 	}
 
-	var r0 []entity.Notify
+	var r0 []entity.Delivery
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.Notify, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.Delivery, error)); ok {
+		return rf(ctx, notifyID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []entity.Notify); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.Delivery); ok {
+		r0 = rf(ctx, notifyID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entity.Notify)
+			r0 = ret.Get(0).([]entity.Delivery)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, notifyID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -214,54 +515,869 @@ func (_m *NotifyDBRepository) GetReadyNotifies(ctx context.Context) ([]entity.No
 	return r0, r1
 }
 
-// NotifyDBRepository_GetReadyNotifies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReadyNotifies'
-type NotifyDBRepository_GetReadyNotifies_Call struct {
+// NotifyDBRepository_GetDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeliveries'
+type NotifyDBRepository_GetDeliveries_Call struct {
 	*mock.Call
 }
 
-// GetReadyNotifies is a helper method to define mock.On call
+// GetDeliveries is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *NotifyDBRepository_Expecter) GetReadyNotifies(ctx interface{}) *NotifyDBRepository_GetReadyNotifies_Call {
-	return &NotifyDBRepository_GetReadyNotifies_Call{Call: _e.mock.On("GetReadyNotifies", ctx)}
+//   - notifyID string
+func (_e *NotifyDBRepository_Expecter) GetDeliveries(ctx interface{}, notifyID interface{}) *NotifyDBRepository_GetDeliveries_Call {
+	return &NotifyDBRepository_GetDeliveries_Call{Call: _e.mock.On("GetDeliveries", ctx, notifyID)}
 }
 
-func (_c *NotifyDBRepository_GetReadyNotifies_Call) Run(run func(ctx context.Context)) *NotifyDBRepository_GetReadyNotifies_Call {
+func (_c *NotifyDBRepository_GetDeliveries_Call) Run(run func(ctx context.Context, notifyID string)) *NotifyDBRepository_GetDeliveries_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *NotifyDBRepository_GetReadyNotifies_Call) Return(_a0 []entity.Notify, _a1 error) *NotifyDBRepository_GetReadyNotifies_Call {
+func (_c *NotifyDBRepository_GetDeliveries_Call) Return(_a0 []entity.Delivery, _a1 error) *NotifyDBRepository_GetDeliveries_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *NotifyDBRepository_GetReadyNotifies_Call) RunAndReturn(run func(context.Context) ([]entity.Notify, error)) *NotifyDBRepository_GetReadyNotifies_Call {
+func (_c *NotifyDBRepository_GetDeliveries_Call) RunAndReturn(run func(context.Context, string) ([]entity.Delivery, error)) *NotifyDBRepository_GetDeliveries_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateNotifyStatus provides a mock function with given fields: ctx, notifyID, status
-func (_m *NotifyDBRepository) UpdateNotifyStatus(ctx context.Context, notifyID string, status string) error {
-	ret := _m.Called(ctx, notifyID, status)
+// GetExpiredAttachments provides a mock function with given fields: ctx, before, limit
+func (_m *NotifyDBRepository) GetExpiredAttachments(ctx context.Context, before time.Time, limit int) ([]entity.Notify, error) {
+	ret := _m.Called(ctx, before, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateNotifyStatus")
+		panic("no return value specified for GetExpiredAttachments")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, notifyID, status)
+	var r0 []entity.Notify
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]entity.Notify, error)); ok {
+		return rf(ctx, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []entity.Notify); ok {
+		r0 = rf(ctx, before, limit)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Notify)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// NotifyDBRepository_UpdateNotifyStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotifyStatus'
-type NotifyDBRepository_UpdateNotifyStatus_Call struct {
+// NotifyDBRepository_GetExpiredAttachments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExpiredAttachments'
+type NotifyDBRepository_GetExpiredAttachments_Call struct {
+	*mock.Call
+}
+
+// GetExpiredAttachments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+//   - limit int
+func (_e *NotifyDBRepository_Expecter) GetExpiredAttachments(ctx interface{}, before interface{}, limit interface{}) *NotifyDBRepository_GetExpiredAttachments_Call {
+	return &NotifyDBRepository_GetExpiredAttachments_Call{Call: _e.mock.On("GetExpiredAttachments", ctx, before, limit)}
+}
+
+func (_c *NotifyDBRepository_GetExpiredAttachments_Call) Run(run func(ctx context.Context, before time.Time, limit int)) *NotifyDBRepository_GetExpiredAttachments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_GetExpiredAttachments_Call) Return(_a0 []entity.Notify, _a1 error) *NotifyDBRepository_GetExpiredAttachments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyDBRepository_GetExpiredAttachments_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]entity.Notify, error)) *NotifyDBRepository_GetExpiredAttachments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNextSendAt provides a mock function with given fields: ctx
+func (_m *NotifyDBRepository) GetNextSendAt(ctx context.Context) (time.Time, bool, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNextSendAt")
+	}
+
+	var r0 time.Time
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (time.Time, bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) time.Time); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) bool); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NotifyDBRepository_GetNextSendAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNextSendAt'
+type NotifyDBRepository_GetNextSendAt_Call struct {
+	*mock.Call
+}
+
+// GetNextSendAt is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *NotifyDBRepository_Expecter) GetNextSendAt(ctx interface{}) *NotifyDBRepository_GetNextSendAt_Call {
+	return &NotifyDBRepository_GetNextSendAt_Call{Call: _e.mock.On("GetNextSendAt", ctx)}
+}
+
+func (_c *NotifyDBRepository_GetNextSendAt_Call) Run(run func(ctx context.Context)) *NotifyDBRepository_GetNextSendAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_GetNextSendAt_Call) Return(_a0 time.Time, _a1 bool, _a2 error) *NotifyDBRepository_GetNextSendAt_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *NotifyDBRepository_GetNextSendAt_Call) RunAndReturn(run func(context.Context) (time.Time, bool, error)) *NotifyDBRepository_GetNextSendAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotify provides a mock function with given fields: ctx, notifyID
+func (_m *NotifyDBRepository) GetNotify(ctx context.Context, notifyID string) (entity.Notify, error) {
+	ret := _m.Called(ctx, notifyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotify")
+	}
+
+	var r0 entity.Notify
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.Notify, error)); ok {
+		return rf(ctx, notifyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.Notify); ok {
+		r0 = rf(ctx, notifyID)
+	} else {
+		r0 = ret.Get(0).(entity.Notify)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, notifyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyDBRepository_GetNotify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotify'
+type NotifyDBRepository_GetNotify_Call struct {
+	*mock.Call
+}
+
+// GetNotify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+func (_e *NotifyDBRepository_Expecter) GetNotify(ctx interface{}, notifyID interface{}) *NotifyDBRepository_GetNotify_Call {
+	return &NotifyDBRepository_GetNotify_Call{Call: _e.mock.On("GetNotify", ctx, notifyID)}
+}
+
+func (_c *NotifyDBRepository_GetNotify_Call) Run(run func(ctx context.Context, notifyID string)) *NotifyDBRepository_GetNotify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_GetNotify_Call) Return(_a0 entity.Notify, _a1 error) *NotifyDBRepository_GetNotify_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyDBRepository_GetNotify_Call) RunAndReturn(run func(context.Context, string) (entity.Notify, error)) *NotifyDBRepository_GetNotify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReadyNotifies provides a mock function with given fields: ctx, limit
+func (_m *NotifyDBRepository) GetReadyNotifies(ctx context.Context, limit int) ([]entity.Notify, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReadyNotifies")
+	}
+
+	var r0 []entity.Notify
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]entity.Notify, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entity.Notify); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Notify)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyDBRepository_GetReadyNotifies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReadyNotifies'
+type NotifyDBRepository_GetReadyNotifies_Call struct {
+	*mock.Call
+}
+
+// GetReadyNotifies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *NotifyDBRepository_Expecter) GetReadyNotifies(ctx interface{}, limit interface{}) *NotifyDBRepository_GetReadyNotifies_Call {
+	return &NotifyDBRepository_GetReadyNotifies_Call{Call: _e.mock.On("GetReadyNotifies", ctx, limit)}
+}
+
+func (_c *NotifyDBRepository_GetReadyNotifies_Call) Run(run func(ctx context.Context, limit int)) *NotifyDBRepository_GetReadyNotifies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_GetReadyNotifies_Call) Return(_a0 []entity.Notify, _a1 error) *NotifyDBRepository_GetReadyNotifies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyDBRepository_GetReadyNotifies_Call) RunAndReturn(run func(context.Context, int) ([]entity.Notify, error)) *NotifyDBRepository_GetReadyNotifies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRecipientList provides a mock function with given fields: ctx, listID
+func (_m *NotifyDBRepository) GetRecipientList(ctx context.Context, listID string) (entity.RecipientList, error) {
+	ret := _m.Called(ctx, listID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecipientList")
+	}
+
+	var r0 entity.RecipientList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.RecipientList, error)); ok {
+		return rf(ctx, listID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.RecipientList); ok {
+		r0 = rf(ctx, listID)
+	} else {
+		r0 = ret.Get(0).(entity.RecipientList)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, listID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyDBRepository_GetRecipientList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecipientList'
+type NotifyDBRepository_GetRecipientList_Call struct {
+	*mock.Call
+}
+
+// GetRecipientList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - listID string
+func (_e *NotifyDBRepository_Expecter) GetRecipientList(ctx interface{}, listID interface{}) *NotifyDBRepository_GetRecipientList_Call {
+	return &NotifyDBRepository_GetRecipientList_Call{Call: _e.mock.On("GetRecipientList", ctx, listID)}
+}
+
+func (_c *NotifyDBRepository_GetRecipientList_Call) Run(run func(ctx context.Context, listID string)) *NotifyDBRepository_GetRecipientList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_GetRecipientList_Call) Return(_a0 entity.RecipientList, _a1 error) *NotifyDBRepository_GetRecipientList_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyDBRepository_GetRecipientList_Call) RunAndReturn(run func(context.Context, string) (entity.RecipientList, error)) *NotifyDBRepository_GetRecipientList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetScheduledSendTimes provides a mock function with given fields: ctx
+func (_m *NotifyDBRepository) GetScheduledSendTimes(ctx context.Context) (map[string]time.Time, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetScheduledSendTimes")
+	}
+
+	var r0 map[string]time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[string]time.Time, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]time.Time); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyDBRepository_GetScheduledSendTimes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetScheduledSendTimes'
+type NotifyDBRepository_GetScheduledSendTimes_Call struct {
+	*mock.Call
+}
+
+// GetScheduledSendTimes is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *NotifyDBRepository_Expecter) GetScheduledSendTimes(ctx interface{}) *NotifyDBRepository_GetScheduledSendTimes_Call {
+	return &NotifyDBRepository_GetScheduledSendTimes_Call{Call: _e.mock.On("GetScheduledSendTimes", ctx)}
+}
+
+func (_c *NotifyDBRepository_GetScheduledSendTimes_Call) Run(run func(ctx context.Context)) *NotifyDBRepository_GetScheduledSendTimes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_GetScheduledSendTimes_Call) Return(_a0 map[string]time.Time, _a1 error) *NotifyDBRepository_GetScheduledSendTimes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyDBRepository_GetScheduledSendTimes_Call) RunAndReturn(run func(context.Context) (map[string]time.Time, error)) *NotifyDBRepository_GetScheduledSendTimes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStuckNotifies provides a mock function with given fields: ctx, before, limit
+func (_m *NotifyDBRepository) GetStuckNotifies(ctx context.Context, before time.Time, limit int) ([]entity.StuckNotify, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStuckNotifies")
+	}
+
+	var r0 []entity.StuckNotify
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]entity.StuckNotify, error)); ok {
+		return rf(ctx, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []entity.StuckNotify); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.StuckNotify)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyDBRepository_GetStuckNotifies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStuckNotifies'
+type NotifyDBRepository_GetStuckNotifies_Call struct {
+	*mock.Call
+}
+
+// GetStuckNotifies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+//   - limit int
+func (_e *NotifyDBRepository_Expecter) GetStuckNotifies(ctx interface{}, before interface{}, limit interface{}) *NotifyDBRepository_GetStuckNotifies_Call {
+	return &NotifyDBRepository_GetStuckNotifies_Call{Call: _e.mock.On("GetStuckNotifies", ctx, before, limit)}
+}
+
+func (_c *NotifyDBRepository_GetStuckNotifies_Call) Run(run func(ctx context.Context, before time.Time, limit int)) *NotifyDBRepository_GetStuckNotifies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_GetStuckNotifies_Call) Return(_a0 []entity.StuckNotify, _a1 error) *NotifyDBRepository_GetStuckNotifies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyDBRepository_GetStuckNotifies_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]entity.StuckNotify, error)) *NotifyDBRepository_GetStuckNotifies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUpcomingNotifies provides a mock function with given fields: ctx, until
+func (_m *NotifyDBRepository) GetUpcomingNotifies(ctx context.Context, until time.Time) ([]entity.Notify, error) {
+	ret := _m.Called(ctx, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUpcomingNotifies")
+	}
+
+	var r0 []entity.Notify
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]entity.Notify, error)); ok {
+		return rf(ctx, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []entity.Notify); ok {
+		r0 = rf(ctx, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Notify)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyDBRepository_GetUpcomingNotifies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUpcomingNotifies'
+type NotifyDBRepository_GetUpcomingNotifies_Call struct {
+	*mock.Call
+}
+
+// GetUpcomingNotifies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - until time.Time
+func (_e *NotifyDBRepository_Expecter) GetUpcomingNotifies(ctx interface{}, until interface{}) *NotifyDBRepository_GetUpcomingNotifies_Call {
+	return &NotifyDBRepository_GetUpcomingNotifies_Call{Call: _e.mock.On("GetUpcomingNotifies", ctx, until)}
+}
+
+func (_c *NotifyDBRepository_GetUpcomingNotifies_Call) Run(run func(ctx context.Context, until time.Time)) *NotifyDBRepository_GetUpcomingNotifies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_GetUpcomingNotifies_Call) Return(_a0 []entity.Notify, _a1 error) *NotifyDBRepository_GetUpcomingNotifies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyDBRepository_GetUpcomingNotifies_Call) RunAndReturn(run func(context.Context, time.Time) ([]entity.Notify, error)) *NotifyDBRepository_GetUpcomingNotifies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkAttachmentsPurged provides a mock function with given fields: ctx, notifyID
+func (_m *NotifyDBRepository) MarkAttachmentsPurged(ctx context.Context, notifyID string) error {
+	ret := _m.Called(ctx, notifyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkAttachmentsPurged")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, notifyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyDBRepository_MarkAttachmentsPurged_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkAttachmentsPurged'
+type NotifyDBRepository_MarkAttachmentsPurged_Call struct {
+	*mock.Call
+}
+
+// MarkAttachmentsPurged is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+func (_e *NotifyDBRepository_Expecter) MarkAttachmentsPurged(ctx interface{}, notifyID interface{}) *NotifyDBRepository_MarkAttachmentsPurged_Call {
+	return &NotifyDBRepository_MarkAttachmentsPurged_Call{Call: _e.mock.On("MarkAttachmentsPurged", ctx, notifyID)}
+}
+
+func (_c *NotifyDBRepository_MarkAttachmentsPurged_Call) Run(run func(ctx context.Context, notifyID string)) *NotifyDBRepository_MarkAttachmentsPurged_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_MarkAttachmentsPurged_Call) Return(_a0 error) *NotifyDBRepository_MarkAttachmentsPurged_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotifyDBRepository_MarkAttachmentsPurged_Call) RunAndReturn(run func(context.Context, string) error) *NotifyDBRepository_MarkAttachmentsPurged_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequeueStuckNotify provides a mock function with given fields: ctx, notifyID, before
+func (_m *NotifyDBRepository) RequeueStuckNotify(ctx context.Context, notifyID string, before time.Time) (bool, error) {
+	ret := _m.Called(ctx, notifyID, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequeueStuckNotify")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (bool, error)); ok {
+		return rf(ctx, notifyID, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) bool); ok {
+		r0 = rf(ctx, notifyID, before)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, notifyID, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyDBRepository_RequeueStuckNotify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequeueStuckNotify'
+type NotifyDBRepository_RequeueStuckNotify_Call struct {
+	*mock.Call
+}
+
+// RequeueStuckNotify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+//   - before time.Time
+func (_e *NotifyDBRepository_Expecter) RequeueStuckNotify(ctx interface{}, notifyID interface{}, before interface{}) *NotifyDBRepository_RequeueStuckNotify_Call {
+	return &NotifyDBRepository_RequeueStuckNotify_Call{Call: _e.mock.On("RequeueStuckNotify", ctx, notifyID, before)}
+}
+
+func (_c *NotifyDBRepository_RequeueStuckNotify_Call) Run(run func(ctx context.Context, notifyID string, before time.Time)) *NotifyDBRepository_RequeueStuckNotify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_RequeueStuckNotify_Call) Return(_a0 bool, _a1 error) *NotifyDBRepository_RequeueStuckNotify_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyDBRepository_RequeueStuckNotify_Call) RunAndReturn(run func(context.Context, string, time.Time) (bool, error)) *NotifyDBRepository_RequeueStuckNotify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RescheduleNotify provides a mock function with given fields: ctx, notifyID, sendAt
+func (_m *NotifyDBRepository) RescheduleNotify(ctx context.Context, notifyID string, sendAt time.Time) (entity.Notify, bool, error) {
+	ret := _m.Called(ctx, notifyID, sendAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RescheduleNotify")
+	}
+
+	var r0 entity.Notify
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (entity.Notify, bool, error)); ok {
+		return rf(ctx, notifyID, sendAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) entity.Notify); ok {
+		r0 = rf(ctx, notifyID, sendAt)
+	} else {
+		r0 = ret.Get(0).(entity.Notify)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) bool); ok {
+		r1 = rf(ctx, notifyID, sendAt)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Time) error); ok {
+		r2 = rf(ctx, notifyID, sendAt)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NotifyDBRepository_RescheduleNotify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RescheduleNotify'
+type NotifyDBRepository_RescheduleNotify_Call struct {
+	*mock.Call
+}
+
+// RescheduleNotify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+//   - sendAt time.Time
+func (_e *NotifyDBRepository_Expecter) RescheduleNotify(ctx interface{}, notifyID interface{}, sendAt interface{}) *NotifyDBRepository_RescheduleNotify_Call {
+	return &NotifyDBRepository_RescheduleNotify_Call{Call: _e.mock.On("RescheduleNotify", ctx, notifyID, sendAt)}
+}
+
+func (_c *NotifyDBRepository_RescheduleNotify_Call) Run(run func(ctx context.Context, notifyID string, sendAt time.Time)) *NotifyDBRepository_RescheduleNotify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_RescheduleNotify_Call) Return(_a0 entity.Notify, _a1 bool, _a2 error) *NotifyDBRepository_RescheduleNotify_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *NotifyDBRepository_RescheduleNotify_Call) RunAndReturn(run func(context.Context, string, time.Time) (entity.Notify, bool, error)) *NotifyDBRepository_RescheduleNotify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetDeliveries provides a mock function with given fields: ctx, notifyID
+func (_m *NotifyDBRepository) ResetDeliveries(ctx context.Context, notifyID string) error {
+	ret := _m.Called(ctx, notifyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetDeliveries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, notifyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyDBRepository_ResetDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetDeliveries'
+type NotifyDBRepository_ResetDeliveries_Call struct {
+	*mock.Call
+}
+
+// ResetDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+func (_e *NotifyDBRepository_Expecter) ResetDeliveries(ctx interface{}, notifyID interface{}) *NotifyDBRepository_ResetDeliveries_Call {
+	return &NotifyDBRepository_ResetDeliveries_Call{Call: _e.mock.On("ResetDeliveries", ctx, notifyID)}
+}
+
+func (_c *NotifyDBRepository_ResetDeliveries_Call) Run(run func(ctx context.Context, notifyID string)) *NotifyDBRepository_ResetDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_ResetDeliveries_Call) Return(_a0 error) *NotifyDBRepository_ResetDeliveries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotifyDBRepository_ResetDeliveries_Call) RunAndReturn(run func(context.Context, string) error) *NotifyDBRepository_ResetDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SkipNotify provides a mock function with given fields: ctx, notifyID
+func (_m *NotifyDBRepository) SkipNotify(ctx context.Context, notifyID string) (entity.Notify, bool, error) {
+	ret := _m.Called(ctx, notifyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SkipNotify")
+	}
+
+	var r0 entity.Notify
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.Notify, bool, error)); ok {
+		return rf(ctx, notifyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.Notify); ok {
+		r0 = rf(ctx, notifyID)
+	} else {
+		r0 = ret.Get(0).(entity.Notify)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, notifyID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, notifyID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NotifyDBRepository_SkipNotify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SkipNotify'
+type NotifyDBRepository_SkipNotify_Call struct {
+	*mock.Call
+}
+
+// SkipNotify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+func (_e *NotifyDBRepository_Expecter) SkipNotify(ctx interface{}, notifyID interface{}) *NotifyDBRepository_SkipNotify_Call {
+	return &NotifyDBRepository_SkipNotify_Call{Call: _e.mock.On("SkipNotify", ctx, notifyID)}
+}
+
+func (_c *NotifyDBRepository_SkipNotify_Call) Run(run func(ctx context.Context, notifyID string)) *NotifyDBRepository_SkipNotify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_SkipNotify_Call) Return(_a0 entity.Notify, _a1 bool, _a2 error) *NotifyDBRepository_SkipNotify_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *NotifyDBRepository_SkipNotify_Call) RunAndReturn(run func(context.Context, string) (entity.Notify, bool, error)) *NotifyDBRepository_SkipNotify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateDelivery provides a mock function with given fields: ctx, notifyID, email, status, lastError
+func (_m *NotifyDBRepository) UpdateDelivery(ctx context.Context, notifyID string, email string, status string, lastError string) error {
+	ret := _m.Called(ctx, notifyID, email, status, lastError)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, notifyID, email, status, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyDBRepository_UpdateDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateDelivery'
+type NotifyDBRepository_UpdateDelivery_Call struct {
+	*mock.Call
+}
+
+// UpdateDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+//   - email string
+//   - status string
+//   - lastError string
+func (_e *NotifyDBRepository_Expecter) UpdateDelivery(ctx interface{}, notifyID interface{}, email interface{}, status interface{}, lastError interface{}) *NotifyDBRepository_UpdateDelivery_Call {
+	return &NotifyDBRepository_UpdateDelivery_Call{Call: _e.mock.On("UpdateDelivery", ctx, notifyID, email, status, lastError)}
+}
+
+func (_c *NotifyDBRepository_UpdateDelivery_Call) Run(run func(ctx context.Context, notifyID string, email string, status string, lastError string)) *NotifyDBRepository_UpdateDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *NotifyDBRepository_UpdateDelivery_Call) Return(_a0 error) *NotifyDBRepository_UpdateDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotifyDBRepository_UpdateDelivery_Call) RunAndReturn(run func(context.Context, string, string, string, string) error) *NotifyDBRepository_UpdateDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateNotifyStatus provides a mock function with given fields: ctx, notifyID, status
+func (_m *NotifyDBRepository) UpdateNotifyStatus(ctx context.Context, notifyID string, status string) (entity.Notify, error) {
+	ret := _m.Called(ctx, notifyID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNotifyStatus")
+	}
+
+	var r0 entity.Notify
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (entity.Notify, error)); ok {
+		return rf(ctx, notifyID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) entity.Notify); ok {
+		r0 = rf(ctx, notifyID, status)
+	} else {
+		r0 = ret.Get(0).(entity.Notify)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, notifyID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyDBRepository_UpdateNotifyStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotifyStatus'
+type NotifyDBRepository_UpdateNotifyStatus_Call struct {
 	*mock.Call
 }
 
@@ -280,12 +1396,12 @@ func (_c *NotifyDBRepository_UpdateNotifyStatus_Call) Run(run func(ctx context.C
 	return _c
 }
 
-func (_c *NotifyDBRepository_UpdateNotifyStatus_Call) Return(_a0 error) *NotifyDBRepository_UpdateNotifyStatus_Call {
-	_c.Call.Return(_a0)
+func (_c *NotifyDBRepository_UpdateNotifyStatus_Call) Return(_a0 entity.Notify, _a1 error) *NotifyDBRepository_UpdateNotifyStatus_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *NotifyDBRepository_UpdateNotifyStatus_Call) RunAndReturn(run func(context.Context, string, string) error) *NotifyDBRepository_UpdateNotifyStatus_Call {
+func (_c *NotifyDBRepository_UpdateNotifyStatus_Call) RunAndReturn(run func(context.Context, string, string) (entity.Notify, error)) *NotifyDBRepository_UpdateNotifyStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
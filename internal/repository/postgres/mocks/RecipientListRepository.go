@@ -0,0 +1,314 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	entity "delayed-notifier/internal/entity"
+)
+
+// RecipientListRepository is an autogenerated mock type for the RecipientListRepository type
+type RecipientListRepository struct {
+	mock.Mock
+}
+
+type RecipientListRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RecipientListRepository) EXPECT() *RecipientListRepository_Expecter {
+	return &RecipientListRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateRecipientList provides a mock function with given fields: ctx, list
+func (_m *RecipientListRepository) CreateRecipientList(ctx context.Context, list entity.RecipientList) (entity.RecipientList, error) {
+	ret := _m.Called(ctx, list)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRecipientList")
+	}
+
+	var r0 entity.RecipientList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.RecipientList) (entity.RecipientList, error)); ok {
+		return rf(ctx, list)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.RecipientList) entity.RecipientList); ok {
+		r0 = rf(ctx, list)
+	} else {
+		r0 = ret.Get(0).(entity.RecipientList)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.RecipientList) error); ok {
+		r1 = rf(ctx, list)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecipientListRepository_CreateRecipientList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRecipientList'
+type RecipientListRepository_CreateRecipientList_Call struct {
+	*mock.Call
+}
+
+// CreateRecipientList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - list entity.RecipientList
+func (_e *RecipientListRepository_Expecter) CreateRecipientList(ctx interface{}, list interface{}) *RecipientListRepository_CreateRecipientList_Call {
+	return &RecipientListRepository_CreateRecipientList_Call{Call: _e.mock.On("CreateRecipientList", ctx, list)}
+}
+
+func (_c *RecipientListRepository_CreateRecipientList_Call) Run(run func(ctx context.Context, list entity.RecipientList)) *RecipientListRepository_CreateRecipientList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.RecipientList))
+	})
+	return _c
+}
+
+func (_c *RecipientListRepository_CreateRecipientList_Call) Return(_a0 entity.RecipientList, _a1 error) *RecipientListRepository_CreateRecipientList_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RecipientListRepository_CreateRecipientList_Call) RunAndReturn(run func(context.Context, entity.RecipientList) (entity.RecipientList, error)) *RecipientListRepository_CreateRecipientList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRecipientList provides a mock function with given fields: ctx, listID
+func (_m *RecipientListRepository) DeleteRecipientList(ctx context.Context, listID string) error {
+	ret := _m.Called(ctx, listID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRecipientList")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, listID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecipientListRepository_DeleteRecipientList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRecipientList'
+type RecipientListRepository_DeleteRecipientList_Call struct {
+	*mock.Call
+}
+
+// DeleteRecipientList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - listID string
+func (_e *RecipientListRepository_Expecter) DeleteRecipientList(ctx interface{}, listID interface{}) *RecipientListRepository_DeleteRecipientList_Call {
+	return &RecipientListRepository_DeleteRecipientList_Call{Call: _e.mock.On("DeleteRecipientList", ctx, listID)}
+}
+
+func (_c *RecipientListRepository_DeleteRecipientList_Call) Run(run func(ctx context.Context, listID string)) *RecipientListRepository_DeleteRecipientList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RecipientListRepository_DeleteRecipientList_Call) Return(_a0 error) *RecipientListRepository_DeleteRecipientList_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RecipientListRepository_DeleteRecipientList_Call) RunAndReturn(run func(context.Context, string) error) *RecipientListRepository_DeleteRecipientList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRecipientList provides a mock function with given fields: ctx, listID
+func (_m *RecipientListRepository) GetRecipientList(ctx context.Context, listID string) (entity.RecipientList, error) {
+	ret := _m.Called(ctx, listID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecipientList")
+	}
+
+	var r0 entity.RecipientList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.RecipientList, error)); ok {
+		return rf(ctx, listID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.RecipientList); ok {
+		r0 = rf(ctx, listID)
+	} else {
+		r0 = ret.Get(0).(entity.RecipientList)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, listID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecipientListRepository_GetRecipientList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecipientList'
+type RecipientListRepository_GetRecipientList_Call struct {
+	*mock.Call
+}
+
+// GetRecipientList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - listID string
+func (_e *RecipientListRepository_Expecter) GetRecipientList(ctx interface{}, listID interface{}) *RecipientListRepository_GetRecipientList_Call {
+	return &RecipientListRepository_GetRecipientList_Call{Call: _e.mock.On("GetRecipientList", ctx, listID)}
+}
+
+func (_c *RecipientListRepository_GetRecipientList_Call) Run(run func(ctx context.Context, listID string)) *RecipientListRepository_GetRecipientList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RecipientListRepository_GetRecipientList_Call) Return(_a0 entity.RecipientList, _a1 error) *RecipientListRepository_GetRecipientList_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RecipientListRepository_GetRecipientList_Call) RunAndReturn(run func(context.Context, string) (entity.RecipientList, error)) *RecipientListRepository_GetRecipientList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRecipientLists provides a mock function with given fields: ctx
+func (_m *RecipientListRepository) ListRecipientLists(ctx context.Context) ([]entity.RecipientList, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRecipientLists")
+	}
+
+	var r0 []entity.RecipientList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.RecipientList, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.RecipientList); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.RecipientList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecipientListRepository_ListRecipientLists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRecipientLists'
+type RecipientListRepository_ListRecipientLists_Call struct {
+	*mock.Call
+}
+
+// ListRecipientLists is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *RecipientListRepository_Expecter) ListRecipientLists(ctx interface{}) *RecipientListRepository_ListRecipientLists_Call {
+	return &RecipientListRepository_ListRecipientLists_Call{Call: _e.mock.On("ListRecipientLists", ctx)}
+}
+
+func (_c *RecipientListRepository_ListRecipientLists_Call) Run(run func(ctx context.Context)) *RecipientListRepository_ListRecipientLists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *RecipientListRepository_ListRecipientLists_Call) Return(_a0 []entity.RecipientList, _a1 error) *RecipientListRepository_ListRecipientLists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RecipientListRepository_ListRecipientLists_Call) RunAndReturn(run func(context.Context) ([]entity.RecipientList, error)) *RecipientListRepository_ListRecipientLists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRecipientList provides a mock function with given fields: ctx, list
+func (_m *RecipientListRepository) UpdateRecipientList(ctx context.Context, list entity.RecipientList) (entity.RecipientList, error) {
+	ret := _m.Called(ctx, list)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRecipientList")
+	}
+
+	var r0 entity.RecipientList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.RecipientList) (entity.RecipientList, error)); ok {
+		return rf(ctx, list)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.RecipientList) entity.RecipientList); ok {
+		r0 = rf(ctx, list)
+	} else {
+		r0 = ret.Get(0).(entity.RecipientList)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.RecipientList) error); ok {
+		r1 = rf(ctx, list)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecipientListRepository_UpdateRecipientList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRecipientList'
+type RecipientListRepository_UpdateRecipientList_Call struct {
+	*mock.Call
+}
+
+// UpdateRecipientList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - list entity.RecipientList
+func (_e *RecipientListRepository_Expecter) UpdateRecipientList(ctx interface{}, list interface{}) *RecipientListRepository_UpdateRecipientList_Call {
+	return &RecipientListRepository_UpdateRecipientList_Call{Call: _e.mock.On("UpdateRecipientList", ctx, list)}
+}
+
+func (_c *RecipientListRepository_UpdateRecipientList_Call) Run(run func(ctx context.Context, list entity.RecipientList)) *RecipientListRepository_UpdateRecipientList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.RecipientList))
+	})
+	return _c
+}
+
+func (_c *RecipientListRepository_UpdateRecipientList_Call) Return(_a0 entity.RecipientList, _a1 error) *RecipientListRepository_UpdateRecipientList_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RecipientListRepository_UpdateRecipientList_Call) RunAndReturn(run func(context.Context, entity.RecipientList) (entity.RecipientList, error)) *RecipientListRepository_UpdateRecipientList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRecipientListRepository creates a new instance of RecipientListRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRecipientListRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RecipientListRepository {
+	mock := &RecipientListRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,307 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	entity "delayed-notifier/internal/entity"
+)
+
+// SuppressionRepository is an autogenerated mock type for the SuppressionRepository type
+type SuppressionRepository struct {
+	mock.Mock
+}
+
+type SuppressionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SuppressionRepository) EXPECT() *SuppressionRepository_Expecter {
+	return &SuppressionRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddSuppression provides a mock function with given fields: ctx, suppression
+func (_m *SuppressionRepository) AddSuppression(ctx context.Context, suppression entity.Suppression) error {
+	ret := _m.Called(ctx, suppression)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSuppression")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Suppression) error); ok {
+		r0 = rf(ctx, suppression)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SuppressionRepository_AddSuppression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddSuppression'
+type SuppressionRepository_AddSuppression_Call struct {
+	*mock.Call
+}
+
+// AddSuppression is a helper method to define mock.On call
+//   - ctx context.Context
+//   - suppression entity.Suppression
+func (_e *SuppressionRepository_Expecter) AddSuppression(ctx interface{}, suppression interface{}) *SuppressionRepository_AddSuppression_Call {
+	return &SuppressionRepository_AddSuppression_Call{Call: _e.mock.On("AddSuppression", ctx, suppression)}
+}
+
+func (_c *SuppressionRepository_AddSuppression_Call) Run(run func(ctx context.Context, suppression entity.Suppression)) *SuppressionRepository_AddSuppression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.Suppression))
+	})
+	return _c
+}
+
+func (_c *SuppressionRepository_AddSuppression_Call) Return(_a0 error) *SuppressionRepository_AddSuppression_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SuppressionRepository_AddSuppression_Call) RunAndReturn(run func(context.Context, entity.Suppression) error) *SuppressionRepository_AddSuppression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSuppression provides a mock function with given fields: ctx, email
+func (_m *SuppressionRepository) DeleteSuppression(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSuppression")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SuppressionRepository_DeleteSuppression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSuppression'
+type SuppressionRepository_DeleteSuppression_Call struct {
+	*mock.Call
+}
+
+// DeleteSuppression is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *SuppressionRepository_Expecter) DeleteSuppression(ctx interface{}, email interface{}) *SuppressionRepository_DeleteSuppression_Call {
+	return &SuppressionRepository_DeleteSuppression_Call{Call: _e.mock.On("DeleteSuppression", ctx, email)}
+}
+
+func (_c *SuppressionRepository_DeleteSuppression_Call) Run(run func(ctx context.Context, email string)) *SuppressionRepository_DeleteSuppression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SuppressionRepository_DeleteSuppression_Call) Return(_a0 error) *SuppressionRepository_DeleteSuppression_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SuppressionRepository_DeleteSuppression_Call) RunAndReturn(run func(context.Context, string) error) *SuppressionRepository_DeleteSuppression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindSuppressions provides a mock function with given fields: ctx, emails
+func (_m *SuppressionRepository) FindSuppressions(ctx context.Context, emails []string) ([]entity.Suppression, error) {
+	ret := _m.Called(ctx, emails)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindSuppressions")
+	}
+
+	var r0 []entity.Suppression
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]entity.Suppression, error)); ok {
+		return rf(ctx, emails)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []entity.Suppression); ok {
+		r0 = rf(ctx, emails)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Suppression)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, emails)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SuppressionRepository_FindSuppressions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindSuppressions'
+type SuppressionRepository_FindSuppressions_Call struct {
+	*mock.Call
+}
+
+// FindSuppressions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - emails []string
+func (_e *SuppressionRepository_Expecter) FindSuppressions(ctx interface{}, emails interface{}) *SuppressionRepository_FindSuppressions_Call {
+	return &SuppressionRepository_FindSuppressions_Call{Call: _e.mock.On("FindSuppressions", ctx, emails)}
+}
+
+func (_c *SuppressionRepository_FindSuppressions_Call) Run(run func(ctx context.Context, emails []string)) *SuppressionRepository_FindSuppressions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *SuppressionRepository_FindSuppressions_Call) Return(_a0 []entity.Suppression, _a1 error) *SuppressionRepository_FindSuppressions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SuppressionRepository_FindSuppressions_Call) RunAndReturn(run func(context.Context, []string) ([]entity.Suppression, error)) *SuppressionRepository_FindSuppressions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSuppression provides a mock function with given fields: ctx, email
+func (_m *SuppressionRepository) GetSuppression(ctx context.Context, email string) (entity.Suppression, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSuppression")
+	}
+
+	var r0 entity.Suppression
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.Suppression, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.Suppression); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(entity.Suppression)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SuppressionRepository_GetSuppression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSuppression'
+type SuppressionRepository_GetSuppression_Call struct {
+	*mock.Call
+}
+
+// GetSuppression is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *SuppressionRepository_Expecter) GetSuppression(ctx interface{}, email interface{}) *SuppressionRepository_GetSuppression_Call {
+	return &SuppressionRepository_GetSuppression_Call{Call: _e.mock.On("GetSuppression", ctx, email)}
+}
+
+func (_c *SuppressionRepository_GetSuppression_Call) Run(run func(ctx context.Context, email string)) *SuppressionRepository_GetSuppression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SuppressionRepository_GetSuppression_Call) Return(_a0 entity.Suppression, _a1 error) *SuppressionRepository_GetSuppression_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SuppressionRepository_GetSuppression_Call) RunAndReturn(run func(context.Context, string) (entity.Suppression, error)) *SuppressionRepository_GetSuppression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSuppressions provides a mock function with given fields: ctx, filter
+func (_m *SuppressionRepository) ListSuppressions(ctx context.Context, filter entity.SuppressionFilter) ([]entity.Suppression, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSuppressions")
+	}
+
+	var r0 []entity.Suppression
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.SuppressionFilter) ([]entity.Suppression, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.SuppressionFilter) []entity.Suppression); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Suppression)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.SuppressionFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SuppressionRepository_ListSuppressions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSuppressions'
+type SuppressionRepository_ListSuppressions_Call struct {
+	*mock.Call
+}
+
+// ListSuppressions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter entity.SuppressionFilter
+func (_e *SuppressionRepository_Expecter) ListSuppressions(ctx interface{}, filter interface{}) *SuppressionRepository_ListSuppressions_Call {
+	return &SuppressionRepository_ListSuppressions_Call{Call: _e.mock.On("ListSuppressions", ctx, filter)}
+}
+
+func (_c *SuppressionRepository_ListSuppressions_Call) Run(run func(ctx context.Context, filter entity.SuppressionFilter)) *SuppressionRepository_ListSuppressions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.SuppressionFilter))
+	})
+	return _c
+}
+
+func (_c *SuppressionRepository_ListSuppressions_Call) Return(_a0 []entity.Suppression, _a1 error) *SuppressionRepository_ListSuppressions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SuppressionRepository_ListSuppressions_Call) RunAndReturn(run func(context.Context, entity.SuppressionFilter) ([]entity.Suppression, error)) *SuppressionRepository_ListSuppressions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSuppressionRepository creates a new instance of SuppressionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSuppressionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SuppressionRepository {
+	mock := &SuppressionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -11,6 +12,27 @@ import (
 	"delayed-notifier/internal/entity"
 )
 
+// notifyColumns — столбцы уведомления в порядке notifyFields
+const notifyColumns = `id, send_at, message, status, email, version, request_id, to_emails, cc_emails, bcc_emails, recipient_lists,
+	subject, html, reply_to, headers, attachments, sender`
+
+// returningNotify дописывается к UPDATE, чтобы сразу получить новую версию строки
+// для записи в кэш.
+const returningNotify = `RETURNING ` + notifyColumns
+
+var (
+	// sendableStatuses — статусы, из которых консьюмер может начать отправку
+	sendableStatuses = []string{entity.StatusQueued, entity.StatusFailed}
+	// inFlightStatuses — статусы уведомлений между постановкой в очередь и результатом отправки
+	inFlightStatuses = []string{entity.StatusQueued, entity.StatusSending}
+)
+
+// notifyFields возвращает адреса полей для Scan в порядке notifyColumns
+func notifyFields(n *entity.Notify) []any {
+	return []any{&n.ID, &n.SendAt, &n.Message, &n.Status, &n.Email, &n.Version, &n.RequestID, &n.To, &n.Cc, &n.Bcc, &n.RecipientLists,
+		&n.Subject, &n.HTML, &n.ReplyTo, &n.Headers, &n.Attachments, &n.Sender}
+}
+
 type NotifyDBRepository struct {
 	Pool *pgxpool.Pool
 }
@@ -21,36 +43,33 @@ func NewNotifyDBRepository(pool *pgxpool.Pool) *NotifyDBRepository {
 
 func (r *NotifyDBRepository) CreateNotify(ctx context.Context, notify entity.Notify) (entity.Notify, error) {
 	query := `
-		INSERT INTO notify (send_at, message, status, email)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id
+		INSERT INTO notify (send_at, message, status, email, request_id, to_emails, cc_emails, bcc_emails, recipient_lists,
+			subject, html, reply_to, headers, attachments, sender)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING id, version
 	`
 
-	var id string
-	err := r.Pool.QueryRow(ctx, query, notify.SendAt, notify.Message, notify.Status, notify.Email).Scan(&id)
+	err := r.Pool.QueryRow(ctx, query, notify.SendAt, notify.Message, notify.Status, notify.Email, notify.RequestID,
+		textArray(notify.To), textArray(notify.Cc), textArray(notify.Bcc), textArray(notify.RecipientLists),
+		notify.Subject, notify.HTML, textArray(notify.ReplyTo), headersJSON(notify.Headers), attachmentsJSON(notify.Attachments),
+		notify.Sender,
+	).Scan(&notify.ID, &notify.Version)
 	if err != nil {
 		return entity.Notify{}, fmt.Errorf("CreateNotify: %w", err)
 	}
 
-	notify.ID = id
 	return notify, nil
 }
 
 func (r *NotifyDBRepository) GetNotify(ctx context.Context, notifyID string) (entity.Notify, error) {
 	query := `
-		SELECT id, send_at, message, status, email
+		SELECT ` + notifyColumns + `
 		FROM notify
 		WHERE id = $1
 	`
 
 	var notify entity.Notify
-	err := r.Pool.QueryRow(ctx, query, notifyID).Scan(
-		&notify.ID,
-		&notify.SendAt,
-		&notify.Message,
-		&notify.Status,
-		&notify.Email,
-	)
+	err := r.Pool.QueryRow(ctx, query, notifyID).Scan(notifyFields(&notify)...)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entity.Notify{}, fmt.Errorf("GetNotify: %w", entity.ErrNotifyNotFound)
@@ -75,14 +94,16 @@ func (r *NotifyDBRepository) DeleteNotify(ctx context.Context, notifyID string)
 	return nil
 }
 
-func (r *NotifyDBRepository) GetReadyNotifies(ctx context.Context) ([]entity.Notify, error) {
+func (r *NotifyDBRepository) GetReadyNotifies(ctx context.Context, limit int) ([]entity.Notify, error) {
 	query := `
-		SELECT id, send_at, message, status, email
+		SELECT ` + notifyColumns + `
 		FROM notify
 		WHERE send_at <= NOW() AND status = $1
+		ORDER BY send_at
+		LIMIT $2
 	`
 
-	rows, err := r.Pool.Query(ctx, query, entity.StatusScheduled)
+	rows, err := r.Pool.Query(ctx, query, entity.StatusScheduled, limit)
 	if err != nil {
 		return nil, fmt.Errorf("GetReadyNotifies query: %w", err)
 	}
@@ -91,13 +112,7 @@ func (r *NotifyDBRepository) GetReadyNotifies(ctx context.Context) ([]entity.Not
 	var notifies []entity.Notify
 	for rows.Next() {
 		var notify entity.Notify
-		if err := rows.Scan(
-			&notify.ID,
-			&notify.SendAt,
-			&notify.Message,
-			&notify.Status,
-			&notify.Email,
-		); err != nil {
+		if err := rows.Scan(notifyFields(&notify)...); err != nil {
 			return nil, fmt.Errorf("GetReadyNotifies scan: %w", err)
 		}
 		notifies = append(notifies, notify)
@@ -110,21 +125,284 @@ func (r *NotifyDBRepository) GetReadyNotifies(ctx context.Context) ([]entity.Not
 	return notifies, nil
 }
 
-func (r *NotifyDBRepository) UpdateNotifyStatus(ctx context.Context, notifyID, status string) error {
+func (r *NotifyDBRepository) UpdateNotifyStatus(ctx context.Context, notifyID, status string) (entity.Notify, error) {
 	query := `
 		UPDATE notify
-		SET status = $1
+		SET status = $1,
+			queued_at = CASE WHEN $1 = 'queued' THEN NOW() ELSE queued_at END
 		WHERE id = $2
+		` + returningNotify
+
+	notify, ok, err := r.updateNotify(ctx, query, status, notifyID)
+	if err != nil {
+		return entity.Notify{}, fmt.Errorf("UpdateNotifyStatus: %w", err)
+	}
+	if !ok {
+		return entity.Notify{}, fmt.Errorf("UpdateNotifyStatus: no rows affected for ID=%s", notifyID)
+	}
+
+	return notify, nil
+}
+
+func (r *NotifyDBRepository) GetScheduledSendTimes(ctx context.Context) (map[string]time.Time, error) {
+	query := `
+		SELECT id, send_at
+		FROM notify
+		WHERE status = $1
+	`
+
+	rows, err := r.Pool.Query(ctx, query, entity.StatusScheduled)
+	if err != nil {
+		return nil, fmt.Errorf("GetScheduledSendTimes query: %w", err)
+	}
+	defer rows.Close()
+
+	sendTimes := make(map[string]time.Time)
+	for rows.Next() {
+		var (
+			id     string
+			sendAt time.Time
+		)
+		if err := rows.Scan(&id, &sendAt); err != nil {
+			return nil, fmt.Errorf("GetScheduledSendTimes scan: %w", err)
+		}
+		sendTimes[id] = sendAt
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("GetScheduledSendTimes iteration: %w", err)
+	}
+
+	return sendTimes, nil
+}
+
+func (r *NotifyDBRepository) GetNextSendAt(ctx context.Context) (time.Time, bool, error) {
+	query := `
+		SELECT min(send_at)
+		FROM notify
+		WHERE status = $1
+	`
+
+	var next *time.Time
+	if err := r.Pool.QueryRow(ctx, query, entity.StatusScheduled).Scan(&next); err != nil {
+		return time.Time{}, false, fmt.Errorf("GetNextSendAt: %w", err)
+	}
+	if next == nil {
+		return time.Time{}, false, nil
+	}
+
+	return *next, true, nil
+}
+
+func (r *NotifyDBRepository) GetUpcomingNotifies(ctx context.Context, until time.Time) ([]entity.Notify, error) {
+	query := `
+		SELECT ` + notifyColumns + `
+		FROM notify
+		WHERE send_at <= $1 AND status = $2
+	`
+
+	rows, err := r.Pool.Query(ctx, query, until, entity.StatusScheduled)
+	if err != nil {
+		return nil, fmt.Errorf("GetUpcomingNotifies query: %w", err)
+	}
+	defer rows.Close()
+
+	var notifies []entity.Notify
+	for rows.Next() {
+		var notify entity.Notify
+		if err := rows.Scan(notifyFields(&notify)...); err != nil {
+			return nil, fmt.Errorf("GetUpcomingNotifies scan: %w", err)
+		}
+		notifies = append(notifies, notify)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("GetUpcomingNotifies iteration: %w", err)
+	}
+
+	return notifies, nil
+}
+
+// ClaimNotify переводит уведомление из scheduled в queued, только если оно всё ещё
+// в статусе scheduled. Возвращает false, если его уже забрал кто-то другой.
+func (r *NotifyDBRepository) ClaimNotify(ctx context.Context, notifyID string) (entity.Notify, bool, error) {
+	query := `
+		UPDATE notify
+		SET status = $1, queued_at = NOW()
+		WHERE id = $2 AND status = $3
+		` + returningNotify
+
+	notify, ok, err := r.updateNotify(ctx, query, entity.StatusQueued, notifyID, entity.StatusScheduled)
+	if err != nil {
+		return entity.Notify{}, false, fmt.Errorf("ClaimNotify: %w", err)
+	}
+	return notify, ok, nil
+}
+
+// ClaimSending переводит уведомление в sending перед отправкой писем, только если
+// оно в статусе queued или failed (повтор со ступени ретраев). Возвращает false,
+// если уведомление уже отправляется другой копией сообщения или уже обработано.
+func (r *NotifyDBRepository) ClaimSending(ctx context.Context, notifyID string) (entity.Notify, bool, error) {
+	query := `
+		UPDATE notify
+		SET status = $1, queued_at = NOW()
+		WHERE id = $2 AND status = ANY($3)
+		` + returningNotify
+
+	notify, ok, err := r.updateNotify(ctx, query, entity.StatusSending, notifyID, sendableStatuses)
+	if err != nil {
+		return entity.Notify{}, false, fmt.Errorf("ClaimSending: %w", err)
+	}
+	return notify, ok, nil
+}
+
+// CountReadyNotifies возвращает число запланированных уведомлений с send_at <= before.
+func (r *NotifyDBRepository) CountReadyNotifies(ctx context.Context, before time.Time) (int, error) {
+	query := `
+		SELECT count(*)
+		FROM notify
+		WHERE send_at <= $1 AND status = $2
+	`
+
+	var count int
+	if err := r.Pool.QueryRow(ctx, query, before, entity.StatusScheduled).Scan(&count); err != nil {
+		return 0, fmt.Errorf("CountReadyNotifies: %w", err)
+	}
+	return count, nil
+}
+
+// SkipNotify помечает уведомление пропущенным, только если оно всё ещё в статусе scheduled.
+func (r *NotifyDBRepository) SkipNotify(ctx context.Context, notifyID string) (entity.Notify, bool, error) {
+	query := `
+		UPDATE notify
+		SET status = $1
+		WHERE id = $2 AND status = $3
+		` + returningNotify
+
+	notify, ok, err := r.updateNotify(ctx, query, entity.StatusSkipped, notifyID, entity.StatusScheduled)
+	if err != nil {
+		return entity.Notify{}, false, fmt.Errorf("SkipNotify: %w", err)
+	}
+	return notify, ok, nil
+}
+
+// RescheduleNotify переносит запланированное уведомление на sendAt.
+func (r *NotifyDBRepository) RescheduleNotify(ctx context.Context, notifyID string, sendAt time.Time) (entity.Notify, bool, error) {
+	query := `
+		UPDATE notify
+		SET send_at = $1
+		WHERE id = $2 AND status = $3
+		` + returningNotify
+
+	notify, ok, err := r.updateNotify(ctx, query, sendAt, notifyID, entity.StatusScheduled)
+	if err != nil {
+		return entity.Notify{}, false, fmt.Errorf("RescheduleNotify: %w", err)
+	}
+	return notify, ok, nil
+}
+
+// GetStuckNotifies возвращает уведомления, находящиеся в статусе queued с момента before или дольше.
+func (r *NotifyDBRepository) GetStuckNotifies(ctx context.Context, before time.Time, limit int) ([]entity.StuckNotify, error) {
+	query := `
+		SELECT ` + notifyColumns + `, queued_at, reap_count
+		FROM notify
+		WHERE status = ANY($1) AND queued_at <= $2
+		ORDER BY queued_at
+		LIMIT $3
+	`
+
+	rows, err := r.Pool.Query(ctx, query, inFlightStatuses, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("GetStuckNotifies query: %w", err)
+	}
+	defer rows.Close()
+
+	var stuck []entity.StuckNotify
+	for rows.Next() {
+		var n entity.StuckNotify
+		if err := rows.Scan(append(notifyFields(&n.Notify), &n.QueuedAt, &n.ReapCount)...); err != nil {
+			return nil, fmt.Errorf("GetStuckNotifies scan: %w", err)
+		}
+		stuck = append(stuck, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("GetStuckNotifies iteration: %w", err)
+	}
+
+	return stuck, nil
+}
+
+// RequeueStuckNotify отмечает повторную постановку зависшего уведомления в очередь.
+// Условие на queued_at не даёт двум воркерам поднять одно уведомление дважды.
+// Уведомление, зависшее в sending (консьюмер упал во время отправки), возвращается
+// в queued, чтобы новую копию сообщения можно было захватить.
+func (r *NotifyDBRepository) RequeueStuckNotify(ctx context.Context, notifyID string, before time.Time) (bool, error) {
+	query := `
+		UPDATE notify
+		SET status = $2, queued_at = NOW(), reap_count = reap_count + 1
+		WHERE id = $1 AND status = ANY($3) AND queued_at <= $4
 	`
 
-	cmdTag, err := r.Pool.Exec(ctx, query, status, notifyID)
+	cmdTag, err := r.Pool.Exec(ctx, query, notifyID, entity.StatusQueued, inFlightStatuses, before)
 	if err != nil {
-		return fmt.Errorf("UpdateNotifyStatus: exec: %w", err)
+		return false, fmt.Errorf("RequeueStuckNotify: exec: %w", err)
 	}
 
-	if cmdTag.RowsAffected() == 0 {
-		return fmt.Errorf("UpdateNotifyStatus: no rows affected for ID=%s", notifyID)
+	return cmdTag.RowsAffected() == 1, nil
+}
+
+func (r *NotifyDBRepository) FailStuckNotify(ctx context.Context, notifyID string, before time.Time) (entity.Notify, bool, error) {
+	query := `
+		UPDATE notify
+		SET status = $1
+		WHERE id = $2 AND status = ANY($3) AND queued_at <= $4
+		` + returningNotify
+
+	notify, ok, err := r.updateNotify(ctx, query, entity.StatusFailed, notifyID, inFlightStatuses, before)
+	if err != nil {
+		return entity.Notify{}, false, fmt.Errorf("FailStuckNotify: %w", err)
 	}
+	return notify, ok, nil
+}
 
+func (r *NotifyDBRepository) AddNotifyEvent(ctx context.Context, event entity.NotifyEvent) error {
+	query := `
+		INSERT INTO notify_events (notify_id, action, details)
+		VALUES ($1, $2, $3)
+	`
+
+	if _, err := r.Pool.Exec(ctx, query, event.NotifyID, event.Action, event.Details); err != nil {
+		return fmt.Errorf("AddNotifyEvent: %w", err)
+	}
 	return nil
 }
+
+// updateNotify выполняет UPDATE ... RETURNING и возвращает false, если строка не подошла под условие.
+func (r *NotifyDBRepository) updateNotify(ctx context.Context, query string, args ...any) (entity.Notify, bool, error) {
+	var notify entity.Notify
+	err := r.Pool.QueryRow(ctx, query, args...).Scan(notifyFields(&notify)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Notify{}, false, nil
+		}
+		return entity.Notify{}, false, err
+	}
+	return notify, true, nil
+}
+
+// headersJSON не даёт записать JSON null в headers NOT NULL
+func headersJSON(headers map[string]string) map[string]string {
+	if headers == nil {
+		return map[string]string{}
+	}
+	return headers
+}
+
+// attachmentsJSON не даёт записать JSON null в attachments NOT NULL
+func attachmentsJSON(attachments []entity.Attachment) []entity.Attachment {
+	if attachments == nil {
+		return []entity.Attachment{}
+	}
+	return attachments
+}
@@ -4,16 +4,20 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"delayed-notifier/internal/config"
+	"delayed-notifier/internal/secret"
 )
 
 type DB struct {
 	Pool *pgxpool.Pool
 }
 
-func NewDbConnection(config *config.Config) (*DB, error) {
+// NewDbConnection создаёт пул; пароль берётся из password при каждом новом соединении,
+// поэтому после ротации секрета новые соединения используют новый пароль.
+func NewDbConnection(config *config.Config, password *secret.Value) (*DB, error) {
 	poolCfg := config.Pool
 	cfg, err := pgxpool.ParseConfig(config.Database.DSN())
 	if err != nil {
@@ -24,6 +28,10 @@ func NewDbConnection(config *config.Config) (*DB, error) {
 	cfg.MinConns = int32(poolCfg.MinConns)
 	cfg.MaxConnLifetime = poolCfg.MaxLifeTime
 	cfg.MaxConnIdleTime = poolCfg.MaxIdleTime
+	cfg.BeforeConnect = func(_ context.Context, cc *pgx.ConnConfig) error {
+		cc.Password = password.Get()
+		return nil
+	}
 
 	pool, err := pgxpool.NewWithConfig(context.Background(), cfg)
 	if err != nil {
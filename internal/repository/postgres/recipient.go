@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"delayed-notifier/internal/entity"
+)
+
+const recipientListColumns = `id, name, members, created_at, updated_at`
+
+func (r *NotifyDBRepository) CreateRecipientList(ctx context.Context, list entity.RecipientList) (entity.RecipientList, error) {
+	query := `
+		INSERT INTO recipient_lists (name, members)
+		VALUES ($1, $2)
+		RETURNING ` + recipientListColumns
+
+	created, err := scanRecipientList(r.Pool.QueryRow(ctx, query, list.Name, textArray(list.Members)))
+	if err != nil {
+		return entity.RecipientList{}, fmt.Errorf("CreateRecipientList: %w", err)
+	}
+	return created, nil
+}
+
+func (r *NotifyDBRepository) GetRecipientList(ctx context.Context, listID string) (entity.RecipientList, error) {
+	// Некорректный UUID не найдётся, а не вызовет ошибку приведения типа
+	query := `
+		SELECT ` + recipientListColumns + `
+		FROM recipient_lists
+		WHERE id::text = $1
+	`
+
+	list, err := scanRecipientList(r.Pool.QueryRow(ctx, query, listID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.RecipientList{}, fmt.Errorf("GetRecipientList: %w", entity.ErrRecipientListNotFound)
+		}
+		return entity.RecipientList{}, fmt.Errorf("GetRecipientList: %w", err)
+	}
+	return list, nil
+}
+
+func (r *NotifyDBRepository) ListRecipientLists(ctx context.Context) ([]entity.RecipientList, error) {
+	query := `
+		SELECT ` + recipientListColumns + `
+		FROM recipient_lists
+		ORDER BY name, id
+	`
+
+	rows, err := r.Pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("ListRecipientLists query: %w", err)
+	}
+	defer rows.Close()
+
+	lists := []entity.RecipientList{}
+	for rows.Next() {
+		list, err := scanRecipientList(rows)
+		if err != nil {
+			return nil, fmt.Errorf("ListRecipientLists scan: %w", err)
+		}
+		lists = append(lists, list)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ListRecipientLists iteration: %w", err)
+	}
+
+	return lists, nil
+}
+
+func (r *NotifyDBRepository) UpdateRecipientList(ctx context.Context, list entity.RecipientList) (entity.RecipientList, error) {
+	query := `
+		UPDATE recipient_lists
+		SET name = $1, members = $2, updated_at = NOW()
+		WHERE id::text = $3
+		RETURNING ` + recipientListColumns
+
+	updated, err := scanRecipientList(r.Pool.QueryRow(ctx, query, list.Name, textArray(list.Members), list.ID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.RecipientList{}, fmt.Errorf("UpdateRecipientList: %w", entity.ErrRecipientListNotFound)
+		}
+		return entity.RecipientList{}, fmt.Errorf("UpdateRecipientList: %w", err)
+	}
+	return updated, nil
+}
+
+func (r *NotifyDBRepository) DeleteRecipientList(ctx context.Context, listID string) error {
+	query := `
+		DELETE FROM recipient_lists
+		WHERE id::text = $1
+	`
+
+	cmdTag, err := r.Pool.Exec(ctx, query, listID)
+	if err != nil {
+		return fmt.Errorf("DeleteRecipientList: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("DeleteRecipientList: %w", entity.ErrRecipientListNotFound)
+	}
+	return nil
+}
+
+// CreateDeliveries добавляет доставки, которых ещё нет. Если два воркера раскрывают
+// уведомление одновременно, обе вставки сходятся к одному набору строк.
+func (r *NotifyDBRepository) CreateDeliveries(ctx context.Context, notifyID string, deliveries []entity.Delivery) ([]entity.Delivery, error) {
+	query := `
+		INSERT INTO notify_deliveries (notify_id, email, kind, list_id, status, position)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (notify_id, lower(email)) DO NOTHING
+	`
+
+	batch := &pgx.Batch{}
+	for i, d := range deliveries {
+		batch.Queue(query, notifyID, d.Email, d.Kind, d.ListID, entity.DeliveryPending, i)
+	}
+	if err := r.Pool.SendBatch(ctx, batch).Close(); err != nil {
+		return nil, fmt.Errorf("CreateDeliveries: %w", err)
+	}
+
+	created, err := r.GetDeliveries(ctx, notifyID)
+	if err != nil {
+		return nil, fmt.Errorf("CreateDeliveries: %w", err)
+	}
+	return created, nil
+}
+
+func (r *NotifyDBRepository) GetDeliveries(ctx context.Context, notifyID string) ([]entity.Delivery, error) {
+	query := `
+		SELECT notify_id, email, kind, list_id, status, attempts, last_error, updated_at
+		FROM notify_deliveries
+		WHERE notify_id = $1
+		ORDER BY position
+	`
+
+	rows, err := r.Pool.Query(ctx, query, notifyID)
+	if err != nil {
+		return nil, fmt.Errorf("GetDeliveries query: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []entity.Delivery{}
+	for rows.Next() {
+		var d entity.Delivery
+		if err := rows.Scan(&d.NotifyID, &d.Email, &d.Kind, &d.ListID, &d.Status, &d.Attempts, &d.LastError, &d.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("GetDeliveries scan: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("GetDeliveries iteration: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *NotifyDBRepository) UpdateDelivery(ctx context.Context, notifyID, email, status, lastError string) error {
+	query := `
+		UPDATE notify_deliveries
+		SET status = $1, last_error = $2, attempts = attempts + 1, updated_at = NOW()
+		WHERE notify_id = $3 AND lower(email) = lower($4)
+	`
+
+	if _, err := r.Pool.Exec(ctx, query, status, lastError, notifyID, email); err != nil {
+		return fmt.Errorf("UpdateDelivery: %w", err)
+	}
+	return nil
+}
+
+func (r *NotifyDBRepository) ResetDeliveries(ctx context.Context, notifyID string) error {
+	query := `
+		UPDATE notify_deliveries
+		SET status = $1, updated_at = NOW()
+		WHERE notify_id = $2
+	`
+
+	if _, err := r.Pool.Exec(ctx, query, entity.DeliveryPending, notifyID); err != nil {
+		return fmt.Errorf("ResetDeliveries: %w", err)
+	}
+	return nil
+}
+
+func scanRecipientList(row pgx.Row) (entity.RecipientList, error) {
+	var list entity.RecipientList
+	err := row.Scan(&list.ID, &list.Name, &list.Members, &list.CreatedAt, &list.UpdatedAt)
+	return list, err
+}
+
+// textArray заменяет nil пустым срезом: pgx передаёт nil как NULL, а столбцы NOT NULL
+func textArray(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
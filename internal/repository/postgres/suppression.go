@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"delayed-notifier/internal/entity"
+)
+
+const suppressionColumns = `email, reason, code, details, notify_id, created_at`
+
+func (r *NotifyDBRepository) FindSuppressions(ctx context.Context, emails []string) ([]entity.Suppression, error) {
+	lower := make([]string, len(emails))
+	for i, email := range emails {
+		lower[i] = strings.ToLower(email)
+	}
+	query := `
+		SELECT ` + suppressionColumns + `
+		FROM suppressions
+		WHERE email = ANY($1)
+		ORDER BY email
+	`
+
+	suppressions, err := r.querySuppressions(ctx, query, lower)
+	if err != nil {
+		return nil, fmt.Errorf("FindSuppressions: %w", err)
+	}
+	return suppressions, nil
+}
+
+// AddSuppression не перезаписывает существующую запись: в ней остаётся первый отказ.
+func (r *NotifyDBRepository) AddSuppression(ctx context.Context, suppression entity.Suppression) error {
+	query := `
+		INSERT INTO suppressions (email, reason, code, details, notify_id)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (email) DO NOTHING
+	`
+
+	_, err := r.Pool.Exec(ctx, query, strings.ToLower(suppression.Email), suppression.Reason,
+		suppression.Code, suppression.Details, suppression.NotifyID)
+	if err != nil {
+		return fmt.Errorf("AddSuppression: %w", err)
+	}
+	return nil
+}
+
+// ListSuppressions возвращает записи, начиная с самых новых.
+func (r *NotifyDBRepository) ListSuppressions(ctx context.Context, filter entity.SuppressionFilter) ([]entity.Suppression, error) {
+	args := []any{strings.ToLower(filter.Email)}
+	query := `
+		SELECT ` + suppressionColumns + `
+		FROM suppressions
+		WHERE strpos(email, $1) > 0
+		ORDER BY created_at DESC, email`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += "\n\t\tLIMIT $2"
+	}
+
+	suppressions, err := r.querySuppressions(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ListSuppressions: %w", err)
+	}
+	return suppressions, nil
+}
+
+func (r *NotifyDBRepository) GetSuppression(ctx context.Context, email string) (entity.Suppression, error) {
+	query := `
+		SELECT ` + suppressionColumns + `
+		FROM suppressions
+		WHERE email = $1
+	`
+
+	suppression, err := scanSuppression(r.Pool.QueryRow(ctx, query, strings.ToLower(email)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Suppression{}, fmt.Errorf("GetSuppression: %w", entity.ErrSuppressionNotFound)
+		}
+		return entity.Suppression{}, fmt.Errorf("GetSuppression: %w", err)
+	}
+	return suppression, nil
+}
+
+func (r *NotifyDBRepository) DeleteSuppression(ctx context.Context, email string) error {
+	query := `
+		DELETE FROM suppressions
+		WHERE email = $1
+	`
+
+	cmdTag, err := r.Pool.Exec(ctx, query, strings.ToLower(email))
+	if err != nil {
+		return fmt.Errorf("DeleteSuppression: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("DeleteSuppression: %w", entity.ErrSuppressionNotFound)
+	}
+	return nil
+}
+
+func (r *NotifyDBRepository) querySuppressions(ctx context.Context, query string, args ...any) ([]entity.Suppression, error) {
+	rows, err := r.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+	defer rows.Close()
+
+	suppressions := []entity.Suppression{}
+	for rows.Next() {
+		suppression, err := scanSuppression(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		suppressions = append(suppressions, suppression)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration: %w", err)
+	}
+
+	return suppressions, nil
+}
+
+func scanSuppression(row pgx.Row) (entity.Suppression, error) {
+	var s entity.Suppression
+	err := row.Scan(&s.Email, &s.Reason, &s.Code, &s.Details, &s.NotifyID, &s.CreatedAt)
+	return s, err
+}
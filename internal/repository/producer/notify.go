@@ -9,6 +9,7 @@ import (
 	"github.com/segmentio/kafka-go"
 
 	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/requestid"
 )
 
 type NotifyProducer struct {
@@ -34,14 +35,18 @@ func NewNotifyProducer(brokerURL, topic string, logger *slog.Logger) *NotifyProd
 func (p *NotifyProducer) Send(ctx context.Context, notify entity.Notify) error {
 	msg, err := json.Marshal(notify)
 	if err != nil {
-		p.logger.Error("failed to marshal notify", slog.Any("error", err))
+		p.logger.ErrorContext(ctx, "failed to marshal notify", slog.Any("error", err))
 		return err
 	}
 
-	return p.writer.WriteMessages(ctx, kafka.Message{
+	message := kafka.Message{
 		Key:   []byte(notify.ID),
 		Value: msg,
-	})
+	}
+	if id := requestid.FromContext(ctx); id != "" {
+		message.Headers = append(message.Headers, kafka.Header{Key: requestid.KafkaHeader, Value: []byte(id)})
+	}
+	return p.writer.WriteMessages(ctx, message)
 }
 
 func (p *NotifyProducer) Close() error {
@@ -4,7 +4,6 @@ package mocks
 
 import (
 	context "context"
-	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 
@@ -128,17 +127,64 @@ func (_c *NotifyCacheRepository_GetNotify_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
-// SetNotify provides a mock function with given fields: ctx, notify, ttl
-func (_m *NotifyCacheRepository) SetNotify(ctx context.Context, notify entity.Notify, ttl time.Duration) error {
-	ret := _m.Called(ctx, notify, ttl)
+// SetMissing provides a mock function with given fields: ctx, notifyID
+func (_m *NotifyCacheRepository) SetMissing(ctx context.Context, notifyID string) error {
+	ret := _m.Called(ctx, notifyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMissing")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, notifyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyCacheRepository_SetMissing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMissing'
+type NotifyCacheRepository_SetMissing_Call struct {
+	*mock.Call
+}
+
+// SetMissing is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+func (_e *NotifyCacheRepository_Expecter) SetMissing(ctx interface{}, notifyID interface{}) *NotifyCacheRepository_SetMissing_Call {
+	return &NotifyCacheRepository_SetMissing_Call{Call: _e.mock.On("SetMissing", ctx, notifyID)}
+}
+
+func (_c *NotifyCacheRepository_SetMissing_Call) Run(run func(ctx context.Context, notifyID string)) *NotifyCacheRepository_SetMissing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotifyCacheRepository_SetMissing_Call) Return(_a0 error) *NotifyCacheRepository_SetMissing_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotifyCacheRepository_SetMissing_Call) RunAndReturn(run func(context.Context, string) error) *NotifyCacheRepository_SetMissing_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetNotify provides a mock function with given fields: ctx, notify
+func (_m *NotifyCacheRepository) SetNotify(ctx context.Context, notify entity.Notify) error {
+	ret := _m.Called(ctx, notify)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SetNotify")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, entity.Notify, time.Duration) error); ok {
-		r0 = rf(ctx, notify, ttl)
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Notify) error); ok {
+		r0 = rf(ctx, notify)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -154,14 +200,13 @@ type NotifyCacheRepository_SetNotify_Call struct {
 // SetNotify is a helper method to define mock.On call
 //   - ctx context.Context
 //   - notify entity.Notify
-//   - ttl time.Duration
-func (_e *NotifyCacheRepository_Expecter) SetNotify(ctx interface{}, notify interface{}, ttl interface{}) *NotifyCacheRepository_SetNotify_Call {
-	return &NotifyCacheRepository_SetNotify_Call{Call: _e.mock.On("SetNotify", ctx, notify, ttl)}
+func (_e *NotifyCacheRepository_Expecter) SetNotify(ctx interface{}, notify interface{}) *NotifyCacheRepository_SetNotify_Call {
+	return &NotifyCacheRepository_SetNotify_Call{Call: _e.mock.On("SetNotify", ctx, notify)}
 }
 
-func (_c *NotifyCacheRepository_SetNotify_Call) Run(run func(ctx context.Context, notify entity.Notify, ttl time.Duration)) *NotifyCacheRepository_SetNotify_Call {
+func (_c *NotifyCacheRepository_SetNotify_Call) Run(run func(ctx context.Context, notify entity.Notify)) *NotifyCacheRepository_SetNotify_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(entity.Notify), args[2].(time.Duration))
+		run(args[0].(context.Context), args[1].(entity.Notify))
 	})
 	return _c
 }
@@ -171,7 +216,7 @@ func (_c *NotifyCacheRepository_SetNotify_Call) Return(_a0 error) *NotifyCacheRe
 	return _c
 }
 
-func (_c *NotifyCacheRepository_SetNotify_Call) RunAndReturn(run func(context.Context, entity.Notify, time.Duration) error) *NotifyCacheRepository_SetNotify_Call {
+func (_c *NotifyCacheRepository_SetNotify_Call) RunAndReturn(run func(context.Context, entity.Notify) error) *NotifyCacheRepository_SetNotify_Call {
 	_c.Call.Return(run)
 	return _c
 }
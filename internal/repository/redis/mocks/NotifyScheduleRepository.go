@@ -0,0 +1,313 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NotifyScheduleRepository is an autogenerated mock type for the NotifyScheduleRepository type
+type NotifyScheduleRepository struct {
+	mock.Mock
+}
+
+type NotifyScheduleRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *NotifyScheduleRepository) EXPECT() *NotifyScheduleRepository_Expecter {
+	return &NotifyScheduleRepository_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function with given fields: ctx, notifyID, sendAt
+func (_m *NotifyScheduleRepository) Add(ctx context.Context, notifyID string, sendAt time.Time) error {
+	ret := _m.Called(ctx, notifyID, sendAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, notifyID, sendAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyScheduleRepository_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type NotifyScheduleRepository_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+//   - sendAt time.Time
+func (_e *NotifyScheduleRepository_Expecter) Add(ctx interface{}, notifyID interface{}, sendAt interface{}) *NotifyScheduleRepository_Add_Call {
+	return &NotifyScheduleRepository_Add_Call{Call: _e.mock.On("Add", ctx, notifyID, sendAt)}
+}
+
+func (_c *NotifyScheduleRepository_Add_Call) Run(run func(ctx context.Context, notifyID string, sendAt time.Time)) *NotifyScheduleRepository_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *NotifyScheduleRepository_Add_Call) Return(_a0 error) *NotifyScheduleRepository_Add_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotifyScheduleRepository_Add_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *NotifyScheduleRepository_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Members provides a mock function with given fields: ctx
+func (_m *NotifyScheduleRepository) Members(ctx context.Context) (map[string]time.Time, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Members")
+	}
+
+	var r0 map[string]time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[string]time.Time, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]time.Time); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyScheduleRepository_Members_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Members'
+type NotifyScheduleRepository_Members_Call struct {
+	*mock.Call
+}
+
+// Members is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *NotifyScheduleRepository_Expecter) Members(ctx interface{}) *NotifyScheduleRepository_Members_Call {
+	return &NotifyScheduleRepository_Members_Call{Call: _e.mock.On("Members", ctx)}
+}
+
+func (_c *NotifyScheduleRepository_Members_Call) Run(run func(ctx context.Context)) *NotifyScheduleRepository_Members_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *NotifyScheduleRepository_Members_Call) Return(_a0 map[string]time.Time, _a1 error) *NotifyScheduleRepository_Members_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyScheduleRepository_Members_Call) RunAndReturn(run func(context.Context) (map[string]time.Time, error)) *NotifyScheduleRepository_Members_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Next provides a mock function with given fields: ctx
+func (_m *NotifyScheduleRepository) Next(ctx context.Context) (time.Time, bool, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Next")
+	}
+
+	var r0 time.Time
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (time.Time, bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) time.Time); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) bool); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NotifyScheduleRepository_Next_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Next'
+type NotifyScheduleRepository_Next_Call struct {
+	*mock.Call
+}
+
+// Next is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *NotifyScheduleRepository_Expecter) Next(ctx interface{}) *NotifyScheduleRepository_Next_Call {
+	return &NotifyScheduleRepository_Next_Call{Call: _e.mock.On("Next", ctx)}
+}
+
+func (_c *NotifyScheduleRepository_Next_Call) Run(run func(ctx context.Context)) *NotifyScheduleRepository_Next_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *NotifyScheduleRepository_Next_Call) Return(_a0 time.Time, _a1 bool, _a2 error) *NotifyScheduleRepository_Next_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *NotifyScheduleRepository_Next_Call) RunAndReturn(run func(context.Context) (time.Time, bool, error)) *NotifyScheduleRepository_Next_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PopDue provides a mock function with given fields: ctx, now, limit
+func (_m *NotifyScheduleRepository) PopDue(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PopDue")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]string, error)); ok {
+		return rf(ctx, now, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []string); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyScheduleRepository_PopDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PopDue'
+type NotifyScheduleRepository_PopDue_Call struct {
+	*mock.Call
+}
+
+// PopDue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - limit int
+func (_e *NotifyScheduleRepository_Expecter) PopDue(ctx interface{}, now interface{}, limit interface{}) *NotifyScheduleRepository_PopDue_Call {
+	return &NotifyScheduleRepository_PopDue_Call{Call: _e.mock.On("PopDue", ctx, now, limit)}
+}
+
+func (_c *NotifyScheduleRepository_PopDue_Call) Run(run func(ctx context.Context, now time.Time, limit int)) *NotifyScheduleRepository_PopDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *NotifyScheduleRepository_PopDue_Call) Return(_a0 []string, _a1 error) *NotifyScheduleRepository_PopDue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyScheduleRepository_PopDue_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]string, error)) *NotifyScheduleRepository_PopDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remove provides a mock function with given fields: ctx, notifyID
+func (_m *NotifyScheduleRepository) Remove(ctx context.Context, notifyID string) error {
+	ret := _m.Called(ctx, notifyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, notifyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NotifyScheduleRepository_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
+type NotifyScheduleRepository_Remove_Call struct {
+	*mock.Call
+}
+
+// Remove is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+func (_e *NotifyScheduleRepository_Expecter) Remove(ctx interface{}, notifyID interface{}) *NotifyScheduleRepository_Remove_Call {
+	return &NotifyScheduleRepository_Remove_Call{Call: _e.mock.On("Remove", ctx, notifyID)}
+}
+
+func (_c *NotifyScheduleRepository_Remove_Call) Run(run func(ctx context.Context, notifyID string)) *NotifyScheduleRepository_Remove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotifyScheduleRepository_Remove_Call) Return(_a0 error) *NotifyScheduleRepository_Remove_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NotifyScheduleRepository_Remove_Call) RunAndReturn(run func(context.Context, string) error) *NotifyScheduleRepository_Remove_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewNotifyScheduleRepository creates a new instance of NotifyScheduleRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotifyScheduleRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NotifyScheduleRepository {
+	mock := &NotifyScheduleRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,44 +3,79 @@ package redis
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"time"
 
+	"github.com/redis/go-redis/v9"
+
+	"delayed-notifier/internal/config"
 	"delayed-notifier/internal/entity"
 )
 
+// setIfNewerScript записывает уведомление, только если в кэше нет записи с той же
+// или более новой версией. Так запоздавшая запись (например, чтение из БД, начатое
+// до смены статуса) не перетирает актуальное состояние.
+var setIfNewerScript = redis.NewScript(`
+local cur = redis.call('GET', KEYS[1])
+if cur then
+	local ok, decoded = pcall(cjson.decode, cur)
+	if ok and type(decoded) == 'table' and tonumber(decoded.version) and tonumber(decoded.version) >= tonumber(ARGV[2]) then
+		return 0
+	end
+end
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[3])
+return 1
+`)
+
+// missingMarker хранится вместо уведомления, которого нет в БД (negative caching)
+const missingMarker = `{"missing":true}`
+
 type NotifyRedisRepository struct {
 	client *RedisClient
+	cfg    config.CacheConfig
 	logger *slog.Logger
 }
 
-func NewNotifyRedisRepository(client *RedisClient, logger *slog.Logger) *NotifyRedisRepository {
+func NewNotifyRedisRepository(client *RedisClient, cfg config.CacheConfig, logger *slog.Logger) *NotifyRedisRepository {
 	return &NotifyRedisRepository{
 		client: client,
+		cfg:    cfg,
 		logger: logger,
 	}
 }
 
-func (r *NotifyRedisRepository) SetNotify(ctx context.Context, notify entity.Notify, ttl time.Duration) error {
+func (r *NotifyRedisRepository) SetNotify(ctx context.Context, notify entity.Notify) error {
 	data, err := json.Marshal(notify)
 	if err != nil {
 		r.logger.Error("failed to marshal notify", slog.Any("error", err))
 		return err
 	}
-	if err := r.client.Client.Set(ctx, notify.ID, data, ttl).Err(); err != nil {
+	written, err := setIfNewerScript.Run(ctx, r.client.Client, []string{r.key(notify.ID)},
+		data, notify.Version, r.ttl(notify.Status).Milliseconds()).Int()
+	if err != nil {
 		r.logger.Error("failed to set notify in redis", slog.Any("error", err))
 		return err
 	}
+	if written == 0 {
+		r.logger.Debug("stale notify not cached", slog.String("id", notify.ID), slog.Int64("version", notify.Version))
+		return nil
+	}
 	r.logger.Info("notify cached in redis", slog.String("id", notify.ID))
 	return nil
 }
 
 func (r *NotifyRedisRepository) GetNotify(ctx context.Context, id string) (entity.Notify, error) {
-	val, err := r.client.Client.Get(ctx, id).Result()
+	val, err := r.client.Client.Get(ctx, r.key(id)).Result()
 	if err != nil {
-		r.logger.Error("failed to get notify from redis", slog.Any("error", err))
+		if !errors.Is(err, redis.Nil) {
+			r.logger.Error("failed to get notify from redis", slog.Any("error", err))
+		}
 		return entity.Notify{}, err
 	}
+	if val == missingMarker {
+		return entity.Notify{}, entity.ErrNotifyNotFound
+	}
 	var notify entity.Notify
 	if err := json.Unmarshal([]byte(val), &notify); err != nil {
 		r.logger.Error("failed to unmarshal notify from redis", slog.Any("error", err))
@@ -49,11 +84,34 @@ func (r *NotifyRedisRepository) GetNotify(ctx context.Context, id string) (entit
 	return notify, nil
 }
 
+// SetMissing записывает отметку об отсутствии уведомления на NegativeTTL.
+// NX не даёт затереть настоящую запись, появившуюся в кэше раньше.
+func (r *NotifyRedisRepository) SetMissing(ctx context.Context, id string) error {
+	if err := r.client.Client.SetNX(ctx, r.key(id), missingMarker, r.cfg.NegativeTTL).Err(); err != nil {
+		r.logger.Error("failed to set missing notify in redis", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
 func (r *NotifyRedisRepository) DeleteNotify(ctx context.Context, id string) error {
-	if err := r.client.Client.Del(ctx, id).Err(); err != nil {
+	if err := r.client.Client.Del(ctx, r.key(id)).Err(); err != nil {
 		r.logger.Error("failed to delete notify from redis", slog.Any("error", err))
 		return err
 	}
 	r.logger.Info("notify deleted from redis", slog.String("id", id))
 	return nil
 }
+
+func (r *NotifyRedisRepository) key(id string) string {
+	return r.cfg.KeyPrefix + id
+}
+
+func (r *NotifyRedisRepository) ttl(status string) time.Duration {
+	switch status {
+	case entity.StatusSent, entity.StatusFailed, entity.StatusSkipped, entity.StatusCanceled:
+		return r.cfg.FinalTTL
+	default:
+		return r.cfg.TTL
+	}
+}
@@ -7,18 +7,22 @@ import (
 	"github.com/redis/go-redis/v9"
 
 	"delayed-notifier/internal/config"
+	"delayed-notifier/internal/secret"
 )
 
 type RedisClient struct {
 	Client *redis.Client
 }
 
-func NewRedisClient(config *config.Config) (*RedisClient, error) {
+// NewRedisClient создаёт клиент; пароль читается из password при установке соединения.
+func NewRedisClient(config *config.Config, password *secret.Value) (*RedisClient, error) {
 	redisCfg := config.Redis
 	client := redis.NewClient(&redis.Options{
-		Addr:     fmt.Sprintf("%s:%s", redisCfg.Host, redisCfg.Port),
-		Password: redisCfg.Password,
-		DB:       redisCfg.DB,
+		Addr: fmt.Sprintf("%s:%s", redisCfg.Host, redisCfg.Port),
+		CredentialsProvider: func() (string, string) {
+			return "", password.Get()
+		},
+		DB: redisCfg.DB,
 	})
 
 	if err := client.Ping(context.Background()).Err(); err != nil {
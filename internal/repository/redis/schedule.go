@@ -0,0 +1,85 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const scheduleKey = "notify:schedule"
+
+// popDueScript атомарно забирает из ZSET участников со score <= ARGV[1] (не больше ARGV[2] штук),
+// поэтому несколько воркеров не получат один и тот же ID.
+var popDueScript = redis.NewScript(`
+local ids = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+if #ids > 0 then
+	redis.call('ZREM', KEYS[1], unpack(ids))
+end
+return ids
+`)
+
+type NotifyScheduleRepository struct {
+	client *RedisClient
+}
+
+func NewNotifyScheduleRepository(client *RedisClient) *NotifyScheduleRepository {
+	return &NotifyScheduleRepository{client: client}
+}
+
+func (r *NotifyScheduleRepository) Add(ctx context.Context, notifyID string, sendAt time.Time) error {
+	err := r.client.Client.ZAdd(ctx, scheduleKey, redis.Z{
+		Score:  float64(sendAt.UnixMilli()),
+		Member: notifyID,
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("Add: %w", err)
+	}
+	return nil
+}
+
+func (r *NotifyScheduleRepository) Remove(ctx context.Context, notifyID string) error {
+	if err := r.client.Client.ZRem(ctx, scheduleKey, notifyID).Err(); err != nil {
+		return fmt.Errorf("Remove: %w", err)
+	}
+	return nil
+}
+
+func (r *NotifyScheduleRepository) PopDue(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	ids, err := popDueScript.Run(ctx, r.client.Client, []string{scheduleKey},
+		strconv.FormatInt(now.UnixMilli(), 10), limit).StringSlice()
+	if err != nil {
+		return nil, fmt.Errorf("PopDue: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *NotifyScheduleRepository) Members(ctx context.Context) (map[string]time.Time, error) {
+	zs, err := r.client.Client.ZRangeWithScores(ctx, scheduleKey, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("Members: %w", err)
+	}
+
+	members := make(map[string]time.Time, len(zs))
+	for _, z := range zs {
+		id, ok := z.Member.(string)
+		if !ok {
+			continue
+		}
+		members[id] = time.UnixMilli(int64(z.Score))
+	}
+	return members, nil
+}
+
+func (r *NotifyScheduleRepository) Next(ctx context.Context) (time.Time, bool, error) {
+	zs, err := r.client.Client.ZRangeWithScores(ctx, scheduleKey, 0, 0).Result()
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("Next: %w", err)
+	}
+	if len(zs) == 0 {
+		return time.Time{}, false, nil
+	}
+	return time.UnixMilli(int64(zs[0].Score)), true, nil
+}
@@ -0,0 +1,51 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+const (
+	// HTTPHeader — заголовок запроса и ответа API
+	HTTPHeader = "X-Request-ID"
+	// KafkaHeader — заголовок сообщения Kafka, по стилю остальных заголовков пайплайна
+	KafkaHeader = "x-request-id"
+
+	maxLength = 128
+)
+
+type ctxKey struct{}
+
+// New генерирует случайный идентификатор из 32 hex-символов.
+func New() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Valid проверяет идентификатор, пришедший извне: непустой, не длиннее 128 символов,
+// только печатные ASCII без пробелов, чтобы его можно было безопасно писать в логи и заголовки.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func NewContext(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
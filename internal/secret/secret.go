@@ -0,0 +1,114 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Value — секрет, который может смениться во время работы. Потребители читают его
+// через Get в момент установки нового соединения.
+type Value struct {
+	mu    sync.RWMutex
+	value string
+}
+
+func Static(value string) *Value {
+	return &Value{value: value}
+}
+
+func (v *Value) Get() string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.value
+}
+
+func (v *Value) set(value string) {
+	v.mu.Lock()
+	v.value = value
+	v.mu.Unlock()
+}
+
+// ReadFile читает секрет из файла, отбрасывая завершающий перевод строки.
+func ReadFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+type watched struct {
+	path    string
+	value   *Value
+	content []byte
+}
+
+// Watcher периодически перечитывает файлы секретов и обновляет их значения.
+// Опрос вместо inotify переживает подмену симлинков, которой Kubernetes обновляет secrets.
+type Watcher struct {
+	mu       sync.Mutex
+	files    []*watched
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+func NewWatcher(interval time.Duration, logger *slog.Logger) *Watcher {
+	return &Watcher{
+		interval: interval,
+		logger:   logger.With(slog.String("component", "secret watcher")),
+	}
+}
+
+// Watch возвращает значение секрета: из файла path, если он задан, иначе
+// неизменяемое value. Значение из файла обновляется после Start.
+func (w *Watcher) Watch(path, value string) *Value {
+	v := Static(value)
+	if path == "" {
+		return v
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.files = append(w.files, &watched{path: path, value: v, content: []byte(value)})
+	return v
+}
+
+func (w *Watcher) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("secret watcher stopped")
+			return
+		case <-ticker.C:
+			w.reload()
+		}
+	}
+}
+
+func (w *Watcher) reload() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, f := range w.files {
+		value, err := ReadFile(f.path)
+		if err != nil {
+			// Оставляем прежнее значение: файл мог на мгновение пропасть при подмене
+			w.logger.Error("failed to read secret file", slog.String("path", f.path), slog.Any("error", err))
+			continue
+		}
+		if bytes.Equal(f.content, []byte(value)) {
+			continue
+		}
+		f.content = []byte(value)
+		f.value.set(value)
+		w.logger.Info("secret reloaded", slog.String("path", f.path))
+	}
+}
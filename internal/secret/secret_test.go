@@ -0,0 +1,46 @@
+package secret
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher(t *testing.T) {
+	logg := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("static without file", func(t *testing.T) {
+		w := NewWatcher(0, logg)
+		assert.Equal(t, "plain", w.Watch("", "plain").Get())
+		assert.Empty(t, w.files)
+	})
+
+	t.Run("reloads rotated file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "password")
+		require.NoError(t, os.WriteFile(path, []byte("old\n"), 0o600))
+		initial, err := ReadFile(path)
+		require.NoError(t, err)
+
+		w := NewWatcher(0, logg)
+		v := w.Watch(path, initial)
+		assert.Equal(t, "old", v.Get())
+
+		require.NoError(t, os.WriteFile(path, []byte("new\n"), 0o600))
+		w.reload()
+		assert.Equal(t, "new", v.Get())
+	})
+
+	t.Run("keeps value when file missing", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "password")
+
+		w := NewWatcher(0, logg)
+		v := w.Watch(path, "current")
+		w.reload()
+		assert.Equal(t, "current", v.Get())
+	})
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"delayed-notifier/internal/entity"
+)
+
+type NotifyAdminRepository interface {
+	ListNotifies(ctx context.Context, filter entity.NotifyFilter) ([]entity.Notify, error)
+	GetNotifyDetails(ctx context.Context, notifyID string) (entity.NotifyDetails, error)
+	CancelNotify(ctx context.Context, notifyID string) (entity.Notify, bool, error)
+	ScheduleNotify(ctx context.Context, notifyID string, sendAt time.Time) (entity.Notify, bool, error)
+	GetNotifyStats(ctx context.Context, now time.Time) (entity.NotifyStats, error)
+}
+
+// AdminService — операции поддержки над уведомлениями для notifyctl и admin API.
+// Каждое изменение пишется в историю уведомления и в аудит-лог.
+type AdminService struct {
+	admin    NotifyAdminRepository
+	db       NotifyDBRepository
+	cache    NotifyCacheRepository
+	producer NotifyProducer
+	schedule NotifyScheduleRepository
+	logger   *slog.Logger
+}
+
+type AdminOption func(*AdminService)
+
+// WithAdminScheduleRepository сразу отражает отмену и перенос в расписании Redis,
+// не дожидаясь ReconcileSchedule.
+func WithAdminScheduleRepository(schedule NotifyScheduleRepository) AdminOption {
+	return func(s *AdminService) {
+		s.schedule = schedule
+	}
+}
+
+func NewAdminService(admin NotifyAdminRepository, db NotifyDBRepository, cache NotifyCacheRepository, producer NotifyProducer, logger *slog.Logger, opts ...AdminOption) *AdminService {
+	s := &AdminService{admin: admin, db: db, cache: cache, producer: producer, logger: logger}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *AdminService) ListNotifies(ctx context.Context, filter entity.NotifyFilter) ([]entity.Notify, error) {
+	notifies, err := s.admin.ListNotifies(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("ListNotifies: %w", err)
+	}
+	return notifies, nil
+}
+
+func (s *AdminService) GetNotifyDetails(ctx context.Context, notifyID string) (entity.NotifyDetails, error) {
+	details, err := s.admin.GetNotifyDetails(ctx, notifyID)
+	if err != nil {
+		return entity.NotifyDetails{}, fmt.Errorf("GetNotifyDetails: %w", err)
+	}
+	return details, nil
+}
+
+func (s *AdminService) GetNotifyStats(ctx context.Context) (entity.NotifyStats, error) {
+	stats, err := s.admin.GetNotifyStats(ctx, time.Now())
+	if err != nil {
+		return entity.NotifyStats{}, fmt.Errorf("GetNotifyStats: %w", err)
+	}
+	return stats, nil
+}
+
+// CancelNotify отменяет запланированное уведомление.
+func (s *AdminService) CancelNotify(ctx context.Context, notifyID string, req entity.AdminActionRequest) (entity.AdminResult, error) {
+	notify, ok, err := s.admin.CancelNotify(ctx, notifyID)
+	if err != nil {
+		return entity.AdminResult{}, fmt.Errorf("CancelNotify: %w", err)
+	}
+	if !ok {
+		return s.skipped(ctx, notifyID, "notify is not scheduled")
+	}
+	s.unschedule(ctx, notify.ID)
+	return s.done(ctx, notify, entity.AdminActionCanceled, entity.EventCanceled, req.Actor), nil
+}
+
+// RescheduleNotify переносит уведомление на req.SendAt. Уведомление в статусе
+// failed, skipped или canceled снова становится запланированным.
+func (s *AdminService) RescheduleNotify(ctx context.Context, notifyID string, req entity.AdminActionRequest) (entity.AdminResult, error) {
+	if req.SendAt.IsZero() {
+		return entity.AdminResult{}, errors.New("RescheduleNotify: send_at is required")
+	}
+	notify, ok, err := s.admin.ScheduleNotify(ctx, notifyID, req.SendAt)
+	if err != nil {
+		return entity.AdminResult{}, fmt.Errorf("RescheduleNotify: %w", err)
+	}
+	if !ok {
+		return s.skipped(ctx, notifyID, "notify is queued or already sent")
+	}
+	s.reschedule(ctx, notify)
+	return s.done(ctx, notify, entity.AdminActionRescheduled, entity.EventRescheduled, req.Actor), nil
+}
+
+// ResendNotify ставит уведомление в очередь немедленно в любом статусе, в том числе
+// уже отправленное; письмо снова уходит всем получателям. Как и в планировщике, статус
+// меняется до отправки: если очередь недоступна, уведомление останется в queued и его
+// подберёт reaper.
+func (s *AdminService) ResendNotify(ctx context.Context, notifyID string, req entity.AdminActionRequest) (entity.AdminResult, error) {
+	notify, err := s.db.UpdateNotifyStatus(ctx, notifyID, entity.StatusQueued)
+	if err != nil {
+		if _, getErr := s.db.GetNotify(ctx, notifyID); errors.Is(getErr, entity.ErrNotifyNotFound) {
+			return entity.AdminResult{}, fmt.Errorf("ResendNotify: %w", entity.ErrNotifyNotFound)
+		}
+		return entity.AdminResult{}, fmt.Errorf("ResendNotify: %w", err)
+	}
+	if err := s.db.ResetDeliveries(ctx, notifyID); err != nil {
+		return entity.AdminResult{}, fmt.Errorf("ResendNotify: %w", err)
+	}
+	result := s.done(ctx, notify, entity.AdminActionResent, entity.EventResent, req.Actor)
+	if err := s.producer.Send(ctx, notify); err != nil {
+		s.logger.ErrorContext(ctx, "failed to send notify to queue", slog.String("notify_id", notifyID), slog.Any("error", err))
+		result.Action = entity.AdminActionFailed
+		result.Error = err.Error()
+	}
+	return result, nil
+}
+
+// RequeueFailed снова планирует на текущий момент уведомления в статусе failed,
+// отобранные фильтром; отправкой займётся планировщик.
+func (s *AdminService) RequeueFailed(ctx context.Context, req entity.RequeueRequest) ([]entity.AdminResult, error) {
+	filter := req.Filter
+	filter.Status = entity.StatusFailed
+	notifies, err := s.admin.ListNotifies(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("RequeueFailed: %w", err)
+	}
+
+	now := time.Now()
+	results := make([]entity.AdminResult, 0, len(notifies))
+	for _, n := range notifies {
+		if req.DryRun {
+			results = append(results, entity.AdminResult{NotifyID: n.ID, Action: entity.AdminActionWouldRequeue, Status: n.Status})
+			continue
+		}
+
+		notify, ok, err := s.admin.ScheduleNotify(ctx, n.ID, now)
+		switch {
+		case err != nil:
+			results = append(results, entity.AdminResult{NotifyID: n.ID, Action: entity.AdminActionFailed, Error: err.Error()})
+		case !ok:
+			// Статус успел измениться после выборки
+			results = append(results, entity.AdminResult{NotifyID: n.ID, Action: entity.AdminActionSkipped, Error: "notify is no longer failed"})
+		default:
+			s.reschedule(ctx, notify)
+			results = append(results, s.done(ctx, notify, entity.AdminActionRequeued, entity.EventRequeued, req.Actor))
+		}
+	}
+
+	s.logger.InfoContext(ctx, "admin requeue",
+		slog.String("actor", req.Actor),
+		slog.Bool("dry_run", req.DryRun),
+		slog.Int("count", len(results)),
+	)
+	return results, nil
+}
+
+// reschedule и unschedule обновляют расписание Redis; расхождение из-за ошибки
+// исправит ReconcileSchedule.
+func (s *AdminService) reschedule(ctx context.Context, notify entity.Notify) {
+	if s.schedule == nil {
+		return
+	}
+	if err := s.schedule.Add(ctx, notify.ID, notify.SendAt); err != nil {
+		s.logger.WarnContext(ctx, "failed to add notify to schedule", slog.String("notify_id", notify.ID), slog.Any("error", err))
+	}
+}
+
+func (s *AdminService) unschedule(ctx context.Context, notifyID string) {
+	if s.schedule == nil {
+		return
+	}
+	if err := s.schedule.Remove(ctx, notifyID); err != nil {
+		s.logger.WarnContext(ctx, "failed to remove notify from schedule", slog.String("notify_id", notifyID), slog.Any("error", err))
+	}
+}
+
+// done обновляет кэш, записывает событие в историю и аудит-лог.
+func (s *AdminService) done(ctx context.Context, notify entity.Notify, action, event, actor string) entity.AdminResult {
+	_ = s.cache.SetNotify(ctx, notify)
+	if err := s.db.AddNotifyEvent(ctx, entity.NotifyEvent{
+		NotifyID: notify.ID,
+		Action:   event,
+		Details:  "actor=" + actor,
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "failed to add notify event", slog.String("notify_id", notify.ID), slog.Any("error", err))
+	}
+	s.logger.InfoContext(ctx, "admin action",
+		slog.String("actor", actor),
+		slog.String("notify_id", notify.ID),
+		slog.String("action", action),
+		slog.String("status", notify.Status),
+	)
+	return entity.AdminResult{NotifyID: notify.ID, Action: action, Status: notify.Status}
+}
+
+// skipped отличает отсутствующее уведомление от уведомления в неподходящем статусе.
+func (s *AdminService) skipped(ctx context.Context, notifyID, reason string) (entity.AdminResult, error) {
+	notify, err := s.db.GetNotify(ctx, notifyID)
+	if err != nil {
+		return entity.AdminResult{}, err
+	}
+	return entity.AdminResult{NotifyID: notifyID, Action: entity.AdminActionSkipped, Status: notify.Status, Error: reason}, nil
+}
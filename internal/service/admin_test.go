@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/entity"
+	mock_db "delayed-notifier/internal/repository/postgres/mocks"
+	mock_producer "delayed-notifier/internal/repository/producer/mocks"
+	mock_cache "delayed-notifier/internal/repository/redis/mocks"
+)
+
+type adminMocks struct {
+	admin    *mock_db.NotifyAdminRepository
+	db       *mock_db.NotifyDBRepository
+	cache    *mock_cache.NotifyCacheRepository
+	producer *mock_producer.NotifyProducer
+}
+
+func setupTestAdminService(t *testing.T) (context.Context, adminMocks, *AdminService) {
+	t.Helper()
+
+	m := adminMocks{
+		admin:    mock_db.NewNotifyAdminRepository(t),
+		db:       mock_db.NewNotifyDBRepository(t),
+		cache:    mock_cache.NewNotifyCacheRepository(t),
+		producer: mock_producer.NewNotifyProducer(t),
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	return context.Background(), m, NewAdminService(m.admin, m.db, m.cache, m.producer, logger)
+}
+
+func actorEvent(notifyID, action string) entity.NotifyEvent {
+	return entity.NotifyEvent{NotifyID: notifyID, Action: action, Details: "actor=alice"}
+}
+
+func TestAdminCancelNotify(t *testing.T) {
+	t.Run("canceled", func(t *testing.T) {
+		ctx, m, s := setupTestAdminService(t)
+
+		canceled := entity.Notify{ID: "id1", Status: entity.StatusCanceled, Version: 2}
+		m.admin.On("CancelNotify", ctx, "id1").Return(canceled, true, nil).Once()
+		m.cache.On("SetNotify", ctx, canceled).Return(nil).Once()
+		m.db.On("AddNotifyEvent", ctx, actorEvent("id1", entity.EventCanceled)).Return(nil).Once()
+
+		result, err := s.CancelNotify(ctx, "id1", entity.AdminActionRequest{Actor: "alice"})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.AdminResult{NotifyID: "id1", Action: entity.AdminActionCanceled, Status: entity.StatusCanceled}, result)
+	})
+
+	t.Run("not scheduled", func(t *testing.T) {
+		ctx, m, s := setupTestAdminService(t)
+
+		m.admin.On("CancelNotify", ctx, "id1").Return(entity.Notify{}, false, nil).Once()
+		m.db.On("GetNotify", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusSent}, nil).Once()
+
+		result, err := s.CancelNotify(ctx, "id1", entity.AdminActionRequest{Actor: "alice"})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.AdminActionSkipped, result.Action)
+		assert.Equal(t, entity.StatusSent, result.Status)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctx, m, s := setupTestAdminService(t)
+
+		m.admin.On("CancelNotify", ctx, "id1").Return(entity.Notify{}, false, nil).Once()
+		m.db.On("GetNotify", ctx, "id1").Return(entity.Notify{}, entity.ErrNotifyNotFound).Once()
+
+		_, err := s.CancelNotify(ctx, "id1", entity.AdminActionRequest{})
+
+		assert.ErrorIs(t, err, entity.ErrNotifyNotFound)
+	})
+}
+
+func TestAdminRescheduleNotify(t *testing.T) {
+	t.Run("send_at required", func(t *testing.T) {
+		ctx, _, s := setupTestAdminService(t)
+
+		_, err := s.RescheduleNotify(ctx, "id1", entity.AdminActionRequest{})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("rescheduled", func(t *testing.T) {
+		ctx, m, s := setupTestAdminService(t)
+
+		sendAt := time.Now().Add(time.Hour)
+		scheduled := entity.Notify{ID: "id1", Status: entity.StatusScheduled, SendAt: sendAt, Version: 5}
+		m.admin.On("ScheduleNotify", ctx, "id1", sendAt).Return(scheduled, true, nil).Once()
+		m.cache.On("SetNotify", ctx, scheduled).Return(nil).Once()
+		m.db.On("AddNotifyEvent", ctx, actorEvent("id1", entity.EventRescheduled)).Return(nil).Once()
+
+		result, err := s.RescheduleNotify(ctx, "id1", entity.AdminActionRequest{SendAt: sendAt, Actor: "alice"})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.AdminActionRescheduled, result.Action)
+	})
+}
+
+func TestAdminResendNotify(t *testing.T) {
+	t.Run("queued before send", func(t *testing.T) {
+		ctx, m, s := setupTestAdminService(t)
+
+		queued := entity.Notify{ID: "id1", Status: entity.StatusQueued, Version: 4}
+		m.db.On("UpdateNotifyStatus", ctx, "id1", entity.StatusQueued).Return(queued, nil).Once()
+		m.db.On("ResetDeliveries", ctx, "id1").Return(nil).Once()
+		m.cache.On("SetNotify", ctx, queued).Return(nil).Once()
+		m.db.On("AddNotifyEvent", ctx, actorEvent("id1", entity.EventResent)).Return(nil).Once()
+		m.producer.On("Send", ctx, queued).Return(nil).Once()
+
+		result, err := s.ResendNotify(ctx, "id1", entity.AdminActionRequest{Actor: "alice"})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.AdminResult{NotifyID: "id1", Action: entity.AdminActionResent, Status: entity.StatusQueued}, result)
+	})
+
+	t.Run("producer error", func(t *testing.T) {
+		ctx, m, s := setupTestAdminService(t)
+
+		queued := entity.Notify{ID: "id1", Status: entity.StatusQueued}
+		m.db.On("UpdateNotifyStatus", ctx, "id1", entity.StatusQueued).Return(queued, nil).Once()
+		m.db.On("ResetDeliveries", ctx, "id1").Return(nil).Once()
+		m.cache.On("SetNotify", ctx, queued).Return(nil).Once()
+		m.db.On("AddNotifyEvent", ctx, mock.Anything).Return(nil).Once()
+		m.producer.On("Send", ctx, queued).Return(assert.AnError).Once()
+
+		result, err := s.ResendNotify(ctx, "id1", entity.AdminActionRequest{Actor: "alice"})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.AdminActionFailed, result.Action)
+		assert.Equal(t, assert.AnError.Error(), result.Error)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctx, m, s := setupTestAdminService(t)
+
+		m.db.On("UpdateNotifyStatus", ctx, "id1", entity.StatusQueued).Return(entity.Notify{}, assert.AnError).Once()
+		m.db.On("GetNotify", ctx, "id1").Return(entity.Notify{}, entity.ErrNotifyNotFound).Once()
+
+		_, err := s.ResendNotify(ctx, "id1", entity.AdminActionRequest{})
+
+		assert.ErrorIs(t, err, entity.ErrNotifyNotFound)
+	})
+}
+
+func TestAdminRequeueFailed(t *testing.T) {
+	t.Run("forces failed status", func(t *testing.T) {
+		ctx, m, s := setupTestAdminService(t)
+
+		filter := entity.NotifyFilter{Status: entity.StatusFailed, Error: "smtp"}
+		notifies := []entity.Notify{{ID: "id1", Status: entity.StatusFailed}, {ID: "id2", Status: entity.StatusFailed}}
+		m.admin.On("ListNotifies", ctx, filter).Return(notifies, nil).Once()
+
+		scheduled := entity.Notify{ID: "id1", Status: entity.StatusScheduled}
+		m.admin.On("ScheduleNotify", ctx, "id1", mock.AnythingOfType("time.Time")).Return(scheduled, true, nil).Once()
+		m.cache.On("SetNotify", ctx, scheduled).Return(nil).Once()
+		m.db.On("AddNotifyEvent", ctx, actorEvent("id1", entity.EventRequeued)).Return(nil).Once()
+		// id2 успели перезапустить до нас
+		m.admin.On("ScheduleNotify", ctx, "id2", mock.AnythingOfType("time.Time")).Return(entity.Notify{}, false, nil).Once()
+
+		results, err := s.RequeueFailed(ctx, entity.RequeueRequest{
+			Filter: entity.NotifyFilter{Status: entity.StatusSent, Error: "smtp"},
+			Actor:  "alice",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, entity.AdminActionRequeued, results[0].Action)
+		assert.Equal(t, entity.AdminActionSkipped, results[1].Action)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		ctx, m, s := setupTestAdminService(t)
+
+		m.admin.On("ListNotifies", ctx, entity.NotifyFilter{Status: entity.StatusFailed}).
+			Return([]entity.Notify{{ID: "id1", Status: entity.StatusFailed}}, nil).Once()
+
+		results, err := s.RequeueFailed(ctx, entity.RequeueRequest{DryRun: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, []entity.AdminResult{{NotifyID: "id1", Action: entity.AdminActionWouldRequeue, Status: entity.StatusFailed}}, results)
+	})
+}
+
+func TestAdminSchedule(t *testing.T) {
+	setup := func(t *testing.T) (context.Context, adminMocks, *mock_cache.NotifyScheduleRepository, *AdminService) {
+		t.Helper()
+		ctx, m, _ := setupTestAdminService(t)
+		schedule := mock_cache.NewNotifyScheduleRepository(t)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+		s := NewAdminService(m.admin, m.db, m.cache, m.producer, logger, WithAdminScheduleRepository(schedule))
+		m.cache.On("SetNotify", ctx, mock.Anything).Return(nil).Maybe()
+		m.db.On("AddNotifyEvent", ctx, mock.Anything).Return(nil).Maybe()
+		return ctx, m, schedule, s
+	}
+
+	t.Run("cancel removes from schedule", func(t *testing.T) {
+		ctx, m, schedule, s := setup(t)
+
+		m.admin.On("CancelNotify", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusCanceled}, true, nil).Once()
+		schedule.On("Remove", ctx, "id1").Return(nil).Once()
+
+		_, err := s.CancelNotify(ctx, "id1", entity.AdminActionRequest{Actor: "alice"})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("reschedule adds new send_at", func(t *testing.T) {
+		ctx, m, schedule, s := setup(t)
+
+		sendAt := time.Now().Add(time.Hour)
+		m.admin.On("ScheduleNotify", ctx, "id1", sendAt).Return(entity.Notify{ID: "id1", Status: entity.StatusScheduled, SendAt: sendAt}, true, nil).Once()
+		// Ошибку Redis исправит сверка, действие не проваливается
+		schedule.On("Add", ctx, "id1", sendAt).Return(assert.AnError).Once()
+
+		result, err := s.RescheduleNotify(ctx, "id1", entity.AdminActionRequest{SendAt: sendAt, Actor: "alice"})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.AdminActionRescheduled, result.Action)
+	})
+
+	t.Run("requeue adds to schedule", func(t *testing.T) {
+		ctx, m, schedule, s := setup(t)
+
+		now := time.Now()
+		m.admin.On("ListNotifies", ctx, entity.NotifyFilter{Status: entity.StatusFailed}).
+			Return([]entity.Notify{{ID: "id1", Status: entity.StatusFailed}}, nil).Once()
+		m.admin.On("ScheduleNotify", ctx, "id1", mock.AnythingOfType("time.Time")).
+			Return(entity.Notify{ID: "id1", Status: entity.StatusScheduled, SendAt: now}, true, nil).Once()
+		schedule.On("Add", ctx, "id1", now).Return(nil).Once()
+
+		_, err := s.RequeueFailed(ctx, entity.RequeueRequest{Actor: "alice"})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("skipped action keeps schedule", func(t *testing.T) {
+		ctx, m, _, s := setup(t)
+
+		m.admin.On("CancelNotify", ctx, "id1").Return(entity.Notify{}, false, nil).Once()
+		m.db.On("GetNotify", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusSent}, nil).Once()
+
+		result, err := s.CancelNotify(ctx, "id1", entity.AdminActionRequest{Actor: "alice"})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.AdminActionSkipped, result.Action)
+	})
+}
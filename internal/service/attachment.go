@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"delayed-notifier/internal/entity"
+)
+
+// AttachmentStore хранит содержимое вложений. Хранилище общее для API, которое
+// записывает файлы, и воркера, который их отправляет и удаляет.
+type AttachmentStore interface {
+	// Put сохраняет содержимое и возвращает его ID
+	Put(ctx context.Context, content []byte) (string, error)
+	// Get возвращает содержимое или entity.ErrAttachmentNotFound
+	Get(ctx context.Context, id string) ([]byte, error)
+	// Delete удаляет содержимое; отсутствие файла не ошибка
+	Delete(ctx context.Context, id string) error
+}
+
+// GetAttachment возвращает вложение уведомления вместе с содержимым.
+func (s *NotifyService) GetAttachment(ctx context.Context, notifyID, attachmentID string) (entity.Attachment, error) {
+	notify, err := s.GetNotify(ctx, notifyID)
+	if err != nil {
+		return entity.Attachment{}, err
+	}
+	i := slices.IndexFunc(notify.Attachments, func(a entity.Attachment) bool { return a.ID == attachmentID })
+	if i < 0 || s.attachments == nil {
+		return entity.Attachment{}, fmt.Errorf("GetAttachment: %w", entity.ErrAttachmentNotFound)
+	}
+
+	attachment := notify.Attachments[i]
+	attachment.Content, err = s.attachments.Get(ctx, attachmentID)
+	if err != nil {
+		return entity.Attachment{}, fmt.Errorf("GetAttachment: %w", err)
+	}
+	return attachment, nil
+}
+
+// PurgeAttachments удаляет содержимое вложений завершённых уведомлений, у которых
+// send_at старше срока хранения. Описания остаются, поэтому повторная отправка
+// такого уведомления упадёт с понятной ошибкой, а не уйдёт без файлов.
+func (s *NotifyService) PurgeAttachments(ctx context.Context) (int, error) {
+	if s.attachments == nil || s.attachmentPolicy.Retention <= 0 {
+		return 0, nil
+	}
+
+	before := time.Now().Add(-s.attachmentPolicy.Retention)
+	purged := 0
+	for {
+		notifies, err := s.db.GetExpiredAttachments(ctx, before, s.batchSize)
+		if err != nil {
+			return purged, fmt.Errorf("PurgeAttachments: %w", err)
+		}
+		for _, notify := range notifies {
+			s.deleteAttachments(ctx, notify.Attachments)
+			if err := s.db.MarkAttachmentsPurged(ctx, notify.ID); err != nil {
+				return purged, fmt.Errorf("PurgeAttachments: %w", err)
+			}
+			purged++
+		}
+		if len(notifies) < s.batchSize {
+			return purged, nil
+		}
+	}
+}
+
+// checkAttachments проверяет вложения по политике до того, как их сохранять.
+func (s *NotifyService) checkAttachments(attachments []entity.Attachment) entity.ValidationError {
+	if len(attachments) == 0 {
+		return nil
+	}
+	if s.attachments == nil {
+		return entity.ValidationError{{Field: "attachments", Message: "attachments are disabled"}}
+	}
+	return s.attachmentPolicy.Check(attachments)
+}
+
+// storeAttachments сохраняет содержимое и возвращает описания вложений без него.
+func (s *NotifyService) storeAttachments(ctx context.Context, attachments []entity.Attachment) ([]entity.Attachment, error) {
+	if len(attachments) == 0 {
+		return attachments, nil
+	}
+	stored := make([]entity.Attachment, 0, len(attachments))
+	for _, a := range attachments {
+		id, err := s.attachments.Put(ctx, a.Content)
+		if err != nil {
+			s.deleteAttachments(ctx, stored)
+			return nil, fmt.Errorf("store attachment %s: %w", a.Filename, err)
+		}
+		stored = append(stored, entity.Attachment{
+			ID:          id,
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Size:        int64(len(a.Content)),
+		})
+	}
+	return stored, nil
+}
+
+// loadAttachments читает содержимое вложений перед отправкой.
+func (s *NotifyService) loadAttachments(ctx context.Context, attachments []entity.Attachment) ([]entity.Attachment, error) {
+	if len(attachments) == 0 {
+		return attachments, nil
+	}
+	if s.attachments == nil {
+		return nil, errors.New("attachments are disabled")
+	}
+	loaded := slices.Clone(attachments)
+	for i := range loaded {
+		content, err := s.attachments.Get(ctx, loaded[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("load attachment %s: %w", loaded[i].Filename, err)
+		}
+		loaded[i].Content = content
+	}
+	return loaded, nil
+}
+
+func (s *NotifyService) deleteAttachments(ctx context.Context, attachments []entity.Attachment) {
+	if s.attachments == nil {
+		return
+	}
+	for _, a := range attachments {
+		if err := s.attachments.Delete(ctx, a.ID); err != nil {
+			s.logger.WarnContext(ctx, "failed to delete attachment", slog.String("attachment_id", a.ID), slog.Any("error", err))
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/entity"
+	mock_blob "delayed-notifier/internal/repository/blob/mocks"
+	mock_email "delayed-notifier/internal/repository/email/mocks"
+)
+
+var testAttachmentPolicy = entity.AttachmentPolicy{
+	MaxCount:     2,
+	MaxSize:      1 << 10,
+	MaxTotalSize: 1 << 11,
+	ContentTypes: []string{"text/csv", "application/pdf"},
+	Retention:    24 * time.Hour,
+}
+
+func TestCreateNotifyAttachments(t *testing.T) {
+	csv := entity.Attachment{Filename: "report.csv", ContentType: "text/csv", Content: []byte("a,b\n1,2\n")}
+
+	t.Run("stored without content", func(t *testing.T) {
+		ctx, db, cache, _, s := setupTestService(t)
+		store := mock_blob.NewAttachmentStore(t)
+		WithAttachments(store, testAttachmentPolicy)(s)
+
+		input := entity.Notify{Message: "report", Email: "a@example.com", Attachments: []entity.Attachment{csv}}
+		stored := input
+		stored.Attachments = []entity.Attachment{{ID: "f1", Filename: "report.csv", ContentType: "text/csv", Size: 8}}
+		created := stored
+		created.ID = "id1"
+
+		store.On("Put", ctx, csv.Content).Return("f1", nil).Once()
+		db.On("CreateNotify", ctx, stored).Return(created, nil).Once()
+		cache.On("SetNotify", ctx, created).Return(nil).Once()
+
+		result, err := s.CreateNotify(ctx, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, created, result)
+		assert.Equal(t, csv.Content, input.Attachments[0].Content, "input must not be modified")
+	})
+
+	t.Run("policy violations", func(t *testing.T) {
+		ctx, _, _, _, s := setupTestService(t)
+		WithAttachments(mock_blob.NewAttachmentStore(t), testAttachmentPolicy)(s)
+
+		disguised := entity.Attachment{Filename: "invoice.pdf", ContentType: "application/pdf", Content: []byte("<html><script>alert(1)</script></html>")}
+		exe := entity.Attachment{Filename: "tool.exe", ContentType: "application/x-msdownload", Content: []byte("MZ")}
+
+		_, err := s.CreateNotify(ctx, entity.Notify{Attachments: []entity.Attachment{disguised, exe}})
+
+		var verr entity.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Equal(t, entity.ValidationError{
+			{Field: "attachments.0.content", Message: "content does not match content type application/pdf"},
+			{Field: "attachments.1.content_type", Message: "content type application/x-msdownload is not allowed"},
+		}, verr)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ctx, _, _, _, s := setupTestService(t)
+
+		_, err := s.CreateNotify(ctx, entity.Notify{Attachments: []entity.Attachment{csv}})
+
+		var verr entity.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Equal(t, "attachments", verr[0].Field)
+	})
+
+	t.Run("db error removes stored content", func(t *testing.T) {
+		ctx, db, _, _, s := setupTestService(t)
+		store := mock_blob.NewAttachmentStore(t)
+		WithAttachments(store, testAttachmentPolicy)(s)
+
+		store.On("Put", ctx, csv.Content).Return("f1", nil).Once()
+		db.On("CreateNotify", ctx, mock.Anything).Return(entity.Notify{}, assert.AnError).Once()
+		store.On("Delete", ctx, "f1").Return(nil).Once()
+
+		_, err := s.CreateNotify(ctx, entity.Notify{Attachments: []entity.Attachment{csv}})
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestProcessNotifyAttachments(t *testing.T) {
+	meta := entity.Attachment{ID: "f1", Filename: "report.csv", ContentType: "text/csv", Size: 3}
+
+	t.Run("content loaded before sending", func(t *testing.T) {
+		ctx, db, cache, _, s := setupTestService(t)
+		db.On("ClaimSending", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusSending}, true, nil).Once()
+		cache.On("SetNotify", ctx, entity.Notify{ID: "id1", Status: entity.StatusSending}).Return(nil).Once()
+		notifier := mock_email.NewNotifier(t)
+		s.notifier = notifier
+		store := mock_blob.NewAttachmentStore(t)
+		WithAttachments(store, testAttachmentPolicy)(s)
+
+		n := entity.Notify{ID: "id1", To: []string{"a@example.com"}, Email: "a@example.com", Attachments: []entity.Attachment{meta}}
+		withContent := n
+		withContent.Attachments = []entity.Attachment{meta}
+		withContent.Attachments[0].Content = []byte("a,b")
+		delivery := entity.Delivery{NotifyID: "id1", Email: "a@example.com", Kind: entity.RecipientTo, Status: entity.DeliveryPending}
+
+		store.On("Get", ctx, "f1").Return([]byte("a,b"), nil).Once()
+		db.On("GetDeliveries", ctx, n.ID).Return([]entity.Delivery{delivery}, nil).Once()
+		notifier.On("Send", ctx, withContent, "a@example.com").Return(nil).Once()
+		db.On("UpdateDelivery", ctx, n.ID, "a@example.com", entity.StatusSent, "").Return(nil).Once()
+		db.On("UpdateNotifyStatus", ctx, n.ID, entity.StatusSent).Return(entity.Notify{ID: "id1"}, nil).Once()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil).Once()
+
+		assert.NoError(t, s.ProcessNotify(ctx, n))
+		assert.Nil(t, n.Attachments[0].Content)
+	})
+
+	t.Run("purged content fails notify", func(t *testing.T) {
+		ctx, db, cache, _, s := setupTestService(t)
+		db.On("ClaimSending", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusSending}, true, nil).Once()
+		cache.On("SetNotify", ctx, entity.Notify{ID: "id1", Status: entity.StatusSending}).Return(nil).Once()
+		store := mock_blob.NewAttachmentStore(t)
+		WithAttachments(store, testAttachmentPolicy)(s)
+
+		n := entity.Notify{ID: "id1", To: []string{"a@example.com"}, Attachments: []entity.Attachment{meta}}
+		store.On("Get", ctx, "f1").Return(nil, entity.ErrAttachmentNotFound).Once()
+		db.On("AddNotifyEvent", ctx, mock.MatchedBy(func(e entity.NotifyEvent) bool { return e.Action == entity.EventSendFailed })).Return(nil).Once()
+		db.On("UpdateNotifyStatus", ctx, n.ID, entity.StatusFailed).Return(entity.Notify{ID: "id1"}, nil).Once()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil).Once()
+
+		assert.ErrorIs(t, s.ProcessNotify(ctx, n), entity.ErrAttachmentNotFound)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestPurgeAttachments(t *testing.T) {
+	ctx, db, _, _, s := setupTestService(t)
+	store := mock_blob.NewAttachmentStore(t)
+	WithAttachments(store, testAttachmentPolicy)(s)
+
+	expired := entity.Notify{ID: "id1", Attachments: []entity.Attachment{{ID: "f1"}, {ID: "f2"}}}
+	db.On("GetExpiredAttachments", ctx, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= testAttachmentPolicy.Retention
+	}), s.batchSize).Return([]entity.Notify{expired}, nil).Once()
+	store.On("Delete", ctx, "f1").Return(nil).Once()
+	store.On("Delete", ctx, "f2").Return(nil).Once()
+	db.On("MarkAttachmentsPurged", ctx, "id1").Return(nil).Once()
+
+	purged, err := s.PurgeAttachments(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	db.AssertExpectations(t)
+}
+
+func TestGetAttachment(t *testing.T) {
+	ctx, _, cache, _, s := setupTestService(t)
+	store := mock_blob.NewAttachmentStore(t)
+	WithAttachments(store, testAttachmentPolicy)(s)
+
+	meta := entity.Attachment{ID: "f1", Filename: "report.csv", ContentType: "text/csv", Size: 3}
+	cache.On("GetNotify", ctx, "id1").Return(entity.Notify{ID: "id1", Attachments: []entity.Attachment{meta}}, nil)
+	store.On("Get", ctx, "f1").Return([]byte("a,b"), nil).Once()
+
+	attachment, err := s.GetAttachment(ctx, "id1", "f1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("a,b"), attachment.Content)
+
+	_, err = s.GetAttachment(ctx, "id1", "other")
+	assert.ErrorIs(t, err, entity.ErrAttachmentNotFound)
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"sync/atomic"
+
+	"delayed-notifier/internal/entity"
+)
+
+type cacheStats struct {
+	hits         atomic.Int64
+	negativeHits atomic.Int64
+	misses       atomic.Int64
+	coalesced    atomic.Int64
+}
+
+// CacheStats возвращает статистику обращений GetNotify к кэшу с момента запуска.
+func (s *NotifyService) CacheStats() entity.CacheStats {
+	return entity.CacheStats{
+		Hits:         s.stats.hits.Load(),
+		NegativeHits: s.stats.negativeHits.Load(),
+		Misses:       s.stats.misses.Load(),
+		Coalesced:    s.stats.coalesced.Load(),
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"delayed-notifier/internal/entity"
+)
+
+// spreadState хранит текущее окно распределения хвоста, чтобы следующие пачки
+// продолжали расписание, а не начинали его заново с текущего момента.
+type spreadState struct {
+	mu    sync.Mutex
+	until time.Time
+	step  time.Duration
+}
+
+type rescheduledNotify struct {
+	notify entity.Notify
+	sendAt time.Time
+}
+
+type catchUpPlan struct {
+	send       []entity.Notify
+	skip       []entity.SkippedNotify
+	reschedule []rescheduledNotify
+}
+
+func (s *NotifyService) isLate(notify entity.Notify, now time.Time) bool {
+	return now.Sub(notify.SendAt) > s.catchUp.LateAfter
+}
+
+// dispatchBatch применяет политику к пачке наступивших уведомлений и ставит в очередь то, что нужно отправить.
+func (s *NotifyService) dispatchBatch(ctx context.Context, notifies []entity.Notify, now time.Time) (entity.ScheduleReport, error) {
+	var report entity.ScheduleReport
+
+	plan, err := s.planCatchUp(ctx, notifies, now)
+	if err != nil {
+		return report, err
+	}
+
+	for _, skipped := range plan.skip {
+		updated, ok, err := s.db.SkipNotify(ctx, skipped.ID)
+		if err != nil {
+			return report, fmt.Errorf("skip ID=%s: %w", skipped.ID, err)
+		}
+		if !ok {
+			continue
+		}
+		_ = s.cache.SetNotify(ctx, updated)
+		s.logger.InfoContext(ctx, "notify skipped by catch-up policy",
+			slog.String("ID", skipped.ID),
+			slog.String("reason", skipped.Reason),
+			slog.Duration("lateness", skipped.Lateness),
+		)
+		report.Skipped = append(report.Skipped, skipped)
+	}
+
+	for _, r := range plan.reschedule {
+		updated, ok, err := s.db.RescheduleNotify(ctx, r.notify.ID, r.sendAt)
+		if err != nil {
+			return report, fmt.Errorf("reschedule ID=%s: %w", r.notify.ID, err)
+		}
+		if !ok {
+			continue
+		}
+		_ = s.cache.SetNotify(ctx, updated)
+		if s.schedule != nil {
+			s.requeue(ctx, r.notify.ID, r.sendAt)
+		}
+		report.Rescheduled++
+	}
+
+	for _, notify := range plan.send {
+		enqueued, err := s.enqueue(ctx, notify)
+		if err != nil {
+			return report, err
+		}
+		if enqueued {
+			report.Enqueued++
+		} else if s.schedule != nil {
+			s.requeue(ctx, notify.ID, notify.SendAt)
+		}
+	}
+
+	return report, nil
+}
+
+func (s *NotifyService) planCatchUp(ctx context.Context, notifies []entity.Notify, now time.Time) (catchUpPlan, error) {
+	var plan catchUpPlan
+
+	switch s.catchUp.Mode {
+	case entity.CatchUpMaxLateness:
+		for _, notify := range notifies {
+			if lateness := now.Sub(notify.SendAt); s.isLate(notify, now) && lateness > s.catchUp.MaxLateness {
+				plan.skip = append(plan.skip, entity.SkippedNotify{ID: notify.ID, Reason: entity.SkipReasonTooLate, Lateness: lateness})
+				continue
+			}
+			plan.send = append(plan.send, notify)
+		}
+
+	case entity.CatchUpCollapse:
+		// Из одинаковых опоздавших уведомлений тем же получателям отправляем только последнее.
+		// Пачка отсортирована по send_at, поэтому последнее — с наибольшим индексом
+		keys := make([]string, len(notifies))
+		latest := make(map[string]int)
+		for i, notify := range notifies {
+			if s.isLate(notify, now) {
+				keys[i] = collapseKey(notify)
+				latest[keys[i]] = i
+			}
+		}
+		for i, notify := range notifies {
+			if s.isLate(notify, now) && latest[keys[i]] != i {
+				plan.skip = append(plan.skip, entity.SkippedNotify{ID: notify.ID, Reason: entity.SkipReasonDuplicate, Lateness: now.Sub(notify.SendAt)})
+				continue
+			}
+			plan.send = append(plan.send, notify)
+		}
+
+	case entity.CatchUpSpread:
+		for _, notify := range notifies {
+			if !s.isLate(notify, now) {
+				plan.send = append(plan.send, notify)
+				continue
+			}
+			sendAt, err := s.spreadSlot(ctx, now)
+			if err != nil {
+				return plan, err
+			}
+			plan.reschedule = append(plan.reschedule, rescheduledNotify{notify: notify, sendAt: sendAt})
+		}
+
+	default:
+		plan.send = notifies
+	}
+
+	return plan, nil
+}
+
+// collapseKey совпадает только у уведомлений с одинаковым набором получателей
+// (без учёта регистра и порядка адресов) и одинаковым содержимым письма.
+func collapseKey(notify entity.Notify) string {
+	notify.Normalize()
+	key := struct {
+		To, Cc, Bcc, Lists []string
+		Subject, Message   string
+		HTML, Sender       string
+		ReplyTo            []string
+		Headers            map[string]string
+		Attachments        []string
+	}{
+		To:      normalizedEmails(notify.To),
+		Cc:      normalizedEmails(notify.Cc),
+		Bcc:     normalizedEmails(notify.Bcc),
+		Lists:   slices.Sorted(slices.Values(notify.RecipientLists)),
+		Subject: notify.Subject,
+		Message: notify.Message,
+		HTML:    notify.HTML,
+		Sender:  notify.Sender,
+		ReplyTo: normalizedEmails(notify.ReplyTo),
+		Headers: notify.Headers,
+	}
+	for _, a := range notify.Attachments {
+		key.Attachments = append(key.Attachments, a.ID)
+	}
+	// json сортирует ключи map, поэтому заголовки не зависят от порядка обхода
+	data, _ := json.Marshal(key)
+	return string(data)
+}
+
+func normalizedEmails(emails []string) []string {
+	out := make([]string, 0, len(emails))
+	for _, email := range emails {
+		out = append(out, strings.ToLower(email))
+	}
+	slices.Sort(out)
+	return out
+}
+
+// spreadSlot возвращает следующее время отправки при равномерном распределении
+// опоздавших уведомлений по SpreadWindow. Шаг считается по размеру всего хвоста
+// в момент начала окна.
+func (s *NotifyService) spreadSlot(ctx context.Context, now time.Time) (time.Time, error) {
+	s.spread.mu.Lock()
+	defer s.spread.mu.Unlock()
+
+	if !s.spread.until.After(now) {
+		count, err := s.db.CountReadyNotifies(ctx, now.Add(-s.catchUp.LateAfter))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("count late notifies: %w", err)
+		}
+		s.spread.until = now
+		s.spread.step = s.catchUp.SpreadWindow / time.Duration(max(count, 1))
+	}
+
+	at := s.spread.until
+	s.spread.until = at.Add(s.spread.step)
+	return at, nil
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"delayed-notifier/internal/entity"
+)
+
+func TestCatchUpPolicy(t *testing.T) {
+	t.Run("max lateness skips too late", func(t *testing.T) {
+		ctx, db, cache, producer, s := setupTestService(t)
+		s.catchUp = entity.CatchUpPolicy{Mode: entity.CatchUpMaxLateness, LateAfter: time.Minute, MaxLateness: time.Hour}
+
+		now := time.Now()
+		old := entity.Notify{ID: "old", SendAt: now.Add(-2 * time.Hour), Status: entity.StatusScheduled}
+		late := entity.Notify{ID: "late", SendAt: now.Add(-10 * time.Minute), Status: entity.StatusScheduled}
+		onTime := entity.Notify{ID: "on-time", SendAt: now.Add(-time.Second), Status: entity.StatusScheduled}
+
+		db.On("GetReadyNotifies", ctx, defaultBatchSize).Return([]entity.Notify{old, late, onTime}, nil).Once()
+		db.On("SkipNotify", ctx, old.ID).Return(entity.Notify{ID: old.ID, Status: entity.StatusSkipped}, true, nil).Once()
+		db.On("ClaimNotify", ctx, mock.Anything).Return(entity.Notify{Status: entity.StatusQueued}, true, nil).Twice()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil)
+		producer.On("Send", ctx, late).Return(nil).Once()
+		producer.On("Send", ctx, onTime).Return(nil).Once()
+
+		report, err := s.ScheduleReadyNotifies(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, report.Enqueued)
+		if assert.Len(t, report.Skipped, 1) {
+			assert.Equal(t, old.ID, report.Skipped[0].ID)
+			assert.Equal(t, entity.SkipReasonTooLate, report.Skipped[0].Reason)
+		}
+		db.AssertExpectations(t)
+		producer.AssertExpectations(t)
+		producer.AssertNotCalled(t, "Send", ctx, old)
+	})
+
+	t.Run("collapse keeps latest duplicate", func(t *testing.T) {
+		ctx, db, cache, producer, s := setupTestService(t)
+		s.catchUp = entity.CatchUpPolicy{Mode: entity.CatchUpCollapse, LateAfter: time.Minute}
+
+		now := time.Now()
+		n1 := entity.Notify{ID: "id1", SendAt: now.Add(-3 * time.Hour), Email: "a@example.com", Message: "ping", Status: entity.StatusScheduled}
+		n2 := entity.Notify{ID: "id2", SendAt: now.Add(-2 * time.Hour), Email: "a@example.com", Message: "ping", Status: entity.StatusScheduled}
+		n3 := entity.Notify{ID: "id3", SendAt: now.Add(-2 * time.Hour), Email: "b@example.com", Message: "ping", Status: entity.StatusScheduled}
+
+		db.On("GetReadyNotifies", ctx, defaultBatchSize).Return([]entity.Notify{n1, n2, n3}, nil).Once()
+		db.On("SkipNotify", ctx, n1.ID).Return(entity.Notify{ID: n1.ID, Status: entity.StatusSkipped}, true, nil).Once()
+		db.On("ClaimNotify", ctx, mock.Anything).Return(entity.Notify{Status: entity.StatusQueued}, true, nil).Twice()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil)
+		producer.On("Send", ctx, n2).Return(nil).Once()
+		producer.On("Send", ctx, n3).Return(nil).Once()
+
+		report, err := s.ScheduleReadyNotifies(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, report.Enqueued)
+		if assert.Len(t, report.Skipped, 1) {
+			assert.Equal(t, entity.SkipReasonDuplicate, report.Skipped[0].Reason)
+		}
+		db.AssertExpectations(t)
+		producer.AssertExpectations(t)
+	})
+
+	t.Run("collapse keeps notifies with different recipients or content", func(t *testing.T) {
+		ctx, db, cache, producer, s := setupTestService(t)
+		s.catchUp = entity.CatchUpPolicy{Mode: entity.CatchUpCollapse, LateAfter: time.Minute}
+
+		now := time.Now()
+		base := entity.Notify{SendAt: now.Add(-2 * time.Hour), Email: "a@example.com", To: []string{"a@example.com"}, Message: "ping", Status: entity.StatusScheduled}
+		plain, withCc, withSubject, bccOnly, listOnly := base, base, base, base, base
+		plain.ID = "plain"
+		withCc.ID, withCc.Cc = "cc", []string{"b@example.com"}
+		withSubject.ID, withSubject.Subject = "subject", "Важно"
+		bccOnly.ID, bccOnly.Email, bccOnly.To, bccOnly.Bcc = "bcc", "", nil, []string{"c@example.com"}
+		listOnly.ID, listOnly.Email, listOnly.To, listOnly.RecipientLists = "list", "", nil, []string{"l1"}
+		// Тот же набор получателей в другом регистре и порядке считается дубликатом
+		reordered := base
+		reordered.ID, reordered.SendAt = "reordered", now.Add(-time.Hour)
+		reordered.To, reordered.Email, reordered.Cc = []string{"A@Example.com"}, "A@Example.com", []string{"B@example.com"}
+		notifies := []entity.Notify{plain, withCc, withSubject, bccOnly, listOnly, reordered}
+
+		db.On("GetReadyNotifies", ctx, defaultBatchSize).Return(notifies, nil).Once()
+		db.On("SkipNotify", ctx, withCc.ID).Return(entity.Notify{ID: withCc.ID, Status: entity.StatusSkipped}, true, nil).Once()
+		db.On("ClaimNotify", ctx, mock.Anything).Return(entity.Notify{Status: entity.StatusQueued}, true, nil).Times(5)
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil)
+		for _, n := range []entity.Notify{plain, withSubject, bccOnly, listOnly, reordered} {
+			producer.On("Send", ctx, n).Return(nil).Once()
+		}
+
+		report, err := s.ScheduleReadyNotifies(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 5, report.Enqueued)
+		if assert.Len(t, report.Skipped, 1) {
+			assert.Equal(t, withCc.ID, report.Skipped[0].ID)
+		}
+		db.AssertExpectations(t)
+		producer.AssertExpectations(t)
+	})
+
+	t.Run("spread reschedules backlog over window", func(t *testing.T) {
+		ctx, db, cache, producer, s := setupTestService(t)
+		s.catchUp = entity.CatchUpPolicy{Mode: entity.CatchUpSpread, LateAfter: time.Minute, SpreadWindow: 10 * time.Minute}
+		s.batchSize = 2
+
+		now := time.Now()
+		n1 := entity.Notify{ID: "id1", SendAt: now.Add(-time.Hour), Status: entity.StatusScheduled}
+		n2 := entity.Notify{ID: "id2", SendAt: now.Add(-time.Hour), Status: entity.StatusScheduled}
+
+		db.On("GetReadyNotifies", ctx, 2).Return([]entity.Notify{n1, n2}, nil).Once()
+		db.On("CountReadyNotifies", ctx, mock.Anything).Return(4, nil).Once()
+		var slots []time.Time
+		db.On("RescheduleNotify", ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			slots = append(slots, args.Get(2).(time.Time))
+		}).Return(entity.Notify{Status: entity.StatusScheduled}, true, nil).Twice()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil)
+
+		report, err := s.ScheduleReadyNotifies(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, report.Rescheduled)
+		assert.True(t, report.HasMore)
+		if assert.Len(t, slots, 2) {
+			assert.Equal(t, 150*time.Second, slots[1].Sub(slots[0]))
+		}
+		db.AssertExpectations(t)
+		producer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("no progress stops batching", func(t *testing.T) {
+		ctx, db, cache, producer, s := setupTestService(t)
+		s.batchSize = 1
+
+		n := entity.Notify{ID: "id1", SendAt: time.Now().Add(-time.Second), Status: entity.StatusScheduled}
+		db.On("GetReadyNotifies", ctx, 1).Return([]entity.Notify{n}, nil).Once()
+		db.On("ClaimNotify", ctx, n.ID).Return(entity.Notify{ID: n.ID, Status: entity.StatusQueued}, true, nil).Once()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil)
+		producer.On("Send", ctx, n).Return(assert.AnError).Once()
+		db.On("UpdateNotifyStatus", ctx, n.ID, entity.StatusScheduled).Return(entity.Notify{ID: n.ID, Status: entity.StatusScheduled}, nil).Once()
+
+		report, err := s.ScheduleReadyNotifies(ctx)
+
+		assert.NoError(t, err)
+		assert.False(t, report.HasMore)
+	})
+
+	t.Run("dispatch leaves late notify to scheduler", func(t *testing.T) {
+		ctx, db, _, producer, s := setupTestService(t)
+
+		n := entity.Notify{ID: "id1", SendAt: time.Now().Add(-time.Hour), Status: entity.StatusScheduled}
+		db.On("GetNotify", ctx, n.ID).Return(n, nil).Once()
+
+		assert.NoError(t, s.DispatchNotify(ctx, n.ID))
+		db.AssertNotCalled(t, "ClaimNotify", mock.Anything, mock.Anything)
+		producer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
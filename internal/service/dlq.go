@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"delayed-notifier/internal/entity"
+)
+
+type NotifyDLQRepository interface {
+	ListEntries(ctx context.Context, filter entity.DLQFilter) ([]entity.DLQEntry, error)
+}
+
+type DLQService struct {
+	dlq      NotifyDLQRepository
+	db       NotifyDBRepository
+	cache    NotifyCacheRepository
+	producer NotifyProducer
+	schedule NotifyScheduleRepository
+	logger   *slog.Logger
+}
+
+type DLQOption func(*DLQService)
+
+// WithDLQScheduleRepository сразу добавляет уведомления, возвращённые в scheduled,
+// в расписание Redis, не дожидаясь ReconcileSchedule.
+func WithDLQScheduleRepository(schedule NotifyScheduleRepository) DLQOption {
+	return func(s *DLQService) {
+		s.schedule = schedule
+	}
+}
+
+func NewDLQService(dlq NotifyDLQRepository, db NotifyDBRepository, cache NotifyCacheRepository, producer NotifyProducer, logger *slog.Logger, opts ...DLQOption) *DLQService {
+	s := &DLQService{dlq: dlq, db: db, cache: cache, producer: producer, logger: logger}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *DLQService) ListDLQ(ctx context.Context, filter entity.DLQFilter) ([]entity.DLQEntry, error) {
+	entries, err := s.dlq.ListEntries(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("ListDLQ: %w", err)
+	}
+	return entries, nil
+}
+
+func (s *DLQService) ReplayDLQ(ctx context.Context, req entity.DLQReplayRequest) ([]entity.DLQReplayResult, error) {
+	if !req.All && len(req.Entries) == 0 {
+		return nil, errors.New("ReplayDLQ: no entries selected")
+	}
+
+	entries, err := s.dlq.ListEntries(ctx, entity.DLQFilter{Reason: req.Reason})
+	if err != nil {
+		return nil, fmt.Errorf("ReplayDLQ: list entries: %w", err)
+	}
+
+	selected := make(map[entity.DLQPosition]bool, len(req.Entries))
+	for _, pos := range req.Entries {
+		selected[pos] = true
+	}
+
+	results := make([]entity.DLQReplayResult, 0, len(entries))
+	for _, entry := range entries {
+		if !req.All && !selected[entity.DLQPosition{Partition: entry.Partition, Offset: entry.Offset}] {
+			continue
+		}
+
+		result := s.replayEntry(ctx, entry, req)
+		s.logger.InfoContext(ctx, "dlq replay",
+			slog.String("actor", req.Actor),
+			slog.Bool("dry_run", req.DryRun),
+			slog.Int("partition", entry.Partition),
+			slog.Int64("offset", entry.Offset),
+			slog.String("reason", entry.Reason),
+			slog.String("notify_id", result.NotifyID),
+			slog.String("action", result.Action),
+			slog.String("status", result.Status),
+			slog.String("error", result.Error),
+		)
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (s *DLQService) replayEntry(ctx context.Context, entry entity.DLQEntry, req entity.DLQReplayRequest) entity.DLQReplayResult {
+	result := entity.DLQReplayResult{
+		Partition: entry.Partition,
+		Offset:    entry.Offset,
+		NotifyID:  entry.NotifyID,
+	}
+
+	if entry.NotifyID == "" {
+		result.Action = entity.DLQActionSkipped
+		result.Error = "notify id is unknown"
+		return result
+	}
+
+	// Источник истины — БД: payload в DLQ может быть битым или устаревшим
+	notify, err := s.db.GetNotify(ctx, entry.NotifyID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotifyNotFound) {
+			result.Action = entity.DLQActionSkipped
+			result.Error = "notify not found"
+			return result
+		}
+		result.Action = entity.DLQActionFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	// Отменённое администратором или пропущенное политикой догоняющей отправки
+	// уведомление не должно вернуться через DLQ
+	if notify.Status == entity.StatusSent || notify.Status == entity.StatusCanceled || notify.Status == entity.StatusSkipped {
+		result.Action = entity.DLQActionSkipped
+		result.Status = notify.Status
+		result.Error = "notify already " + notify.Status
+		return result
+	}
+
+	if req.Reschedule {
+		result.Status = entity.StatusScheduled
+		if req.DryRun {
+			result.Action = entity.DLQActionWouldReschedule
+			return result
+		}
+		scheduled, err := s.updateStatus(ctx, notify.ID, entity.StatusScheduled)
+		if err != nil {
+			result.Action = entity.DLQActionFailed
+			result.Error = err.Error()
+			return result
+		}
+		if s.schedule != nil {
+			// Ошибку Redis исправит ReconcileSchedule, уведомление уже в scheduled
+			if err := s.schedule.Add(ctx, scheduled.ID, scheduled.SendAt); err != nil {
+				s.logger.WarnContext(ctx, "failed to add notify to schedule", slog.String("notify_id", scheduled.ID), slog.Any("error", err))
+			}
+		}
+		result.Action = entity.DLQActionRescheduled
+		return result
+	}
+
+	result.Status = entity.StatusQueued
+	if req.DryRun {
+		result.Action = entity.DLQActionWouldReplay
+		return result
+	}
+
+	// Статус меняется до отправки в Kafka: иначе быстрый консьюмер успеет записать
+	// sent, а запоздавший queued перетрёт его, и reaper отправит письмо повторно
+	queued, err := s.updateStatus(ctx, notify.ID, entity.StatusQueued)
+	if err != nil {
+		result.Action = entity.DLQActionFailed
+		result.Error = err.Error()
+		return result
+	}
+	if err := s.producer.Send(ctx, queued); err != nil {
+		// Сообщение не ушло, поэтому возвращаем прежний статус, чтобы запись можно было повторить
+		if _, restoreErr := s.updateStatus(ctx, notify.ID, notify.Status); restoreErr != nil {
+			s.logger.ErrorContext(ctx, "failed to restore notify status", slog.String("notify_id", notify.ID), slog.Any("error", restoreErr))
+		}
+		result.Action = entity.DLQActionFailed
+		result.Error = err.Error()
+		return result
+	}
+	result.Action = entity.DLQActionReplayed
+	return result
+}
+
+func (s *DLQService) updateStatus(ctx context.Context, notifyID, status string) (entity.Notify, error) {
+	updated, err := s.db.UpdateNotifyStatus(ctx, notifyID, status)
+	if err != nil {
+		return entity.Notify{}, err
+	}
+	_ = s.cache.SetNotify(ctx, updated)
+	return updated, nil
+}
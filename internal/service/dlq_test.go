@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"delayed-notifier/internal/entity"
+	mock_dlq "delayed-notifier/internal/repository/dlq/mocks"
+	mock_db "delayed-notifier/internal/repository/postgres/mocks"
+	mock_producer "delayed-notifier/internal/repository/producer/mocks"
+	mock_cache "delayed-notifier/internal/repository/redis/mocks"
+)
+
+func setupTestDLQService(t *testing.T) (context.Context, *mock_dlq.NotifyDLQRepository, *mock_db.NotifyDBRepository, *mock_cache.NotifyCacheRepository, *mock_producer.NotifyProducer, *DLQService) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	dlq := new(mock_dlq.NotifyDLQRepository)
+	db := new(mock_db.NotifyDBRepository)
+	cache := new(mock_cache.NotifyCacheRepository)
+	producer := new(mock_producer.NotifyProducer)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	s := NewDLQService(dlq, db, cache, producer, logger)
+
+	return ctx, dlq, db, cache, producer, s
+}
+
+func TestListDLQ(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctx, dlq, _, _, _, s := setupTestDLQService(t)
+
+		filter := entity.DLQFilter{Reason: entity.DLQReasonProcessingFailed, Limit: 10}
+		entries := []entity.DLQEntry{{Partition: 0, Offset: 1, NotifyID: "id1", Reason: entity.DLQReasonProcessingFailed}}
+		dlq.On("ListEntries", ctx, filter).Return(entries, nil).Once()
+
+		result, err := s.ListDLQ(ctx, filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entries, result)
+		dlq.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		ctx, dlq, _, _, _, s := setupTestDLQService(t)
+
+		dlq.On("ListEntries", ctx, entity.DLQFilter{}).Return(nil, assert.AnError).Once()
+
+		result, err := s.ListDLQ(ctx, entity.DLQFilter{})
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, result)
+	})
+}
+
+func TestReplayDLQ(t *testing.T) {
+	entries := []entity.DLQEntry{
+		{Partition: 0, Offset: 1, NotifyID: "id1", Reason: entity.DLQReasonProcessingFailed},
+		{Partition: 0, Offset: 2, NotifyID: "id2", Reason: entity.DLQReasonProcessingFailed},
+		{Partition: 1, Offset: 7, Reason: entity.DLQReasonInvalidJSON},
+	}
+
+	t.Run("replay selected", func(t *testing.T) {
+		ctx, dlq, db, cache, producer, s := setupTestDLQService(t)
+
+		n2 := entity.Notify{ID: "id2", Message: "m2", Email: "a@example.com", Status: entity.StatusFailed, Version: 3}
+		queued := n2
+		queued.Status, queued.Version = entity.StatusQueued, 4
+		dlq.On("ListEntries", ctx, entity.DLQFilter{}).Return(entries, nil).Once()
+		db.On("GetNotify", ctx, "id2").Return(n2, nil).Once()
+		// Статус пишется до отправки, чтобы консьюмер не успел записать sent раньше
+		db.On("UpdateNotifyStatus", ctx, "id2", entity.StatusQueued).Return(queued, nil).Once().
+			Run(func(mock.Arguments) { producer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything) })
+		producer.On("Send", ctx, queued).Return(nil).Once()
+		cache.On("SetNotify", ctx, queued).Return(nil).Once()
+
+		results, err := s.ReplayDLQ(ctx, entity.DLQReplayRequest{Entries: []entity.DLQPosition{{Partition: 0, Offset: 2}}})
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, entity.DLQActionReplayed, results[0].Action)
+		assert.Equal(t, entity.StatusQueued, results[0].Status)
+		db.AssertExpectations(t)
+		producer.AssertExpectations(t)
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("reschedule all", func(t *testing.T) {
+		ctx, dlq, db, cache, producer, s := setupTestDLQService(t)
+
+		n1 := entity.Notify{ID: "id1", Status: entity.StatusFailed}
+		n2 := entity.Notify{ID: "id2", Status: entity.StatusSkipped}
+		dlq.On("ListEntries", ctx, entity.DLQFilter{}).Return(entries, nil).Once()
+		db.On("GetNotify", ctx, "id1").Return(n1, nil).Once()
+		db.On("GetNotify", ctx, "id2").Return(n2, nil).Once()
+		db.On("UpdateNotifyStatus", ctx, "id1", entity.StatusScheduled).Return(entity.Notify{ID: "id1", Status: entity.StatusScheduled}, nil).Once()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil).Once()
+
+		results, err := s.ReplayDLQ(ctx, entity.DLQReplayRequest{All: true, Reschedule: true})
+
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, entity.DLQActionRescheduled, results[0].Action)
+		assert.Equal(t, entity.DLQActionSkipped, results[1].Action)
+		assert.Equal(t, entity.DLQActionSkipped, results[2].Action)
+		db.AssertExpectations(t)
+		producer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("final statuses skipped", func(t *testing.T) {
+		for _, status := range []string{entity.StatusSent, entity.StatusCanceled, entity.StatusSkipped} {
+			ctx, dlq, db, _, producer, s := setupTestDLQService(t)
+
+			dlq.On("ListEntries", ctx, entity.DLQFilter{}).Return(entries[:1], nil).Once()
+			db.On("GetNotify", ctx, "id1").Return(entity.Notify{ID: "id1", Status: status}, nil).Once()
+
+			results, err := s.ReplayDLQ(ctx, entity.DLQReplayRequest{All: true})
+
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			assert.Equal(t, entity.DLQActionSkipped, results[0].Action, status)
+			assert.Equal(t, status, results[0].Status)
+			db.AssertNotCalled(t, "UpdateNotifyStatus", mock.Anything, mock.Anything, mock.Anything)
+			producer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("reschedule adds to schedule", func(t *testing.T) {
+		ctx, dlq, db, cache, producer, _ := setupTestDLQService(t)
+		schedule := mock_cache.NewNotifyScheduleRepository(t)
+		s := NewDLQService(dlq, db, cache, producer, slog.New(slog.NewTextHandler(io.Discard, nil)), WithDLQScheduleRepository(schedule))
+
+		sendAt := time.Now().Add(-time.Minute)
+		dlq.On("ListEntries", ctx, entity.DLQFilter{}).Return(entries[:1], nil).Once()
+		db.On("GetNotify", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusFailed, SendAt: sendAt}, nil).Once()
+		db.On("UpdateNotifyStatus", ctx, "id1", entity.StatusScheduled).Return(entity.Notify{ID: "id1", Status: entity.StatusScheduled, SendAt: sendAt}, nil).Once()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil).Once()
+		schedule.On("Add", ctx, "id1", sendAt).Return(nil).Once()
+
+		results, err := s.ReplayDLQ(ctx, entity.DLQReplayRequest{All: true, Reschedule: true})
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, entity.DLQActionRescheduled, results[0].Action)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		ctx, dlq, db, cache, producer, s := setupTestDLQService(t)
+
+		filter := entity.DLQFilter{Reason: entity.DLQReasonProcessingFailed}
+		dlq.On("ListEntries", ctx, filter).Return(entries[:2], nil).Once()
+		db.On("GetNotify", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusFailed}, nil).Once()
+		db.On("GetNotify", ctx, "id2").Return(entity.Notify{}, entity.ErrNotifyNotFound).Once()
+
+		results, err := s.ReplayDLQ(ctx, entity.DLQReplayRequest{All: true, Reason: entity.DLQReasonProcessingFailed, DryRun: true})
+
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, entity.DLQActionWouldReplay, results[0].Action)
+		assert.Equal(t, entity.DLQActionSkipped, results[1].Action)
+		producer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+		db.AssertNotCalled(t, "UpdateNotifyStatus", mock.Anything, mock.Anything, mock.Anything)
+		cache.AssertNotCalled(t, "SetNotify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("producer error", func(t *testing.T) {
+		ctx, dlq, db, cache, producer, s := setupTestDLQService(t)
+
+		n1 := entity.Notify{ID: "id1", Status: entity.StatusFailed}
+		queued := entity.Notify{ID: "id1", Status: entity.StatusQueued}
+		dlq.On("ListEntries", ctx, entity.DLQFilter{}).Return(entries[:1], nil).Once()
+		db.On("GetNotify", ctx, "id1").Return(n1, nil).Once()
+		db.On("UpdateNotifyStatus", ctx, "id1", entity.StatusQueued).Return(queued, nil).Once()
+		producer.On("Send", ctx, queued).Return(assert.AnError).Once()
+		db.On("UpdateNotifyStatus", ctx, "id1", entity.StatusFailed).Return(n1, nil).Once()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil).Twice()
+
+		results, err := s.ReplayDLQ(ctx, entity.DLQReplayRequest{All: true})
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, entity.DLQActionFailed, results[0].Action)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("nothing selected", func(t *testing.T) {
+		ctx, dlq, _, _, _, s := setupTestDLQService(t)
+
+		_, err := s.ReplayDLQ(ctx, entity.DLQReplayRequest{})
+
+		assert.Error(t, err)
+		dlq.AssertNotCalled(t, "ListEntries", mock.Anything, mock.Anything)
+	})
+}
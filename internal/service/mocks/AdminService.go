@@ -0,0 +1,443 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	entity "delayed-notifier/internal/entity"
+)
+
+// AdminService is an autogenerated mock type for the AdminService type
+type AdminService struct {
+	mock.Mock
+}
+
+type AdminService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *AdminService) EXPECT() *AdminService_Expecter {
+	return &AdminService_Expecter{mock: &_m.Mock}
+}
+
+// CancelNotify provides a mock function with given fields: ctx, notifyID, req
+func (_m *AdminService) CancelNotify(ctx context.Context, notifyID string, req entity.AdminActionRequest) (entity.AdminResult, error) {
+	ret := _m.Called(ctx, notifyID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelNotify")
+	}
+
+	var r0 entity.AdminResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.AdminActionRequest) (entity.AdminResult, error)); ok {
+		return rf(ctx, notifyID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.AdminActionRequest) entity.AdminResult); ok {
+		r0 = rf(ctx, notifyID, req)
+	} else {
+		r0 = ret.Get(0).(entity.AdminResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, entity.AdminActionRequest) error); ok {
+		r1 = rf(ctx, notifyID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminService_CancelNotify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelNotify'
+type AdminService_CancelNotify_Call struct {
+	*mock.Call
+}
+
+// CancelNotify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+//   - req entity.AdminActionRequest
+func (_e *AdminService_Expecter) CancelNotify(ctx interface{}, notifyID interface{}, req interface{}) *AdminService_CancelNotify_Call {
+	return &AdminService_CancelNotify_Call{Call: _e.mock.On("CancelNotify", ctx, notifyID, req)}
+}
+
+func (_c *AdminService_CancelNotify_Call) Run(run func(ctx context.Context, notifyID string, req entity.AdminActionRequest)) *AdminService_CancelNotify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(entity.AdminActionRequest))
+	})
+	return _c
+}
+
+func (_c *AdminService_CancelNotify_Call) Return(_a0 entity.AdminResult, _a1 error) *AdminService_CancelNotify_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminService_CancelNotify_Call) RunAndReturn(run func(context.Context, string, entity.AdminActionRequest) (entity.AdminResult, error)) *AdminService_CancelNotify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotifyDetails provides a mock function with given fields: ctx, notifyID
+func (_m *AdminService) GetNotifyDetails(ctx context.Context, notifyID string) (entity.NotifyDetails, error) {
+	ret := _m.Called(ctx, notifyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotifyDetails")
+	}
+
+	var r0 entity.NotifyDetails
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.NotifyDetails, error)); ok {
+		return rf(ctx, notifyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.NotifyDetails); ok {
+		r0 = rf(ctx, notifyID)
+	} else {
+		r0 = ret.Get(0).(entity.NotifyDetails)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, notifyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminService_GetNotifyDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotifyDetails'
+type AdminService_GetNotifyDetails_Call struct {
+	*mock.Call
+}
+
+// GetNotifyDetails is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+func (_e *AdminService_Expecter) GetNotifyDetails(ctx interface{}, notifyID interface{}) *AdminService_GetNotifyDetails_Call {
+	return &AdminService_GetNotifyDetails_Call{Call: _e.mock.On("GetNotifyDetails", ctx, notifyID)}
+}
+
+func (_c *AdminService_GetNotifyDetails_Call) Run(run func(ctx context.Context, notifyID string)) *AdminService_GetNotifyDetails_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *AdminService_GetNotifyDetails_Call) Return(_a0 entity.NotifyDetails, _a1 error) *AdminService_GetNotifyDetails_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminService_GetNotifyDetails_Call) RunAndReturn(run func(context.Context, string) (entity.NotifyDetails, error)) *AdminService_GetNotifyDetails_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotifyStats provides a mock function with given fields: ctx
+func (_m *AdminService) GetNotifyStats(ctx context.Context) (entity.NotifyStats, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotifyStats")
+	}
+
+	var r0 entity.NotifyStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (entity.NotifyStats, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) entity.NotifyStats); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(entity.NotifyStats)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminService_GetNotifyStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotifyStats'
+type AdminService_GetNotifyStats_Call struct {
+	*mock.Call
+}
+
+// GetNotifyStats is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *AdminService_Expecter) GetNotifyStats(ctx interface{}) *AdminService_GetNotifyStats_Call {
+	return &AdminService_GetNotifyStats_Call{Call: _e.mock.On("GetNotifyStats", ctx)}
+}
+
+func (_c *AdminService_GetNotifyStats_Call) Run(run func(ctx context.Context)) *AdminService_GetNotifyStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *AdminService_GetNotifyStats_Call) Return(_a0 entity.NotifyStats, _a1 error) *AdminService_GetNotifyStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminService_GetNotifyStats_Call) RunAndReturn(run func(context.Context) (entity.NotifyStats, error)) *AdminService_GetNotifyStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNotifies provides a mock function with given fields: ctx, filter
+func (_m *AdminService) ListNotifies(ctx context.Context, filter entity.NotifyFilter) ([]entity.Notify, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNotifies")
+	}
+
+	var r0 []entity.Notify
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.NotifyFilter) ([]entity.Notify, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.NotifyFilter) []entity.Notify); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Notify)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.NotifyFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminService_ListNotifies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNotifies'
+type AdminService_ListNotifies_Call struct {
+	*mock.Call
+}
+
+// ListNotifies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter entity.NotifyFilter
+func (_e *AdminService_Expecter) ListNotifies(ctx interface{}, filter interface{}) *AdminService_ListNotifies_Call {
+	return &AdminService_ListNotifies_Call{Call: _e.mock.On("ListNotifies", ctx, filter)}
+}
+
+func (_c *AdminService_ListNotifies_Call) Run(run func(ctx context.Context, filter entity.NotifyFilter)) *AdminService_ListNotifies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.NotifyFilter))
+	})
+	return _c
+}
+
+func (_c *AdminService_ListNotifies_Call) Return(_a0 []entity.Notify, _a1 error) *AdminService_ListNotifies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminService_ListNotifies_Call) RunAndReturn(run func(context.Context, entity.NotifyFilter) ([]entity.Notify, error)) *AdminService_ListNotifies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequeueFailed provides a mock function with given fields: ctx, req
+func (_m *AdminService) RequeueFailed(ctx context.Context, req entity.RequeueRequest) ([]entity.AdminResult, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequeueFailed")
+	}
+
+	var r0 []entity.AdminResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.RequeueRequest) ([]entity.AdminResult, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.RequeueRequest) []entity.AdminResult); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.AdminResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.RequeueRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminService_RequeueFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequeueFailed'
+type AdminService_RequeueFailed_Call struct {
+	*mock.Call
+}
+
+// RequeueFailed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req entity.RequeueRequest
+func (_e *AdminService_Expecter) RequeueFailed(ctx interface{}, req interface{}) *AdminService_RequeueFailed_Call {
+	return &AdminService_RequeueFailed_Call{Call: _e.mock.On("RequeueFailed", ctx, req)}
+}
+
+func (_c *AdminService_RequeueFailed_Call) Run(run func(ctx context.Context, req entity.RequeueRequest)) *AdminService_RequeueFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.RequeueRequest))
+	})
+	return _c
+}
+
+func (_c *AdminService_RequeueFailed_Call) Return(_a0 []entity.AdminResult, _a1 error) *AdminService_RequeueFailed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminService_RequeueFailed_Call) RunAndReturn(run func(context.Context, entity.RequeueRequest) ([]entity.AdminResult, error)) *AdminService_RequeueFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RescheduleNotify provides a mock function with given fields: ctx, notifyID, req
+func (_m *AdminService) RescheduleNotify(ctx context.Context, notifyID string, req entity.AdminActionRequest) (entity.AdminResult, error) {
+	ret := _m.Called(ctx, notifyID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RescheduleNotify")
+	}
+
+	var r0 entity.AdminResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.AdminActionRequest) (entity.AdminResult, error)); ok {
+		return rf(ctx, notifyID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.AdminActionRequest) entity.AdminResult); ok {
+		r0 = rf(ctx, notifyID, req)
+	} else {
+		r0 = ret.Get(0).(entity.AdminResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, entity.AdminActionRequest) error); ok {
+		r1 = rf(ctx, notifyID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminService_RescheduleNotify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RescheduleNotify'
+type AdminService_RescheduleNotify_Call struct {
+	*mock.Call
+}
+
+// RescheduleNotify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+//   - req entity.AdminActionRequest
+func (_e *AdminService_Expecter) RescheduleNotify(ctx interface{}, notifyID interface{}, req interface{}) *AdminService_RescheduleNotify_Call {
+	return &AdminService_RescheduleNotify_Call{Call: _e.mock.On("RescheduleNotify", ctx, notifyID, req)}
+}
+
+func (_c *AdminService_RescheduleNotify_Call) Run(run func(ctx context.Context, notifyID string, req entity.AdminActionRequest)) *AdminService_RescheduleNotify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(entity.AdminActionRequest))
+	})
+	return _c
+}
+
+func (_c *AdminService_RescheduleNotify_Call) Return(_a0 entity.AdminResult, _a1 error) *AdminService_RescheduleNotify_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminService_RescheduleNotify_Call) RunAndReturn(run func(context.Context, string, entity.AdminActionRequest) (entity.AdminResult, error)) *AdminService_RescheduleNotify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResendNotify provides a mock function with given fields: ctx, notifyID, req
+func (_m *AdminService) ResendNotify(ctx context.Context, notifyID string, req entity.AdminActionRequest) (entity.AdminResult, error) {
+	ret := _m.Called(ctx, notifyID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendNotify")
+	}
+
+	var r0 entity.AdminResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.AdminActionRequest) (entity.AdminResult, error)); ok {
+		return rf(ctx, notifyID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.AdminActionRequest) entity.AdminResult); ok {
+		r0 = rf(ctx, notifyID, req)
+	} else {
+		r0 = ret.Get(0).(entity.AdminResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, entity.AdminActionRequest) error); ok {
+		r1 = rf(ctx, notifyID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AdminService_ResendNotify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResendNotify'
+type AdminService_ResendNotify_Call struct {
+	*mock.Call
+}
+
+// ResendNotify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+//   - req entity.AdminActionRequest
+func (_e *AdminService_Expecter) ResendNotify(ctx interface{}, notifyID interface{}, req interface{}) *AdminService_ResendNotify_Call {
+	return &AdminService_ResendNotify_Call{Call: _e.mock.On("ResendNotify", ctx, notifyID, req)}
+}
+
+func (_c *AdminService_ResendNotify_Call) Run(run func(ctx context.Context, notifyID string, req entity.AdminActionRequest)) *AdminService_ResendNotify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(entity.AdminActionRequest))
+	})
+	return _c
+}
+
+func (_c *AdminService_ResendNotify_Call) Return(_a0 entity.AdminResult, _a1 error) *AdminService_ResendNotify_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *AdminService_ResendNotify_Call) RunAndReturn(run func(context.Context, string, entity.AdminActionRequest) (entity.AdminResult, error)) *AdminService_ResendNotify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewAdminService creates a new instance of AdminService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAdminService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AdminService {
+	mock := &AdminService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,156 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "delayed-notifier/internal/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DLQService is an autogenerated mock type for the DLQService type
+type DLQService struct {
+	mock.Mock
+}
+
+type DLQService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DLQService) EXPECT() *DLQService_Expecter {
+	return &DLQService_Expecter{mock: &_m.Mock}
+}
+
+// ListDLQ provides a mock function with given fields: ctx, filter
+func (_m *DLQService) ListDLQ(ctx context.Context, filter entity.DLQFilter) ([]entity.DLQEntry, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDLQ")
+	}
+
+	var r0 []entity.DLQEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DLQFilter) ([]entity.DLQEntry, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DLQFilter) []entity.DLQEntry); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.DLQEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.DLQFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DLQService_ListDLQ_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDLQ'
+type DLQService_ListDLQ_Call struct {
+	*mock.Call
+}
+
+// ListDLQ is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter entity.DLQFilter
+func (_e *DLQService_Expecter) ListDLQ(ctx interface{}, filter interface{}) *DLQService_ListDLQ_Call {
+	return &DLQService_ListDLQ_Call{Call: _e.mock.On("ListDLQ", ctx, filter)}
+}
+
+func (_c *DLQService_ListDLQ_Call) Run(run func(ctx context.Context, filter entity.DLQFilter)) *DLQService_ListDLQ_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.DLQFilter))
+	})
+	return _c
+}
+
+func (_c *DLQService_ListDLQ_Call) Return(_a0 []entity.DLQEntry, _a1 error) *DLQService_ListDLQ_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DLQService_ListDLQ_Call) RunAndReturn(run func(context.Context, entity.DLQFilter) ([]entity.DLQEntry, error)) *DLQService_ListDLQ_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplayDLQ provides a mock function with given fields: ctx, req
+func (_m *DLQService) ReplayDLQ(ctx context.Context, req entity.DLQReplayRequest) ([]entity.DLQReplayResult, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayDLQ")
+	}
+
+	var r0 []entity.DLQReplayResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DLQReplayRequest) ([]entity.DLQReplayResult, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DLQReplayRequest) []entity.DLQReplayResult); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.DLQReplayResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.DLQReplayRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DLQService_ReplayDLQ_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplayDLQ'
+type DLQService_ReplayDLQ_Call struct {
+	*mock.Call
+}
+
+// ReplayDLQ is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req entity.DLQReplayRequest
+func (_e *DLQService_Expecter) ReplayDLQ(ctx interface{}, req interface{}) *DLQService_ReplayDLQ_Call {
+	return &DLQService_ReplayDLQ_Call{Call: _e.mock.On("ReplayDLQ", ctx, req)}
+}
+
+func (_c *DLQService_ReplayDLQ_Call) Run(run func(ctx context.Context, req entity.DLQReplayRequest)) *DLQService_ReplayDLQ_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.DLQReplayRequest))
+	})
+	return _c
+}
+
+func (_c *DLQService_ReplayDLQ_Call) Return(_a0 []entity.DLQReplayResult, _a1 error) *DLQService_ReplayDLQ_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DLQService_ReplayDLQ_Call) RunAndReturn(run func(context.Context, entity.DLQReplayRequest) ([]entity.DLQReplayResult, error)) *DLQService_ReplayDLQ_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDLQService creates a new instance of DLQService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDLQService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DLQService {
+	mock := &DLQService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
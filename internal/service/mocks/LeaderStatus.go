@@ -0,0 +1,77 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// LeaderStatus is an autogenerated mock type for the LeaderStatus type
+type LeaderStatus struct {
+	mock.Mock
+}
+
+type LeaderStatus_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LeaderStatus) EXPECT() *LeaderStatus_Expecter {
+	return &LeaderStatus_Expecter{mock: &_m.Mock}
+}
+
+// IsLeader provides a mock function with no fields
+func (_m *LeaderStatus) IsLeader() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsLeader")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// LeaderStatus_IsLeader_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsLeader'
+type LeaderStatus_IsLeader_Call struct {
+	*mock.Call
+}
+
+// IsLeader is a helper method to define mock.On call
+func (_e *LeaderStatus_Expecter) IsLeader() *LeaderStatus_IsLeader_Call {
+	return &LeaderStatus_IsLeader_Call{Call: _e.mock.On("IsLeader")}
+}
+
+func (_c *LeaderStatus_IsLeader_Call) Run(run func()) *LeaderStatus_IsLeader_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *LeaderStatus_IsLeader_Call) Return(_a0 bool) *LeaderStatus_IsLeader_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LeaderStatus_IsLeader_Call) RunAndReturn(run func() bool) *LeaderStatus_IsLeader_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLeaderStatus creates a new instance of LeaderStatus. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLeaderStatus(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LeaderStatus {
+	mock := &LeaderStatus{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
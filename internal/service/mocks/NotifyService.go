@@ -127,6 +127,123 @@ func (_c *NotifyService_DeleteNotify_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// GetAttachment provides a mock function with given fields: ctx, notifyID, attachmentID
+func (_m *NotifyService) GetAttachment(ctx context.Context, notifyID string, attachmentID string) (entity.Attachment, error) {
+	ret := _m.Called(ctx, notifyID, attachmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttachment")
+	}
+
+	var r0 entity.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (entity.Attachment, error)); ok {
+		return rf(ctx, notifyID, attachmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) entity.Attachment); ok {
+		r0 = rf(ctx, notifyID, attachmentID)
+	} else {
+		r0 = ret.Get(0).(entity.Attachment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, notifyID, attachmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyService_GetAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAttachment'
+type NotifyService_GetAttachment_Call struct {
+	*mock.Call
+}
+
+// GetAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+//   - attachmentID string
+func (_e *NotifyService_Expecter) GetAttachment(ctx interface{}, notifyID interface{}, attachmentID interface{}) *NotifyService_GetAttachment_Call {
+	return &NotifyService_GetAttachment_Call{Call: _e.mock.On("GetAttachment", ctx, notifyID, attachmentID)}
+}
+
+func (_c *NotifyService_GetAttachment_Call) Run(run func(ctx context.Context, notifyID string, attachmentID string)) *NotifyService_GetAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *NotifyService_GetAttachment_Call) Return(_a0 entity.Attachment, _a1 error) *NotifyService_GetAttachment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyService_GetAttachment_Call) RunAndReturn(run func(context.Context, string, string) (entity.Attachment, error)) *NotifyService_GetAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, notifyID
+func (_m *NotifyService) GetDeliveries(ctx context.Context, notifyID string) ([]entity.Delivery, error) {
+	ret := _m.Called(ctx, notifyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveries")
+	}
+
+	var r0 []entity.Delivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]entity.Delivery, error)); ok {
+		return rf(ctx, notifyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []entity.Delivery); ok {
+		r0 = rf(ctx, notifyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Delivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, notifyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NotifyService_GetDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeliveries'
+type NotifyService_GetDeliveries_Call struct {
+	*mock.Call
+}
+
+// GetDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+func (_e *NotifyService_Expecter) GetDeliveries(ctx interface{}, notifyID interface{}) *NotifyService_GetDeliveries_Call {
+	return &NotifyService_GetDeliveries_Call{Call: _e.mock.On("GetDeliveries", ctx, notifyID)}
+}
+
+func (_c *NotifyService_GetDeliveries_Call) Run(run func(ctx context.Context, notifyID string)) *NotifyService_GetDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *NotifyService_GetDeliveries_Call) Return(_a0 []entity.Delivery, _a1 error) *NotifyService_GetDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NotifyService_GetDeliveries_Call) RunAndReturn(run func(context.Context, string) ([]entity.Delivery, error)) *NotifyService_GetDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetNotify provides a mock function with given fields: ctx, notifyID
 func (_m *NotifyService) GetNotify(ctx context.Context, notifyID string) (entity.Notify, error) {
 	ret := _m.Called(ctx, notifyID)
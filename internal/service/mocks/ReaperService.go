@@ -0,0 +1,94 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	entity "delayed-notifier/internal/entity"
+)
+
+// ReaperService is an autogenerated mock type for the ReaperService type
+type ReaperService struct {
+	mock.Mock
+}
+
+type ReaperService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ReaperService) EXPECT() *ReaperService_Expecter {
+	return &ReaperService_Expecter{mock: &_m.Mock}
+}
+
+// ReapStuckNotifies provides a mock function with given fields: ctx
+func (_m *ReaperService) ReapStuckNotifies(ctx context.Context) (entity.ReapReport, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReapStuckNotifies")
+	}
+
+	var r0 entity.ReapReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (entity.ReapReport, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) entity.ReapReport); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(entity.ReapReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReaperService_ReapStuckNotifies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReapStuckNotifies'
+type ReaperService_ReapStuckNotifies_Call struct {
+	*mock.Call
+}
+
+// ReapStuckNotifies is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ReaperService_Expecter) ReapStuckNotifies(ctx interface{}) *ReaperService_ReapStuckNotifies_Call {
+	return &ReaperService_ReapStuckNotifies_Call{Call: _e.mock.On("ReapStuckNotifies", ctx)}
+}
+
+func (_c *ReaperService_ReapStuckNotifies_Call) Run(run func(ctx context.Context)) *ReaperService_ReapStuckNotifies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ReaperService_ReapStuckNotifies_Call) Return(_a0 entity.ReapReport, _a1 error) *ReaperService_ReapStuckNotifies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ReaperService_ReapStuckNotifies_Call) RunAndReturn(run func(context.Context) (entity.ReapReport, error)) *ReaperService_ReapStuckNotifies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewReaperService creates a new instance of ReaperService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReaperService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReaperService {
+	mock := &ReaperService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
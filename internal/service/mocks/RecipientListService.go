@@ -0,0 +1,314 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	entity "delayed-notifier/internal/entity"
+)
+
+// RecipientListService is an autogenerated mock type for the RecipientListService type
+type RecipientListService struct {
+	mock.Mock
+}
+
+type RecipientListService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *RecipientListService) EXPECT() *RecipientListService_Expecter {
+	return &RecipientListService_Expecter{mock: &_m.Mock}
+}
+
+// CreateRecipientList provides a mock function with given fields: ctx, list
+func (_m *RecipientListService) CreateRecipientList(ctx context.Context, list entity.RecipientList) (entity.RecipientList, error) {
+	ret := _m.Called(ctx, list)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRecipientList")
+	}
+
+	var r0 entity.RecipientList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.RecipientList) (entity.RecipientList, error)); ok {
+		return rf(ctx, list)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.RecipientList) entity.RecipientList); ok {
+		r0 = rf(ctx, list)
+	} else {
+		r0 = ret.Get(0).(entity.RecipientList)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.RecipientList) error); ok {
+		r1 = rf(ctx, list)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecipientListService_CreateRecipientList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRecipientList'
+type RecipientListService_CreateRecipientList_Call struct {
+	*mock.Call
+}
+
+// CreateRecipientList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - list entity.RecipientList
+func (_e *RecipientListService_Expecter) CreateRecipientList(ctx interface{}, list interface{}) *RecipientListService_CreateRecipientList_Call {
+	return &RecipientListService_CreateRecipientList_Call{Call: _e.mock.On("CreateRecipientList", ctx, list)}
+}
+
+func (_c *RecipientListService_CreateRecipientList_Call) Run(run func(ctx context.Context, list entity.RecipientList)) *RecipientListService_CreateRecipientList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.RecipientList))
+	})
+	return _c
+}
+
+func (_c *RecipientListService_CreateRecipientList_Call) Return(_a0 entity.RecipientList, _a1 error) *RecipientListService_CreateRecipientList_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RecipientListService_CreateRecipientList_Call) RunAndReturn(run func(context.Context, entity.RecipientList) (entity.RecipientList, error)) *RecipientListService_CreateRecipientList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRecipientList provides a mock function with given fields: ctx, listID
+func (_m *RecipientListService) DeleteRecipientList(ctx context.Context, listID string) error {
+	ret := _m.Called(ctx, listID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRecipientList")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, listID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecipientListService_DeleteRecipientList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRecipientList'
+type RecipientListService_DeleteRecipientList_Call struct {
+	*mock.Call
+}
+
+// DeleteRecipientList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - listID string
+func (_e *RecipientListService_Expecter) DeleteRecipientList(ctx interface{}, listID interface{}) *RecipientListService_DeleteRecipientList_Call {
+	return &RecipientListService_DeleteRecipientList_Call{Call: _e.mock.On("DeleteRecipientList", ctx, listID)}
+}
+
+func (_c *RecipientListService_DeleteRecipientList_Call) Run(run func(ctx context.Context, listID string)) *RecipientListService_DeleteRecipientList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RecipientListService_DeleteRecipientList_Call) Return(_a0 error) *RecipientListService_DeleteRecipientList_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *RecipientListService_DeleteRecipientList_Call) RunAndReturn(run func(context.Context, string) error) *RecipientListService_DeleteRecipientList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRecipientList provides a mock function with given fields: ctx, listID
+func (_m *RecipientListService) GetRecipientList(ctx context.Context, listID string) (entity.RecipientList, error) {
+	ret := _m.Called(ctx, listID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecipientList")
+	}
+
+	var r0 entity.RecipientList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.RecipientList, error)); ok {
+		return rf(ctx, listID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.RecipientList); ok {
+		r0 = rf(ctx, listID)
+	} else {
+		r0 = ret.Get(0).(entity.RecipientList)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, listID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecipientListService_GetRecipientList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecipientList'
+type RecipientListService_GetRecipientList_Call struct {
+	*mock.Call
+}
+
+// GetRecipientList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - listID string
+func (_e *RecipientListService_Expecter) GetRecipientList(ctx interface{}, listID interface{}) *RecipientListService_GetRecipientList_Call {
+	return &RecipientListService_GetRecipientList_Call{Call: _e.mock.On("GetRecipientList", ctx, listID)}
+}
+
+func (_c *RecipientListService_GetRecipientList_Call) Run(run func(ctx context.Context, listID string)) *RecipientListService_GetRecipientList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *RecipientListService_GetRecipientList_Call) Return(_a0 entity.RecipientList, _a1 error) *RecipientListService_GetRecipientList_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RecipientListService_GetRecipientList_Call) RunAndReturn(run func(context.Context, string) (entity.RecipientList, error)) *RecipientListService_GetRecipientList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRecipientLists provides a mock function with given fields: ctx
+func (_m *RecipientListService) ListRecipientLists(ctx context.Context) ([]entity.RecipientList, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRecipientLists")
+	}
+
+	var r0 []entity.RecipientList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]entity.RecipientList, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.RecipientList); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.RecipientList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecipientListService_ListRecipientLists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRecipientLists'
+type RecipientListService_ListRecipientLists_Call struct {
+	*mock.Call
+}
+
+// ListRecipientLists is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *RecipientListService_Expecter) ListRecipientLists(ctx interface{}) *RecipientListService_ListRecipientLists_Call {
+	return &RecipientListService_ListRecipientLists_Call{Call: _e.mock.On("ListRecipientLists", ctx)}
+}
+
+func (_c *RecipientListService_ListRecipientLists_Call) Run(run func(ctx context.Context)) *RecipientListService_ListRecipientLists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *RecipientListService_ListRecipientLists_Call) Return(_a0 []entity.RecipientList, _a1 error) *RecipientListService_ListRecipientLists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RecipientListService_ListRecipientLists_Call) RunAndReturn(run func(context.Context) ([]entity.RecipientList, error)) *RecipientListService_ListRecipientLists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRecipientList provides a mock function with given fields: ctx, list
+func (_m *RecipientListService) UpdateRecipientList(ctx context.Context, list entity.RecipientList) (entity.RecipientList, error) {
+	ret := _m.Called(ctx, list)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRecipientList")
+	}
+
+	var r0 entity.RecipientList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.RecipientList) (entity.RecipientList, error)); ok {
+		return rf(ctx, list)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.RecipientList) entity.RecipientList); ok {
+		r0 = rf(ctx, list)
+	} else {
+		r0 = ret.Get(0).(entity.RecipientList)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.RecipientList) error); ok {
+		r1 = rf(ctx, list)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecipientListService_UpdateRecipientList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRecipientList'
+type RecipientListService_UpdateRecipientList_Call struct {
+	*mock.Call
+}
+
+// UpdateRecipientList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - list entity.RecipientList
+func (_e *RecipientListService_Expecter) UpdateRecipientList(ctx interface{}, list interface{}) *RecipientListService_UpdateRecipientList_Call {
+	return &RecipientListService_UpdateRecipientList_Call{Call: _e.mock.On("UpdateRecipientList", ctx, list)}
+}
+
+func (_c *RecipientListService_UpdateRecipientList_Call) Run(run func(ctx context.Context, list entity.RecipientList)) *RecipientListService_UpdateRecipientList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.RecipientList))
+	})
+	return _c
+}
+
+func (_c *RecipientListService_UpdateRecipientList_Call) Return(_a0 entity.RecipientList, _a1 error) *RecipientListService_UpdateRecipientList_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *RecipientListService_UpdateRecipientList_Call) RunAndReturn(run func(context.Context, entity.RecipientList) (entity.RecipientList, error)) *RecipientListService_UpdateRecipientList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRecipientListService creates a new instance of RecipientListService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRecipientListService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RecipientListService {
+	mock := &RecipientListService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
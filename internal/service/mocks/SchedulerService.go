@@ -0,0 +1,158 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+
+	entity "delayed-notifier/internal/entity"
+)
+
+// SchedulerService is an autogenerated mock type for the SchedulerService type
+type SchedulerService struct {
+	mock.Mock
+}
+
+type SchedulerService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SchedulerService) EXPECT() *SchedulerService_Expecter {
+	return &SchedulerService_Expecter{mock: &_m.Mock}
+}
+
+// NextSendAt provides a mock function with given fields: ctx
+func (_m *SchedulerService) NextSendAt(ctx context.Context) (time.Time, bool, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NextSendAt")
+	}
+
+	var r0 time.Time
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (time.Time, bool, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) time.Time); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) bool); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SchedulerService_NextSendAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NextSendAt'
+type SchedulerService_NextSendAt_Call struct {
+	*mock.Call
+}
+
+// NextSendAt is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *SchedulerService_Expecter) NextSendAt(ctx interface{}) *SchedulerService_NextSendAt_Call {
+	return &SchedulerService_NextSendAt_Call{Call: _e.mock.On("NextSendAt", ctx)}
+}
+
+func (_c *SchedulerService_NextSendAt_Call) Run(run func(ctx context.Context)) *SchedulerService_NextSendAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *SchedulerService_NextSendAt_Call) Return(_a0 time.Time, _a1 bool, _a2 error) *SchedulerService_NextSendAt_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *SchedulerService_NextSendAt_Call) RunAndReturn(run func(context.Context) (time.Time, bool, error)) *SchedulerService_NextSendAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleReadyNotifies provides a mock function with given fields: ctx
+func (_m *SchedulerService) ScheduleReadyNotifies(ctx context.Context) (entity.ScheduleReport, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleReadyNotifies")
+	}
+
+	var r0 entity.ScheduleReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (entity.ScheduleReport, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) entity.ScheduleReport); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(entity.ScheduleReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SchedulerService_ScheduleReadyNotifies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleReadyNotifies'
+type SchedulerService_ScheduleReadyNotifies_Call struct {
+	*mock.Call
+}
+
+// ScheduleReadyNotifies is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *SchedulerService_Expecter) ScheduleReadyNotifies(ctx interface{}) *SchedulerService_ScheduleReadyNotifies_Call {
+	return &SchedulerService_ScheduleReadyNotifies_Call{Call: _e.mock.On("ScheduleReadyNotifies", ctx)}
+}
+
+func (_c *SchedulerService_ScheduleReadyNotifies_Call) Run(run func(ctx context.Context)) *SchedulerService_ScheduleReadyNotifies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *SchedulerService_ScheduleReadyNotifies_Call) Return(_a0 entity.ScheduleReport, _a1 error) *SchedulerService_ScheduleReadyNotifies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SchedulerService_ScheduleReadyNotifies_Call) RunAndReturn(run func(context.Context) (entity.ScheduleReport, error)) *SchedulerService_ScheduleReadyNotifies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSchedulerService creates a new instance of SchedulerService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSchedulerService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SchedulerService {
+	mock := &SchedulerService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,201 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	entity "delayed-notifier/internal/entity"
+)
+
+// SuppressionService is an autogenerated mock type for the SuppressionService type
+type SuppressionService struct {
+	mock.Mock
+}
+
+type SuppressionService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SuppressionService) EXPECT() *SuppressionService_Expecter {
+	return &SuppressionService_Expecter{mock: &_m.Mock}
+}
+
+// DeleteSuppression provides a mock function with given fields: ctx, email
+func (_m *SuppressionService) DeleteSuppression(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSuppression")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SuppressionService_DeleteSuppression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSuppression'
+type SuppressionService_DeleteSuppression_Call struct {
+	*mock.Call
+}
+
+// DeleteSuppression is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *SuppressionService_Expecter) DeleteSuppression(ctx interface{}, email interface{}) *SuppressionService_DeleteSuppression_Call {
+	return &SuppressionService_DeleteSuppression_Call{Call: _e.mock.On("DeleteSuppression", ctx, email)}
+}
+
+func (_c *SuppressionService_DeleteSuppression_Call) Run(run func(ctx context.Context, email string)) *SuppressionService_DeleteSuppression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SuppressionService_DeleteSuppression_Call) Return(_a0 error) *SuppressionService_DeleteSuppression_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SuppressionService_DeleteSuppression_Call) RunAndReturn(run func(context.Context, string) error) *SuppressionService_DeleteSuppression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSuppression provides a mock function with given fields: ctx, email
+func (_m *SuppressionService) GetSuppression(ctx context.Context, email string) (entity.Suppression, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSuppression")
+	}
+
+	var r0 entity.Suppression
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (entity.Suppression, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.Suppression); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(entity.Suppression)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SuppressionService_GetSuppression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSuppression'
+type SuppressionService_GetSuppression_Call struct {
+	*mock.Call
+}
+
+// GetSuppression is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *SuppressionService_Expecter) GetSuppression(ctx interface{}, email interface{}) *SuppressionService_GetSuppression_Call {
+	return &SuppressionService_GetSuppression_Call{Call: _e.mock.On("GetSuppression", ctx, email)}
+}
+
+func (_c *SuppressionService_GetSuppression_Call) Run(run func(ctx context.Context, email string)) *SuppressionService_GetSuppression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SuppressionService_GetSuppression_Call) Return(_a0 entity.Suppression, _a1 error) *SuppressionService_GetSuppression_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SuppressionService_GetSuppression_Call) RunAndReturn(run func(context.Context, string) (entity.Suppression, error)) *SuppressionService_GetSuppression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSuppressions provides a mock function with given fields: ctx, filter
+func (_m *SuppressionService) ListSuppressions(ctx context.Context, filter entity.SuppressionFilter) ([]entity.Suppression, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSuppressions")
+	}
+
+	var r0 []entity.Suppression
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.SuppressionFilter) ([]entity.Suppression, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.SuppressionFilter) []entity.Suppression); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Suppression)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.SuppressionFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SuppressionService_ListSuppressions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSuppressions'
+type SuppressionService_ListSuppressions_Call struct {
+	*mock.Call
+}
+
+// ListSuppressions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter entity.SuppressionFilter
+func (_e *SuppressionService_Expecter) ListSuppressions(ctx interface{}, filter interface{}) *SuppressionService_ListSuppressions_Call {
+	return &SuppressionService_ListSuppressions_Call{Call: _e.mock.On("ListSuppressions", ctx, filter)}
+}
+
+func (_c *SuppressionService_ListSuppressions_Call) Run(run func(ctx context.Context, filter entity.SuppressionFilter)) *SuppressionService_ListSuppressions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(entity.SuppressionFilter))
+	})
+	return _c
+}
+
+func (_c *SuppressionService_ListSuppressions_Call) Return(_a0 []entity.Suppression, _a1 error) *SuppressionService_ListSuppressions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SuppressionService_ListSuppressions_Call) RunAndReturn(run func(context.Context, entity.SuppressionFilter) ([]entity.Suppression, error)) *SuppressionService_ListSuppressions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSuppressionService creates a new instance of SuppressionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSuppressionService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SuppressionService {
+	mock := &SuppressionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
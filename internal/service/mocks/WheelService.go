@@ -0,0 +1,145 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+
+	entity "delayed-notifier/internal/entity"
+)
+
+// WheelService is an autogenerated mock type for the WheelService type
+type WheelService struct {
+	mock.Mock
+}
+
+type WheelService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *WheelService) EXPECT() *WheelService_Expecter {
+	return &WheelService_Expecter{mock: &_m.Mock}
+}
+
+// DispatchNotify provides a mock function with given fields: ctx, notifyID
+func (_m *WheelService) DispatchNotify(ctx context.Context, notifyID string) error {
+	ret := _m.Called(ctx, notifyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DispatchNotify")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, notifyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WheelService_DispatchNotify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DispatchNotify'
+type WheelService_DispatchNotify_Call struct {
+	*mock.Call
+}
+
+// DispatchNotify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifyID string
+func (_e *WheelService_Expecter) DispatchNotify(ctx interface{}, notifyID interface{}) *WheelService_DispatchNotify_Call {
+	return &WheelService_DispatchNotify_Call{Call: _e.mock.On("DispatchNotify", ctx, notifyID)}
+}
+
+func (_c *WheelService_DispatchNotify_Call) Run(run func(ctx context.Context, notifyID string)) *WheelService_DispatchNotify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *WheelService_DispatchNotify_Call) Return(_a0 error) *WheelService_DispatchNotify_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *WheelService_DispatchNotify_Call) RunAndReturn(run func(context.Context, string) error) *WheelService_DispatchNotify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUpcomingNotifies provides a mock function with given fields: ctx, until
+func (_m *WheelService) GetUpcomingNotifies(ctx context.Context, until time.Time) ([]entity.Notify, error) {
+	ret := _m.Called(ctx, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUpcomingNotifies")
+	}
+
+	var r0 []entity.Notify
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]entity.Notify, error)); ok {
+		return rf(ctx, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []entity.Notify); ok {
+		r0 = rf(ctx, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Notify)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WheelService_GetUpcomingNotifies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUpcomingNotifies'
+type WheelService_GetUpcomingNotifies_Call struct {
+	*mock.Call
+}
+
+// GetUpcomingNotifies is a helper method to define mock.On call
+//   - ctx context.Context
+//   - until time.Time
+func (_e *WheelService_Expecter) GetUpcomingNotifies(ctx interface{}, until interface{}) *WheelService_GetUpcomingNotifies_Call {
+	return &WheelService_GetUpcomingNotifies_Call{Call: _e.mock.On("GetUpcomingNotifies", ctx, until)}
+}
+
+func (_c *WheelService_GetUpcomingNotifies_Call) Run(run func(ctx context.Context, until time.Time)) *WheelService_GetUpcomingNotifies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *WheelService_GetUpcomingNotifies_Call) Return(_a0 []entity.Notify, _a1 error) *WheelService_GetUpcomingNotifies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *WheelService_GetUpcomingNotifies_Call) RunAndReturn(run func(context.Context, time.Time) ([]entity.Notify, error)) *WheelService_GetUpcomingNotifies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewWheelService creates a new instance of WheelService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWheelService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WheelService {
+	mock := &WheelService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -2,107 +2,648 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
 	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/requestid"
 )
 
 type NotifyDBRepository interface {
 	CreateNotify(ctx context.Context, notify entity.Notify) (entity.Notify, error)
 	GetNotify(ctx context.Context, notifyID string) (entity.Notify, error)
 	DeleteNotify(ctx context.Context, notifyID string) error
-	GetReadyNotifies(ctx context.Context) ([]entity.Notify, error)
-	UpdateNotifyStatus(ctx context.Context, notifyID, status string) error
+	GetReadyNotifies(ctx context.Context, limit int) ([]entity.Notify, error)
+	CountReadyNotifies(ctx context.Context, before time.Time) (int, error)
+	UpdateNotifyStatus(ctx context.Context, notifyID, status string) (entity.Notify, error)
+	ClaimNotify(ctx context.Context, notifyID string) (entity.Notify, bool, error)
+	// ClaimSending атомарно переводит уведомление в sending; false — отправлять не нужно
+	ClaimSending(ctx context.Context, notifyID string) (entity.Notify, bool, error)
+	SkipNotify(ctx context.Context, notifyID string) (entity.Notify, bool, error)
+	RescheduleNotify(ctx context.Context, notifyID string, sendAt time.Time) (entity.Notify, bool, error)
+	GetStuckNotifies(ctx context.Context, before time.Time, limit int) ([]entity.StuckNotify, error)
+	RequeueStuckNotify(ctx context.Context, notifyID string, before time.Time) (bool, error)
+	FailStuckNotify(ctx context.Context, notifyID string, before time.Time) (entity.Notify, bool, error)
+	AddNotifyEvent(ctx context.Context, event entity.NotifyEvent) error
+	GetUpcomingNotifies(ctx context.Context, until time.Time) ([]entity.Notify, error)
+	GetScheduledSendTimes(ctx context.Context) (map[string]time.Time, error)
+	GetNextSendAt(ctx context.Context) (time.Time, bool, error)
+	GetRecipientList(ctx context.Context, listID string) (entity.RecipientList, error)
+	// CreateDeliveries добавляет недостающие доставки и возвращает все доставки уведомления
+	CreateDeliveries(ctx context.Context, notifyID string, deliveries []entity.Delivery) ([]entity.Delivery, error)
+	GetDeliveries(ctx context.Context, notifyID string) ([]entity.Delivery, error)
+	// UpdateDelivery записывает результат попытки отправки одному получателю
+	UpdateDelivery(ctx context.Context, notifyID, email, status, lastError string) error
+	// ResetDeliveries возвращает все доставки уведомления в pending для повторной отправки
+	ResetDeliveries(ctx context.Context, notifyID string) error
+	// GetExpiredAttachments возвращает завершённые уведомления с send_at до before,
+	// вложения которых ещё не удалены
+	GetExpiredAttachments(ctx context.Context, before time.Time, limit int) ([]entity.Notify, error)
+	MarkAttachmentsPurged(ctx context.Context, notifyID string) error
 }
 
 type NotifyCacheRepository interface {
-	SetNotify(ctx context.Context, notify entity.Notify, ttl time.Duration) error
+	// SetNotify записывает уведомление, если в кэше нет более новой версии
+	SetNotify(ctx context.Context, notify entity.Notify) error
 	GetNotify(ctx context.Context, notifyID string) (entity.Notify, error)
 	DeleteNotify(ctx context.Context, notifyID string) error
+	// SetMissing запоминает, что уведомления нет, чтобы повторные запросы не шли в БД
+	SetMissing(ctx context.Context, notifyID string) error
+}
+
+type NotifyScheduleRepository interface {
+	Add(ctx context.Context, notifyID string, sendAt time.Time) error
+	Remove(ctx context.Context, notifyID string) error
+	PopDue(ctx context.Context, now time.Time, limit int) ([]string, error)
+	Members(ctx context.Context) (map[string]time.Time, error)
+	Next(ctx context.Context) (time.Time, bool, error)
 }
 
 type NotifyProducer interface {
 	Send(ctx context.Context, notify entity.Notify) error
 }
 
+// SuppressionRepository хранит список подавления. Адреса сравниваются без учёта регистра.
+type SuppressionRepository interface {
+	// FindSuppressions возвращает записи для тех адресов из emails, что есть в списке
+	FindSuppressions(ctx context.Context, emails []string) ([]entity.Suppression, error)
+	// AddSuppression добавляет адрес; повторное добавление не меняет существующую запись
+	AddSuppression(ctx context.Context, suppression entity.Suppression) error
+	ListSuppressions(ctx context.Context, filter entity.SuppressionFilter) ([]entity.Suppression, error)
+	GetSuppression(ctx context.Context, email string) (entity.Suppression, error)
+	DeleteSuppression(ctx context.Context, email string) error
+}
+
+// Notifier отправляет уведомление одному получателю; заголовки To и Cc письма
+// берутся из самого уведомления.
 type Notifier interface {
-	Send(ctx context.Context, notify entity.Notify) error
+	Send(ctx context.Context, notify entity.Notify, recipient string) error
 }
 
+const defaultBatchSize = 500
+
 type NotifyService struct {
-	db       NotifyDBRepository
-	cache    NotifyCacheRepository
-	producer NotifyProducer
-	notifier Notifier
-	logger   *slog.Logger
+	db               NotifyDBRepository
+	cache            NotifyCacheRepository
+	producer         NotifyProducer
+	notifier         Notifier
+	schedule         NotifyScheduleRepository
+	batchSize        int
+	catchUp          entity.CatchUpPolicy
+	spread           spreadState
+	reap             entity.ReapPolicy
+	attachments      AttachmentStore
+	attachmentPolicy entity.AttachmentPolicy
+	senders          []string
+	suppressions     SuppressionRepository
+	loads            singleflight.Group
+	stats            cacheStats
+	logger           *slog.Logger
+}
+
+type Option func(*NotifyService)
+
+// WithScheduleRepository включает планирование через внешнюю очередь (Redis ZSET)
+// вместо опроса PostgreSQL. БД при этом остаётся источником истины.
+func WithScheduleRepository(schedule NotifyScheduleRepository) Option {
+	return func(s *NotifyService) {
+		s.schedule = schedule
+	}
+}
+
+// WithBatchSize ограничивает число уведомлений, обрабатываемых за один прогон планировщика.
+func WithBatchSize(size int) Option {
+	return func(s *NotifyService) {
+		s.batchSize = size
+	}
+}
+
+// WithCatchUpPolicy задаёт обработку уведомлений, опоздавших из-за простоя планировщика.
+func WithCatchUpPolicy(policy entity.CatchUpPolicy) Option {
+	return func(s *NotifyService) {
+		s.catchUp = policy
+	}
+}
+
+// WithReapPolicy задаёт порог и число попыток для восстановления зависших уведомлений.
+func WithReapPolicy(policy entity.ReapPolicy) Option {
+	return func(s *NotifyService) {
+		s.reap = policy
+	}
+}
+
+// WithAttachments включает вложения: содержимое хранится в store, ограничения задаёт policy.
+// Без этой опции уведомления с вложениями отклоняются.
+func WithAttachments(store AttachmentStore, policy entity.AttachmentPolicy) Option {
+	return func(s *NotifyService) {
+		s.attachments = store
+		s.attachmentPolicy = policy
+	}
+}
+
+// WithSenders задаёт имена отправителей, которых клиенты могут указать в sender.
+// Без этой опции допустим только отправитель по умолчанию. Список общий для всех
+// клиентов: вызывающий не аутентифицируется, и привязки отправителей к тенанту нет.
+func WithSenders(names []string) Option {
+	return func(s *NotifyService) {
+		s.senders = names
+	}
 }
 
-func NewNotifyService(db NotifyDBRepository, cache NotifyCacheRepository, producer NotifyProducer, notifier Notifier, logger *slog.Logger) *NotifyService {
-	return &NotifyService{db: db, cache: cache, producer: producer, logger: logger, notifier: notifier}
+// WithSuppressions включает список подавления: уведомления с адресами из него
+// отклоняются, участники списков получателей из него пропускаются при отправке,
+// а адреса с постоянным отказом сервера (bounce) добавляются в него.
+func WithSuppressions(repo SuppressionRepository) Option {
+	return func(s *NotifyService) {
+		s.suppressions = repo
+	}
+}
+
+func NewNotifyService(db NotifyDBRepository, cache NotifyCacheRepository, producer NotifyProducer, notifier Notifier, logger *slog.Logger, opts ...Option) *NotifyService {
+	s := &NotifyService{
+		db:        db,
+		cache:     cache,
+		producer:  producer,
+		logger:    logger,
+		notifier:  notifier,
+		batchSize: defaultBatchSize,
+		catchUp:   entity.CatchUpPolicy{Mode: entity.CatchUpAll, LateAfter: time.Minute},
+		reap:      entity.ReapPolicy{Threshold: 30 * time.Minute, MaxReaps: 3},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *NotifyService) CreateNotify(ctx context.Context, notify entity.Notify) (entity.Notify, error) {
+	// Списки раскрываются при отправке, но ссылку на несуществующий список
+	// лучше отклонить сразу, а не ронять отправку
+	var errs entity.ValidationError
+	for i, listID := range notify.RecipientLists {
+		if _, err := s.db.GetRecipientList(ctx, listID); err != nil {
+			if !errors.Is(err, entity.ErrRecipientListNotFound) {
+				return entity.Notify{}, fmt.Errorf("CreateNotify: %w", err)
+			}
+			errs = append(errs, entity.FieldError{
+				Field:   fmt.Sprintf("recipient_lists.%d", i),
+				Message: "recipient list " + listID + " not found",
+			})
+		}
+	}
+	if notify.Sender != "" && notify.Sender != entity.DefaultSender && !slices.Contains(s.senders, notify.Sender) {
+		errs = append(errs, entity.FieldError{Field: "sender", Message: "sender " + notify.Sender + " is not allowed"})
+	}
+	suppressed, err := s.checkSuppressions(ctx, notify)
+	if err != nil {
+		return entity.Notify{}, fmt.Errorf("CreateNotify: %w", err)
+	}
+	errs = append(errs, suppressed...)
+	errs = append(errs, s.checkAttachments(notify.Attachments)...)
+	if len(errs) > 0 {
+		return entity.Notify{}, errs
+	}
+
+	// Содержимое кладётся в хранилище до записи в БД, чтобы воркер не увидел
+	// уведомление без файлов; при ошибке записи файлы удаляются
+	attachments, err := s.storeAttachments(ctx, notify.Attachments)
+	if err != nil {
+		return entity.Notify{}, fmt.Errorf("CreateNotify: %w", err)
+	}
+	notify.Attachments = attachments
 	created, err := s.db.CreateNotify(ctx, notify)
 	if err != nil {
+		s.deleteAttachments(ctx, attachments)
 		return entity.Notify{}, err
 	}
-	_ = s.cache.SetNotify(ctx, created, 24*time.Hour)
+	_ = s.cache.SetNotify(ctx, created)
+	if s.schedule != nil {
+		// Расхождение с БД исправит ReconcileSchedule
+		if err := s.schedule.Add(ctx, created.ID, created.SendAt); err != nil {
+			s.logger.WarnContext(ctx, "CreateNotify: failed to add notify to schedule", slog.String("ID", created.ID), slog.Any("error", err))
+		}
+	}
 	return created, nil
 }
 
 func (s *NotifyService) GetNotify(ctx context.Context, notifyID string) (entity.Notify, error) {
 	notify, err := s.cache.GetNotify(ctx, notifyID)
 	if err == nil {
+		s.stats.hits.Add(1)
 		return notify, nil
 	}
-	notify, err = s.db.GetNotify(ctx, notifyID)
+	if errors.Is(err, entity.ErrNotifyNotFound) {
+		s.stats.negativeHits.Add(1)
+		return entity.Notify{}, err
+	}
+	s.stats.misses.Add(1)
+
+	// Одновременные промахи по одному ID идут в БД одним запросом. Отмена запроса
+	// первого клиента не должна ронять остальных, поэтому контекст без отмены
+	loadCtx := context.WithoutCancel(ctx)
+	leader := false
+	v, err, _ := s.loads.Do(notifyID, func() (any, error) {
+		leader = true
+		notify, err := s.db.GetNotify(loadCtx, notifyID)
+		if err != nil {
+			if errors.Is(err, entity.ErrNotifyNotFound) {
+				_ = s.cache.SetMissing(loadCtx, notifyID)
+			}
+			return entity.Notify{}, err
+		}
+		_ = s.cache.SetNotify(loadCtx, notify)
+		return notify, nil
+	})
+	if !leader {
+		s.stats.coalesced.Add(1)
+	}
 	if err != nil {
 		return entity.Notify{}, err
 	}
-	_ = s.cache.SetNotify(ctx, notify, 24*time.Hour)
-	return notify, nil
+	return v.(entity.Notify), nil
 }
 
 func (s *NotifyService) DeleteNotify(ctx context.Context, notifyID string) error {
+	// Описания вложений удаляются вместе со строкой, поэтому читаются до удаления
+	var (
+		notify entity.Notify
+		getErr error
+	)
+	if s.attachments != nil {
+		notify, getErr = s.db.GetNotify(ctx, notifyID)
+	}
+	if err := s.db.DeleteNotify(ctx, notifyID); err != nil {
+		return err
+	}
+
+	// Кэш чистится после БД, как и при обновлениях: иначе промах, пришедший между
+	// удалениями, снова закэширует удаляемую строку на весь TTL
 	_ = s.cache.DeleteNotify(ctx, notifyID)
-	return s.db.DeleteNotify(ctx, notifyID)
+	if s.schedule != nil {
+		_ = s.schedule.Remove(ctx, notifyID)
+	}
+	if s.attachments != nil && getErr == nil {
+		s.deleteAttachments(ctx, notify.Attachments)
+	}
+	return nil
 }
 
 func (s *NotifyService) UpdateNotifyStatus(ctx context.Context, notifyID, status string) error {
-	if err := s.db.UpdateNotifyStatus(ctx, notifyID, status); err != nil {
+	updated, err := s.db.UpdateNotifyStatus(ctx, notifyID, status)
+	if err != nil {
 		return err
 	}
-	_ = s.cache.DeleteNotify(ctx, notifyID)
+	_ = s.cache.SetNotify(ctx, updated)
 	return nil
 }
 
-func (s *NotifyService) ScheduleReadyNotifies(ctx context.Context) error {
-	notifies, err := s.db.GetReadyNotifies(ctx)
+// ScheduleReadyNotifies ставит в очередь одну пачку наступивших уведомлений,
+// применяя к опоздавшим политику догоняющей отправки.
+func (s *NotifyService) ScheduleReadyNotifies(ctx context.Context) (entity.ScheduleReport, error) {
+	if s.schedule != nil {
+		return s.scheduleFromQueue(ctx)
+	}
+
+	notifies, err := s.db.GetReadyNotifies(ctx, s.batchSize)
 	if err != nil {
-		return fmt.Errorf("ScheduleReadyNotifies: get ready notifies: %w", err)
+		return entity.ScheduleReport{}, fmt.Errorf("ScheduleReadyNotifies: get ready notifies: %w", err)
 	}
 
-	for _, notify := range notifies {
-		if err := s.producer.Send(ctx, notify); err != nil {
-			s.logger.Error("ScheduleReadyNotifies: failed to send notify", slog.String("ID", notify.ID), slog.Any("error", err))
+	report, err := s.dispatchBatch(ctx, notifies, time.Now())
+	if err != nil {
+		return report, fmt.Errorf("ScheduleReadyNotifies: %w", err)
+	}
+	// Если ни одно уведомление не ушло (например, Kafka недоступна), повторный прогон
+	// сразу вернёт ту же пачку
+	report.HasMore = len(notifies) == s.batchSize && report.Enqueued+report.Rescheduled+len(report.Skipped) > 0
+
+	return report, nil
+}
+
+// enqueue забирает уведомление (scheduled -> queued) и отправляет его в Kafka.
+// Захват в БД гарантирует, что планировщик и колесо таймеров не отправят одно
+// уведомление дважды. Возвращает true, если уведомление поставлено в очередь.
+func (s *NotifyService) enqueue(ctx context.Context, notify entity.Notify) (bool, error) {
+	ctx = requestid.NewContext(ctx, notify.RequestID)
+	claimed, ok, err := s.db.ClaimNotify(ctx, notify.ID)
+	if err != nil {
+		return false, fmt.Errorf("claim ID=%s: %w", notify.ID, err)
+	}
+	if !ok {
+		return false, nil
+	}
+	_ = s.cache.SetNotify(ctx, claimed)
+
+	if err := s.producer.Send(ctx, notify); err != nil {
+		s.logger.ErrorContext(ctx, "failed to send notify", slog.String("ID", notify.ID), slog.Any("error", err))
+		// Возвращаем уведомление планировщику, чтобы оно ушло при следующем прогоне
+		if err := s.UpdateNotifyStatus(ctx, notify.ID, entity.StatusScheduled); err != nil {
+			s.logger.ErrorContext(ctx, "failed to release notify", slog.String("ID", notify.ID), slog.Any("error", err))
+		}
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// DispatchNotify ставит в очередь одно уведомление, время которого наступило
+// (используется колесом таймеров).
+func (s *NotifyService) DispatchNotify(ctx context.Context, notifyID string) error {
+	notify, err := s.db.GetNotify(ctx, notifyID)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotifyNotFound) {
+			return nil
+		}
+		return fmt.Errorf("DispatchNotify: %w", err)
+	}
+
+	// Уведомление могли отправить, удалить или перенести на более позднее время
+	if notify.Status != entity.StatusScheduled || notify.SendAt.After(time.Now()) {
+		return nil
+	}
+	// Опоздавшие уведомления обрабатывает планировщик по политике догоняющей отправки
+	if s.isLate(notify, time.Now()) {
+		return nil
+	}
+
+	if _, err := s.enqueue(ctx, notify); err != nil {
+		return fmt.Errorf("DispatchNotify: %w", err)
+	}
+	return nil
+}
+
+func (s *NotifyService) GetUpcomingNotifies(ctx context.Context, until time.Time) ([]entity.Notify, error) {
+	return s.db.GetUpcomingNotifies(ctx, until)
+}
+
+// NextSendAt возвращает ближайшее время отправки среди запланированных уведомлений.
+func (s *NotifyService) NextSendAt(ctx context.Context) (time.Time, bool, error) {
+	if s.schedule != nil {
+		return s.schedule.Next(ctx)
+	}
+	return s.db.GetNextSendAt(ctx)
+}
+
+func (s *NotifyService) scheduleFromQueue(ctx context.Context) (entity.ScheduleReport, error) {
+	now := time.Now()
+	ids, err := s.schedule.PopDue(ctx, now, s.batchSize)
+	if err != nil {
+		return entity.ScheduleReport{}, fmt.Errorf("ScheduleReadyNotifies: pop due notifies: %w", err)
+	}
+
+	ready := make([]entity.Notify, 0, len(ids))
+	for i, id := range ids {
+		notify, err := s.db.GetNotify(ctx, id)
+		if err != nil {
+			if errors.Is(err, entity.ErrNotifyNotFound) {
+				continue
+			}
+			// Возвращаем в очередь всё, что ещё не обработано
+			for _, rest := range ids[i:] {
+				s.requeue(ctx, rest, now)
+			}
+			for _, notify := range ready {
+				s.requeue(ctx, notify.ID, notify.SendAt)
+			}
+			return entity.ScheduleReport{}, fmt.Errorf("ScheduleReadyNotifies: get notify ID=%s: %w", id, err)
+		}
+
+		// Уведомление могли удалить, отправить или перенести после попадания в очередь
+		if notify.Status != entity.StatusScheduled {
 			continue
 		}
+		if notify.SendAt.After(now) {
+			s.requeue(ctx, notify.ID, notify.SendAt)
+			continue
+		}
+		ready = append(ready, notify)
+	}
 
-		if err := s.UpdateNotifyStatus(ctx, notify.ID, entity.StatusQueued); err != nil {
-			return fmt.Errorf("ScheduleReadyNotifies: update status for ID=%s: %w", notify.ID, err)
+	report, err := s.dispatchBatch(ctx, ready, now)
+	if err != nil {
+		// Уже захваченные уведомления при следующем извлечении будут пропущены по статусу
+		for _, notify := range ready {
+			s.requeue(ctx, notify.ID, notify.SendAt)
 		}
+		return report, fmt.Errorf("ScheduleReadyNotifies: %w", err)
+	}
+	report.HasMore = len(ids) == s.batchSize && report.Enqueued+report.Rescheduled+len(report.Skipped) > 0
+
+	return report, nil
+}
+
+func (s *NotifyService) requeue(ctx context.Context, notifyID string, sendAt time.Time) {
+	if err := s.schedule.Add(ctx, notifyID, sendAt); err != nil {
+		s.logger.ErrorContext(ctx, "ScheduleReadyNotifies: failed to return notify to schedule", slog.String("ID", notifyID), slog.Any("error", err))
 	}
+}
 
+// ReconcileSchedule приводит очередь планирования в соответствие с БД:
+// добавляет недостающие запланированные уведомления и удаляет лишние.
+func (s *NotifyService) ReconcileSchedule(ctx context.Context) error {
+	if s.schedule == nil {
+		return nil
+	}
+
+	// Сначала очередь, потом БД: уведомление, созданное между чтениями, уже есть
+	// в снимке БД и не будет удалено из очереди как лишнее
+	members, err := s.schedule.Members(ctx)
+	if err != nil {
+		return fmt.Errorf("ReconcileSchedule: get schedule members: %w", err)
+	}
+	scheduled, err := s.db.GetScheduledSendTimes(ctx)
+	if err != nil {
+		return fmt.Errorf("ReconcileSchedule: get scheduled notifies: %w", err)
+	}
+
+	var added, removed int
+	for id, sendAt := range scheduled {
+		if at, ok := members[id]; ok && at.UnixMilli() == sendAt.UnixMilli() {
+			continue
+		}
+		if err := s.schedule.Add(ctx, id, sendAt); err != nil {
+			return fmt.Errorf("ReconcileSchedule: add ID=%s: %w", id, err)
+		}
+		added++
+	}
+	for id := range members {
+		if _, ok := scheduled[id]; ok {
+			continue
+		}
+		// Уведомление могли перенести или вернуть в работу после чтения БД
+		notify, err := s.db.GetNotify(ctx, id)
+		switch {
+		case errors.Is(err, entity.ErrNotifyNotFound):
+		case err != nil:
+			return fmt.Errorf("ReconcileSchedule: get notify ID=%s: %w", id, err)
+		case notify.Status == entity.StatusScheduled:
+			continue
+		}
+		if err := s.schedule.Remove(ctx, id); err != nil {
+			return fmt.Errorf("ReconcileSchedule: remove ID=%s: %w", id, err)
+		}
+		removed++
+	}
+
+	if added > 0 || removed > 0 {
+		s.logger.InfoContext(ctx, "ReconcileSchedule: schedule repaired", slog.Int("added", added), slog.Int("removed", removed))
+	}
 	return nil
 }
 
+// ProcessNotify отправляет письмо каждому получателю отдельно. При первой попытке
+// уведомление раскрывается в доставки; при повторных (ретраи, requeue) письмо уходит
+// только тем, кому его ещё не доставили. Уведомление считается отправленным,
+// когда доставлено всем получателям, кроме адресов из списка подавления. Если
+// остались только постоянные отказы сервера, ошибка оборачивает entity.ErrPermanentFailure.
+// Перед отправкой уведомление захватывается в БД: копия сообщения, которую reaper
+// поставил в очередь, пока оригинал отставал в Kafka, или повтор из DLQ при живой
+// копии на ступени ретраев пропускаются, если уведомление уже отправляется или обработано.
 func (s *NotifyService) ProcessNotify(ctx context.Context, notify entity.Notify) error {
-	err := s.notifier.Send(ctx, notify)
+	claimed, ok, err := s.db.ClaimSending(ctx, notify.ID)
 	if err != nil {
-		_ = s.db.UpdateNotifyStatus(ctx, notify.ID, entity.StatusFailed)
-		return err
+		return fmt.Errorf("ProcessNotify: claim: %w", err)
+	}
+	if !ok {
+		s.logger.InfoContext(ctx, "notify already sending or processed, message skipped", slog.String("notify_id", notify.ID))
+		return nil
+	}
+	_ = s.cache.SetNotify(ctx, claimed)
+
+	// Сообщения, поставленные в очередь до появления To, содержат только Email
+	notify.Normalize()
+
+	attachments, err := s.loadAttachments(ctx, notify.Attachments)
+	if err != nil {
+		s.sendFailed(ctx, notify.ID, err.Error())
+		_ = s.UpdateNotifyStatus(ctx, notify.ID, entity.StatusFailed)
+		return fmt.Errorf("ProcessNotify: %w", err)
+	}
+	notify.Attachments = attachments
+
+	deliveries, err := s.fanOut(ctx, notify)
+	if err != nil {
+		s.sendFailed(ctx, notify.ID, err.Error())
+		_ = s.UpdateNotifyStatus(ctx, notify.ID, entity.StatusFailed)
+		return fmt.Errorf("ProcessNotify: %w", err)
+	}
+
+	suppressed, err := s.suppressedSet(ctx, deliveries)
+	if err != nil {
+		s.sendFailed(ctx, notify.ID, err.Error())
+		_ = s.UpdateNotifyStatus(ctx, notify.ID, entity.StatusFailed)
+		return fmt.Errorf("ProcessNotify: %w", err)
+	}
+
+	// errs — временные ошибки, ради которых стоит повторить отправку; permanent —
+	// постоянные отказы, в том числе с прошлых попыток
+	var errs, permanent []error
+	sent := false
+	for _, d := range deliveries {
+		switch d.Status {
+		case entity.StatusSent:
+			sent = true
+			continue
+		case entity.DeliveryBounced:
+			permanent = append(permanent, fmt.Errorf("%s: %s", d.Email, d.LastError))
+			continue
+		case entity.DeliverySuppressed:
+			continue
+		}
+
+		status, lastError := entity.StatusSent, ""
+		if suppressed[strings.ToLower(d.Email)] {
+			status, lastError = entity.DeliverySuppressed, "recipient is suppressed"
+			s.recordEvent(ctx, notify.ID, entity.EventSuppressed, d.Email)
+		} else if err := s.notifier.Send(ctx, notify, d.Email); err != nil {
+			status, lastError = entity.StatusFailed, err.Error()
+			var sendErr *entity.SendError
+			if errors.As(err, &sendErr) && sendErr.Permanent {
+				status = entity.DeliveryBounced
+				permanent = append(permanent, fmt.Errorf("%s: %w", d.Email, err))
+				s.suppress(ctx, notify.ID, d.Email, sendErr)
+			} else {
+				errs = append(errs, fmt.Errorf("%s: %w", d.Email, err))
+			}
+			// Текст ошибки попадает в историю: по нему notifyctl ищет упавшие уведомления
+			s.sendFailed(ctx, notify.ID, d.Email+": "+lastError)
+		} else {
+			sent = true
+		}
+		if err := s.db.UpdateDelivery(ctx, notify.ID, d.Email, status, lastError); err != nil {
+			s.logger.ErrorContext(ctx, "failed to update delivery", slog.String("notify_id", notify.ID), slog.String("email", d.Email), slog.Any("error", err))
+		}
+	}
+
+	switch {
+	case len(errs) > 0:
+		_ = s.UpdateNotifyStatus(ctx, notify.ID, entity.StatusFailed)
+		return errors.Join(append(errs, permanent...)...)
+	case len(permanent) > 0:
+		_ = s.UpdateNotifyStatus(ctx, notify.ID, entity.StatusFailed)
+		return fmt.Errorf("%w: %w", entity.ErrPermanentFailure, errors.Join(permanent...))
+	case !sent:
+		// Все получатели в списке подавления
+		return s.UpdateNotifyStatus(ctx, notify.ID, entity.StatusSkipped)
+	}
+	return s.UpdateNotifyStatus(ctx, notify.ID, entity.StatusSent)
+}
+
+// GetDeliveries возвращает доставки уведомления по получателям.
+func (s *NotifyService) GetDeliveries(ctx context.Context, notifyID string) ([]entity.Delivery, error) {
+	if _, err := s.GetNotify(ctx, notifyID); err != nil {
+		return nil, err
+	}
+	deliveries, err := s.db.GetDeliveries(ctx, notifyID)
+	if err != nil {
+		return nil, fmt.Errorf("GetDeliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// fanOut возвращает доставки уведомления, создавая их при первой отправке. Состав
+// получателей фиксируется в этот момент: изменения списков не влияют на повторные попытки.
+func (s *NotifyService) fanOut(ctx context.Context, notify entity.Notify) ([]entity.Delivery, error) {
+	deliveries, err := s.db.GetDeliveries(ctx, notify.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get deliveries: %w", err)
+	}
+	if len(deliveries) > 0 {
+		return deliveries, nil
+	}
+
+	lists := make([]entity.RecipientList, 0, len(notify.RecipientLists))
+	for _, listID := range notify.RecipientLists {
+		list, err := s.db.GetRecipientList(ctx, listID)
+		if err != nil {
+			return nil, fmt.Errorf("get recipient list %s: %w", listID, err)
+		}
+		lists = append(lists, list)
+	}
+
+	deliveries = notify.Deliveries(lists)
+	if len(deliveries) == 0 {
+		return nil, errors.New("notify has no recipients")
+	}
+	deliveries, err = s.db.CreateDeliveries(ctx, notify.ID, deliveries)
+	if err != nil {
+		return nil, fmt.Errorf("create deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (s *NotifyService) sendFailed(ctx context.Context, notifyID, details string) {
+	if err := s.db.AddNotifyEvent(ctx, entity.NotifyEvent{
+		NotifyID: notifyID,
+		Action:   entity.EventSendFailed,
+		Details:  details,
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "failed to add notify event", slog.String("notify_id", notifyID), slog.Any("error", err))
 	}
-	return s.db.UpdateNotifyStatus(ctx, notify.ID, entity.StatusSent)
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"io"
 	"log/slog"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 
 	"delayed-notifier/internal/entity"
 	mock_email "delayed-notifier/internal/repository/email/mocks"
+	"delayed-notifier/internal/repository/memory"
 	mock_db "delayed-notifier/internal/repository/postgres/mocks"
 	mock_producer "delayed-notifier/internal/repository/producer/mocks"
 	mock_cache "delayed-notifier/internal/repository/redis/mocks"
@@ -51,7 +54,7 @@ func TestCreateNotify(t *testing.T) {
 		expected.Status = entity.StatusScheduled
 
 		db.On("CreateNotify", ctx, input).Return(expected, nil).Once()
-		cache.On("SetNotify", ctx, expected, 24*time.Hour).Return(nil).Once()
+		cache.On("SetNotify", ctx, expected).Return(nil).Once()
 
 		result, err := s.CreateNotify(ctx, input)
 
@@ -72,7 +75,44 @@ func TestCreateNotify(t *testing.T) {
 		assert.Error(t, err)
 		assert.Equal(t, entity.Notify{}, result)
 		db.AssertExpectations(t)
-		cache.AssertNotCalled(t, "SetNotify", mock.Anything, mock.Anything, mock.Anything)
+		cache.AssertNotCalled(t, "SetNotify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown recipient list", func(t *testing.T) {
+		ctx, db, _, _, s := setupTestService(t)
+
+		input := entity.Notify{Message: "hi", RecipientLists: []string{"l1", "missing"}}
+		db.On("GetRecipientList", ctx, "l1").Return(entity.RecipientList{ID: "l1"}, nil).Once()
+		db.On("GetRecipientList", ctx, "missing").Return(entity.RecipientList{}, entity.ErrRecipientListNotFound).Once()
+
+		_, err := s.CreateNotify(ctx, input)
+
+		var verr entity.ValidationError
+		assert.ErrorAs(t, err, &verr)
+		assert.Equal(t, entity.ValidationError{
+			{Field: "recipient_lists.1", Message: "recipient list missing not found"},
+		}, verr)
+		db.AssertNotCalled(t, "CreateNotify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("sender", func(t *testing.T) {
+		ctx := context.Background()
+		db := new(mock_db.NotifyDBRepository)
+		cache := new(mock_cache.NotifyCacheRepository)
+		s := NewNotifyService(db, cache, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)),
+			WithSenders([]string{entity.DefaultSender, "billing"}))
+
+		allowed := entity.Notify{Message: "hi", Email: "a@example.com", Sender: "billing"}
+		db.On("CreateNotify", ctx, allowed).Return(allowed, nil).Once()
+		cache.On("SetNotify", ctx, allowed).Return(nil).Once()
+		_, err := s.CreateNotify(ctx, allowed)
+		assert.NoError(t, err)
+
+		_, err = s.CreateNotify(ctx, entity.Notify{Message: "hi", Email: "a@example.com", Sender: "marketing"})
+		var verr entity.ValidationError
+		assert.ErrorAs(t, err, &verr)
+		assert.Equal(t, entity.ValidationError{{Field: "sender", Message: "sender marketing is not allowed"}}, verr)
+		db.AssertExpectations(t)
 	})
 }
 
@@ -96,8 +136,8 @@ func TestGetNotify(t *testing.T) {
 
 		n := entity.Notify{ID: "id2", Message: "msg2", SendAt: mustParseTime(t, "2025-10-25T10:10:10.555555"), Status: entity.StatusQueued, Email: "db@example.com"}
 		cache.On("GetNotify", ctx, n.ID).Return(entity.Notify{}, assert.AnError).Once()
-		db.On("GetNotify", ctx, n.ID).Return(n, nil).Once()
-		cache.On("SetNotify", ctx, n, 24*time.Hour).Return(nil).Once()
+		db.On("GetNotify", mock.Anything, n.ID).Return(n, nil).Once()
+		cache.On("SetNotify", mock.Anything, n).Return(nil).Once()
 
 		result, err := s.GetNotify(ctx, n.ID)
 
@@ -112,7 +152,7 @@ func TestGetNotify(t *testing.T) {
 
 		id := "id3"
 		cache.On("GetNotify", ctx, id).Return(entity.Notify{}, assert.AnError).Once()
-		db.On("GetNotify", ctx, id).Return(entity.Notify{}, assert.AnError).Once()
+		db.On("GetNotify", mock.Anything, id).Return(entity.Notify{}, assert.AnError).Once()
 
 		result, err := s.GetNotify(ctx, id)
 
@@ -120,6 +160,68 @@ func TestGetNotify(t *testing.T) {
 		assert.Equal(t, entity.Notify{}, result)
 		cache.AssertExpectations(t)
 		db.AssertExpectations(t)
+		cache.AssertNotCalled(t, "SetMissing", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not found is cached", func(t *testing.T) {
+		ctx, db, cache, _, s := setupTestService(t)
+
+		id := "id4"
+		cache.On("GetNotify", ctx, id).Return(entity.Notify{}, assert.AnError).Once()
+		db.On("GetNotify", mock.Anything, id).Return(entity.Notify{}, entity.ErrNotifyNotFound).Once()
+		cache.On("SetMissing", mock.Anything, id).Return(nil).Once()
+
+		_, err := s.GetNotify(ctx, id)
+
+		assert.ErrorIs(t, err, entity.ErrNotifyNotFound)
+		cache.AssertExpectations(t)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("negative hit", func(t *testing.T) {
+		ctx, db, cache, _, s := setupTestService(t)
+
+		id := "id5"
+		cache.On("GetNotify", ctx, id).Return(entity.Notify{}, entity.ErrNotifyNotFound).Once()
+
+		_, err := s.GetNotify(ctx, id)
+
+		assert.ErrorIs(t, err, entity.ErrNotifyNotFound)
+		assert.Equal(t, entity.CacheStats{NegativeHits: 1}, s.CacheStats())
+		db.AssertNotCalled(t, "GetNotify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("concurrent misses coalesced", func(t *testing.T) {
+		ctx, db, cache, _, s := setupTestService(t)
+
+		n := entity.Notify{ID: "id6", Status: entity.StatusScheduled}
+		const callers = 5
+		release := make(chan struct{})
+		cache.On("GetNotify", ctx, n.ID).Return(entity.Notify{}, assert.AnError).Times(callers)
+		var loads atomic.Int64
+		db.On("GetNotify", mock.Anything, n.ID).Run(func(mock.Arguments) {
+			loads.Add(1)
+			<-release
+		}).Return(n, nil)
+		cache.On("SetNotify", mock.Anything, n).Return(nil)
+
+		var wg sync.WaitGroup
+		for range callers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result, err := s.GetNotify(ctx, n.ID)
+				assert.NoError(t, err)
+				assert.Equal(t, n, result)
+			}()
+		}
+		assert.Eventually(t, func() bool { return s.CacheStats().Misses == callers }, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		// Горутина может успеть засчитать промах, но войти в Do уже после первого чтения
+		assert.Less(t, loads.Load(), int64(callers))
+		assert.Equal(t, int64(callers)-loads.Load(), s.CacheStats().Coalesced)
 	})
 }
 
@@ -128,8 +230,9 @@ func TestDeleteNotify(t *testing.T) {
 		ctx, db, cache, _, s := setupTestService(t)
 
 		id := "id1"
-		db.On("DeleteNotify", ctx, id).Return(nil).Once()
-		cache.On("DeleteNotify", ctx, id).Return(nil).Once()
+		dbCall := db.On("DeleteNotify", ctx, id).Return(nil).Once()
+		// Кэш чистится только после удаления строки
+		cache.On("DeleteNotify", ctx, id).Return(nil).Once().NotBefore(dbCall)
 
 		err := s.DeleteNotify(ctx, id)
 
@@ -137,6 +240,15 @@ func TestDeleteNotify(t *testing.T) {
 		db.AssertExpectations(t)
 		cache.AssertExpectations(t)
 	})
+
+	t.Run("db error keeps cache", func(t *testing.T) {
+		ctx, db, cache, _, s := setupTestService(t)
+
+		db.On("DeleteNotify", ctx, "id1").Return(assert.AnError).Once()
+
+		assert.ErrorIs(t, s.DeleteNotify(ctx, "id1"), assert.AnError)
+		cache.AssertNotCalled(t, "DeleteNotify", mock.Anything, mock.Anything)
+	})
 }
 
 func TestUpdateNotifyStatus(t *testing.T) {
@@ -145,8 +257,9 @@ func TestUpdateNotifyStatus(t *testing.T) {
 
 		id := "id1"
 		status := entity.StatusQueued
-		db.On("UpdateNotifyStatus", ctx, id, status).Return(nil).Once()
-		cache.On("DeleteNotify", ctx, id).Return(nil).Once()
+		updated := entity.Notify{ID: id, Status: status, Version: 2}
+		db.On("UpdateNotifyStatus", ctx, id, status).Return(updated, nil).Once()
+		cache.On("SetNotify", ctx, updated).Return(nil).Once()
 
 		err := s.UpdateNotifyStatus(ctx, id, status)
 
@@ -156,6 +269,211 @@ func TestUpdateNotifyStatus(t *testing.T) {
 	})
 }
 
+func TestProcessNotify(t *testing.T) {
+	pending := func(email, kind string) entity.Delivery {
+		return entity.Delivery{NotifyID: "id1", Email: email, Kind: kind, Status: entity.DeliveryPending}
+	}
+
+	t.Run("sent status written through to cache", func(t *testing.T) {
+		ctx, db, cache, _, s := setupTestService(t)
+		db.On("ClaimSending", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusSending}, true, nil).Once()
+		cache.On("SetNotify", ctx, entity.Notify{ID: "id1", Status: entity.StatusSending}).Return(nil).Once()
+		notifier := new(mock_email.Notifier)
+		s.notifier = notifier
+
+		n := entity.Notify{ID: "id1", Status: entity.StatusQueued, Version: 2, Email: "a@example.com", To: []string{"a@example.com"}}
+		sent := entity.Notify{ID: "id1", Status: entity.StatusSent, Version: 3}
+		deliveries := []entity.Delivery{pending("a@example.com", entity.RecipientTo)}
+		db.On("GetDeliveries", ctx, n.ID).Return([]entity.Delivery{}, nil).Once()
+		db.On("CreateDeliveries", ctx, n.ID, deliveries).Return(deliveries, nil).Once()
+		notifier.On("Send", ctx, n, "a@example.com").Return(nil).Once()
+		db.On("UpdateDelivery", ctx, n.ID, "a@example.com", entity.StatusSent, "").Return(nil).Once()
+		db.On("UpdateNotifyStatus", ctx, n.ID, entity.StatusSent).Return(sent, nil).Once()
+		cache.On("SetNotify", ctx, sent).Return(nil).Once()
+
+		assert.NoError(t, s.ProcessNotify(ctx, n))
+		db.AssertExpectations(t)
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("failed status written through to cache", func(t *testing.T) {
+		ctx, db, cache, _, s := setupTestService(t)
+		db.On("ClaimSending", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusSending}, true, nil).Once()
+		cache.On("SetNotify", ctx, entity.Notify{ID: "id1", Status: entity.StatusSending}).Return(nil).Once()
+		notifier := new(mock_email.Notifier)
+		s.notifier = notifier
+
+		n := entity.Notify{ID: "id1", Status: entity.StatusQueued, Version: 2, Email: "a@example.com", To: []string{"a@example.com"}}
+		failed := entity.Notify{ID: "id1", Status: entity.StatusFailed, Version: 3}
+		db.On("GetDeliveries", ctx, n.ID).Return([]entity.Delivery{pending("a@example.com", entity.RecipientTo)}, nil).Once()
+		notifier.On("Send", ctx, n, "a@example.com").Return(assert.AnError).Once()
+		db.On("AddNotifyEvent", ctx, entity.NotifyEvent{NotifyID: n.ID, Action: entity.EventSendFailed, Details: "a@example.com: " + assert.AnError.Error()}).Return(nil).Once()
+		db.On("UpdateDelivery", ctx, n.ID, "a@example.com", entity.StatusFailed, assert.AnError.Error()).Return(nil).Once()
+		db.On("UpdateNotifyStatus", ctx, n.ID, entity.StatusFailed).Return(failed, nil).Once()
+		cache.On("SetNotify", ctx, failed).Return(nil).Once()
+
+		assert.ErrorIs(t, s.ProcessNotify(ctx, n), assert.AnError)
+		db.AssertExpectations(t)
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("legacy email and recipient lists fanned out", func(t *testing.T) {
+		ctx, db, cache, _, s := setupTestService(t)
+		db.On("ClaimSending", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusSending}, true, nil).Once()
+		cache.On("SetNotify", ctx, entity.Notify{ID: "id1", Status: entity.StatusSending}).Return(nil).Once()
+		notifier := new(mock_email.Notifier)
+		s.notifier = notifier
+
+		// Сообщение старого формата: только Email
+		n := entity.Notify{ID: "id1", Email: "a@example.com", Cc: []string{"b@example.com"}, RecipientLists: []string{"l1"}}
+		normalized := n
+		normalized.To = []string{"a@example.com"}
+		deliveries := []entity.Delivery{
+			pending("a@example.com", entity.RecipientTo),
+			pending("b@example.com", entity.RecipientCc),
+			{NotifyID: "id1", Email: "c@example.com", Kind: entity.RecipientBcc, ListID: "l1", Status: entity.DeliveryPending},
+		}
+		db.On("GetDeliveries", ctx, n.ID).Return([]entity.Delivery{}, nil).Once()
+		db.On("GetRecipientList", ctx, "l1").Return(entity.RecipientList{ID: "l1", Members: []string{"B@example.com", "c@example.com"}}, nil).Once()
+		db.On("CreateDeliveries", ctx, n.ID, deliveries).Return(deliveries, nil).Once()
+		for _, d := range deliveries {
+			notifier.On("Send", ctx, normalized, d.Email).Return(nil).Once()
+			db.On("UpdateDelivery", ctx, n.ID, d.Email, entity.StatusSent, "").Return(nil).Once()
+		}
+		db.On("UpdateNotifyStatus", ctx, n.ID, entity.StatusSent).Return(entity.Notify{ID: "id1"}, nil).Once()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil).Once()
+
+		assert.NoError(t, s.ProcessNotify(ctx, n))
+		db.AssertExpectations(t)
+		notifier.AssertExpectations(t)
+	})
+
+	t.Run("retry skips delivered recipients", func(t *testing.T) {
+		ctx, db, cache, _, s := setupTestService(t)
+		db.On("ClaimSending", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusSending}, true, nil).Once()
+		cache.On("SetNotify", ctx, entity.Notify{ID: "id1", Status: entity.StatusSending}).Return(nil).Once()
+		notifier := new(mock_email.Notifier)
+		s.notifier = notifier
+
+		n := entity.Notify{ID: "id1", Email: "a@example.com", To: []string{"a@example.com", "b@example.com"}}
+		db.On("GetDeliveries", ctx, n.ID).Return([]entity.Delivery{
+			{NotifyID: "id1", Email: "a@example.com", Kind: entity.RecipientTo, Status: entity.StatusSent, Attempts: 1},
+			{NotifyID: "id1", Email: "b@example.com", Kind: entity.RecipientTo, Status: entity.StatusFailed, Attempts: 1},
+		}, nil).Once()
+		notifier.On("Send", ctx, n, "b@example.com").Return(nil).Once()
+		db.On("UpdateDelivery", ctx, n.ID, "b@example.com", entity.StatusSent, "").Return(nil).Once()
+		db.On("UpdateNotifyStatus", ctx, n.ID, entity.StatusSent).Return(entity.Notify{ID: "id1"}, nil).Once()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil).Once()
+
+		assert.NoError(t, s.ProcessNotify(ctx, n))
+		db.AssertExpectations(t)
+		notifier.AssertExpectations(t)
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		ctx, db, cache, _, s := setupTestService(t)
+		db.On("ClaimSending", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusSending}, true, nil).Once()
+		cache.On("SetNotify", ctx, entity.Notify{ID: "id1", Status: entity.StatusSending}).Return(nil).Once()
+		notifier := new(mock_email.Notifier)
+		s.notifier = notifier
+
+		n := entity.Notify{ID: "id1", Email: "a@example.com", To: []string{"a@example.com", "b@example.com"}}
+		db.On("GetDeliveries", ctx, n.ID).Return([]entity.Delivery{
+			pending("a@example.com", entity.RecipientTo),
+			pending("b@example.com", entity.RecipientTo),
+		}, nil).Once()
+		notifier.On("Send", ctx, n, "a@example.com").Return(nil).Once()
+		notifier.On("Send", ctx, n, "b@example.com").Return(assert.AnError).Once()
+		db.On("UpdateDelivery", ctx, n.ID, "a@example.com", entity.StatusSent, "").Return(nil).Once()
+		db.On("UpdateDelivery", ctx, n.ID, "b@example.com", entity.StatusFailed, assert.AnError.Error()).Return(nil).Once()
+		db.On("AddNotifyEvent", ctx, mock.Anything).Return(nil).Once()
+		db.On("UpdateNotifyStatus", ctx, n.ID, entity.StatusFailed).Return(entity.Notify{ID: "id1"}, nil).Once()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil).Once()
+
+		err := s.ProcessNotify(ctx, n)
+
+		assert.ErrorContains(t, err, "b@example.com")
+		db.AssertExpectations(t)
+	})
+
+	t.Run("missing recipient list fails notify", func(t *testing.T) {
+		ctx, db, cache, _, s := setupTestService(t)
+		db.On("ClaimSending", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusSending}, true, nil).Once()
+		cache.On("SetNotify", ctx, entity.Notify{ID: "id1", Status: entity.StatusSending}).Return(nil).Once()
+
+		n := entity.Notify{ID: "id1", RecipientLists: []string{"gone"}}
+		db.On("GetDeliveries", ctx, n.ID).Return([]entity.Delivery{}, nil).Once()
+		db.On("GetRecipientList", ctx, "gone").Return(entity.RecipientList{}, entity.ErrRecipientListNotFound).Once()
+		db.On("AddNotifyEvent", ctx, mock.Anything).Return(nil).Once()
+		db.On("UpdateNotifyStatus", ctx, n.ID, entity.StatusFailed).Return(entity.Notify{ID: "id1"}, nil).Once()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil).Once()
+
+		assert.ErrorIs(t, s.ProcessNotify(ctx, n), entity.ErrRecipientListNotFound)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("notify not claimed skipped", func(t *testing.T) {
+		ctx, db, cache, _, s := setupTestService(t)
+		notifier := new(mock_email.Notifier)
+		s.notifier = notifier
+
+		db.On("ClaimSending", ctx, "id1").Return(entity.Notify{}, false, nil).Once()
+
+		assert.NoError(t, s.ProcessNotify(ctx, entity.Notify{ID: "id1", Email: "a@example.com"}))
+		db.AssertExpectations(t)
+		db.AssertNotCalled(t, "GetDeliveries", mock.Anything, mock.Anything)
+		notifier.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+		cache.AssertNotCalled(t, "SetNotify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("claim error retried", func(t *testing.T) {
+		ctx, db, _, _, s := setupTestService(t)
+
+		db.On("ClaimSending", ctx, "id1").Return(entity.Notify{}, false, assert.AnError).Once()
+
+		assert.ErrorIs(t, s.ProcessNotify(ctx, entity.Notify{ID: "id1"}), assert.AnError)
+		db.AssertNotCalled(t, "UpdateNotifyStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	// Копия, которую reaper поставил в очередь, пока оригинал отставал в Kafka,
+	// обрабатывается одновременно с оригиналом
+	t.Run("concurrent copies send once", func(t *testing.T) {
+		ctx := context.Background()
+		db := memory.NewNotifyDBRepository()
+		cache := new(mock_cache.NotifyCacheRepository)
+		cache.On("SetNotify", mock.Anything, mock.Anything).Return(nil).Maybe()
+		notifier := new(mock_email.Notifier)
+		s := NewNotifyService(db, cache, nil, notifier, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		created, err := db.CreateNotify(ctx, entity.Notify{Message: "m", Email: "a@example.com", To: []string{"a@example.com"}})
+		assert.NoError(t, err)
+		queued, err := db.UpdateNotifyStatus(ctx, created.ID, entity.StatusQueued)
+		assert.NoError(t, err)
+
+		var sends atomic.Int32
+		notifier.On("Send", mock.Anything, mock.Anything, "a@example.com").
+			Run(func(mock.Arguments) { sends.Add(1) }).Return(nil).Maybe()
+
+		var wg sync.WaitGroup
+		start := make(chan struct{})
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				assert.NoError(t, s.ProcessNotify(ctx, queued))
+			}()
+		}
+		close(start)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), sends.Load())
+		current, err := db.GetNotify(ctx, created.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, entity.StatusSent, current.Status)
+	})
+}
+
 func TestScheduleReadyNotifies(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		ctx, db, cache, producer, s := setupTestService(t)
@@ -164,17 +482,17 @@ func TestScheduleReadyNotifies(t *testing.T) {
 		n2 := entity.Notify{ID: "id2", Message: "m2", SendAt: mustParseTime(t, "2025-10-26T11:11:11.111111"), Status: entity.StatusScheduled}
 		notifies := []entity.Notify{n1, n2}
 
-		db.On("GetReadyNotifies", ctx).Return(notifies, nil).Once()
+		db.On("GetReadyNotifies", ctx, defaultBatchSize).Return(notifies, nil).Once()
 
+		db.On("ClaimNotify", ctx, n1.ID).Return(entity.Notify{ID: n1.ID, Status: entity.StatusQueued}, true, nil).Once()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil).Once()
 		producer.On("Send", ctx, n1).Return(nil).Once()
-		db.On("UpdateNotifyStatus", ctx, n1.ID, entity.StatusQueued).Return(nil).Once()
-		cache.On("DeleteNotify", ctx, n1.ID).Return(nil).Once()
 
+		db.On("ClaimNotify", ctx, n2.ID).Return(entity.Notify{ID: n2.ID, Status: entity.StatusQueued}, true, nil).Once()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil).Once()
 		producer.On("Send", ctx, n2).Return(nil).Once()
-		db.On("UpdateNotifyStatus", ctx, n2.ID, entity.StatusQueued).Return(nil).Once()
-		cache.On("DeleteNotify", ctx, n2.ID).Return(nil).Once()
 
-		err := s.ScheduleReadyNotifies(ctx)
+		_, err := s.ScheduleReadyNotifies(ctx)
 
 		assert.NoError(t, err)
 		db.AssertExpectations(t)
@@ -185,9 +503,9 @@ func TestScheduleReadyNotifies(t *testing.T) {
 	t.Run("db error", func(t *testing.T) {
 		ctx, db, _, producer, s := setupTestService(t)
 
-		db.On("GetReadyNotifies", ctx).Return(nil, assert.AnError).Once()
+		db.On("GetReadyNotifies", ctx, defaultBatchSize).Return(nil, assert.AnError).Once()
 
-		err := s.ScheduleReadyNotifies(ctx)
+		_, err := s.ScheduleReadyNotifies(ctx)
 
 		assert.Error(t, err)
 		db.AssertExpectations(t)
@@ -195,43 +513,239 @@ func TestScheduleReadyNotifies(t *testing.T) {
 	})
 
 	t.Run("producer error", func(t *testing.T) {
-		ctx, db, _, producer, s := setupTestService(t)
+		ctx, db, cache, producer, s := setupTestService(t)
 
 		n1 := entity.Notify{ID: "id1", Message: "m1", SendAt: mustParseTime(t, "2025-10-25T10:10:10.555555"), Status: entity.StatusScheduled}
 		n2 := entity.Notify{ID: "id2", Message: "m2", SendAt: mustParseTime(t, "2025-10-26T11:11:11.111111"), Status: entity.StatusScheduled}
 		notifies := []entity.Notify{n1, n2}
 
-		db.On("GetReadyNotifies", ctx).Return(notifies, nil).Once()
+		db.On("GetReadyNotifies", ctx, defaultBatchSize).Return(notifies, nil).Once()
+		db.On("ClaimNotify", ctx, mock.Anything).Return(entity.Notify{Status: entity.StatusQueued}, true, nil).Twice()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil)
 		producer.On("Send", ctx, n1).Return(assert.AnError).Once()
 		producer.On("Send", ctx, n2).Return(assert.AnError).Once()
+		db.On("UpdateNotifyStatus", ctx, n1.ID, entity.StatusScheduled).Return(entity.Notify{ID: n1.ID, Status: entity.StatusScheduled}, nil).Once()
+		db.On("UpdateNotifyStatus", ctx, n2.ID, entity.StatusScheduled).Return(entity.Notify{ID: n2.ID, Status: entity.StatusScheduled}, nil).Once()
 
-		err := s.ScheduleReadyNotifies(ctx)
+		_, err := s.ScheduleReadyNotifies(ctx)
 
 		assert.NoError(t, err)
 		db.AssertExpectations(t)
 		producer.AssertExpectations(t)
-		db.AssertNotCalled(t, "UpdateNotifyStatus", mock.Anything, mock.Anything, mock.Anything)
+		db.AssertNotCalled(t, "UpdateNotifyStatus", mock.Anything, mock.Anything, entity.StatusQueued)
 	})
 
-	t.Run("update status error", func(t *testing.T) {
+	t.Run("already claimed", func(t *testing.T) {
+		ctx, db, cache, producer, s := setupTestService(t)
+
+		n1 := entity.Notify{ID: "id1", Message: "m1", SendAt: mustParseTime(t, "2025-10-25T10:10:10.555555"), Status: entity.StatusScheduled}
+
+		db.On("GetReadyNotifies", ctx, defaultBatchSize).Return([]entity.Notify{n1}, nil).Once()
+		db.On("ClaimNotify", ctx, n1.ID).Return(entity.Notify{}, false, nil).Once()
+
+		_, err := s.ScheduleReadyNotifies(ctx)
+
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		producer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+		cache.AssertNotCalled(t, "SetNotify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("claim error", func(t *testing.T) {
 		ctx, db, cache, producer, s := setupTestService(t)
 
 		n1 := entity.Notify{ID: "id1", Message: "m1", SendAt: mustParseTime(t, "2025-10-25T10:10:10.555555"), Status: entity.StatusScheduled}
 		n2 := entity.Notify{ID: "id2", Message: "m2", SendAt: mustParseTime(t, "2025-10-26T11:11:11.111111"), Status: entity.StatusScheduled}
 		notifies := []entity.Notify{n1, n2}
 
-		db.On("GetReadyNotifies", ctx).Return(notifies, nil).Once()
-		producer.On("Send", ctx, n1).Return(nil).Once()
-		db.On("UpdateNotifyStatus", ctx, n1.ID, entity.StatusQueued).Return(assert.AnError).Once()
+		db.On("GetReadyNotifies", ctx, defaultBatchSize).Return(notifies, nil).Once()
+		db.On("ClaimNotify", ctx, n1.ID).Return(entity.Notify{}, false, assert.AnError).Once()
 
-		err := s.ScheduleReadyNotifies(ctx)
+		_, err := s.ScheduleReadyNotifies(ctx)
 
 		assert.Error(t, err)
-		db.AssertCalled(t, "GetReadyNotifies", ctx)
-		producer.AssertCalled(t, "Send", ctx, n1)
+		db.AssertExpectations(t)
+		db.AssertNotCalled(t, "ClaimNotify", ctx, n2.ID)
+		producer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+		cache.AssertNotCalled(t, "SetNotify", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDispatchNotify(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctx, db, cache, producer, s := setupTestService(t)
+
+		n := entity.Notify{ID: "id1", SendAt: time.Now().Add(-time.Second), Status: entity.StatusScheduled}
+		db.On("GetNotify", ctx, n.ID).Return(n, nil).Once()
+		db.On("ClaimNotify", ctx, n.ID).Return(entity.Notify{ID: n.ID, Status: entity.StatusQueued}, true, nil).Once()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil).Once()
+		producer.On("Send", ctx, n).Return(nil).Once()
+
+		assert.NoError(t, s.DispatchNotify(ctx, n.ID))
+		db.AssertExpectations(t)
+		producer.AssertExpectations(t)
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("skips not ready", func(t *testing.T) {
+		ctx, db, _, producer, s := setupTestService(t)
+
+		rescheduled := entity.Notify{ID: "id1", SendAt: time.Now().Add(time.Hour), Status: entity.StatusScheduled}
+		sent := entity.Notify{ID: "id2", SendAt: time.Now().Add(-time.Second), Status: entity.StatusSent}
+		db.On("GetNotify", ctx, rescheduled.ID).Return(rescheduled, nil).Once()
+		db.On("GetNotify", ctx, sent.ID).Return(sent, nil).Once()
+		db.On("GetNotify", ctx, "gone").Return(entity.Notify{}, entity.ErrNotifyNotFound).Once()
+
+		assert.NoError(t, s.DispatchNotify(ctx, rescheduled.ID))
+		assert.NoError(t, s.DispatchNotify(ctx, sent.ID))
+		assert.NoError(t, s.DispatchNotify(ctx, "gone"))
+		db.AssertExpectations(t)
+		db.AssertNotCalled(t, "ClaimNotify", mock.Anything, mock.Anything)
+		producer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		ctx, db, _, _, s := setupTestService(t)
+
+		db.On("GetNotify", ctx, "id1").Return(entity.Notify{}, assert.AnError).Once()
+
+		assert.Error(t, s.DispatchNotify(ctx, "id1"))
+	})
+}
+
+func setupTestServiceWithSchedule(t *testing.T) (context.Context, *mock_db.NotifyDBRepository, *mock_cache.NotifyCacheRepository, *mock_cache.NotifyScheduleRepository, *mock_producer.NotifyProducer, *NotifyService) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	db := new(mock_db.NotifyDBRepository)
+	cache := new(mock_cache.NotifyCacheRepository)
+	schedule := new(mock_cache.NotifyScheduleRepository)
+	producer := new(mock_producer.NotifyProducer)
+	notifier := new(mock_email.Notifier)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	s := NewNotifyService(db, cache, producer, notifier, logger, WithScheduleRepository(schedule))
+
+	return ctx, db, cache, schedule, producer, s
+}
+
+func TestScheduleRepository(t *testing.T) {
+	t.Run("create adds to schedule", func(t *testing.T) {
+		ctx, db, cache, schedule, _, s := setupTestServiceWithSchedule(t)
+
+		input := entity.Notify{SendAt: mustParseTime(t, "2025-10-25T10:10:10.555555"), Message: "m", Email: "a@example.com"}
+		created := input
+		created.ID = "id1"
+		db.On("CreateNotify", ctx, input).Return(created, nil).Once()
+		cache.On("SetNotify", ctx, created).Return(nil).Once()
+		schedule.On("Add", ctx, created.ID, created.SendAt).Return(assert.AnError).Once()
+
+		result, err := s.CreateNotify(ctx, input)
+
+		assert.NoError(t, err)
+		assert.Equal(t, created, result)
+		schedule.AssertExpectations(t)
+	})
+
+	t.Run("delete removes from schedule", func(t *testing.T) {
+		ctx, db, cache, schedule, _, s := setupTestServiceWithSchedule(t)
+
+		db.On("DeleteNotify", ctx, "id1").Return(nil).Once()
+		cache.On("DeleteNotify", ctx, "id1").Return(nil).Once()
+		schedule.On("Remove", ctx, "id1").Return(nil).Once()
+
+		assert.NoError(t, s.DeleteNotify(ctx, "id1"))
+		schedule.AssertExpectations(t)
+	})
+
+	t.Run("schedule pops due notifies", func(t *testing.T) {
+		ctx, db, cache, schedule, producer, s := setupTestServiceWithSchedule(t)
+
+		past := time.Now().Add(-time.Minute)
+		n1 := entity.Notify{ID: "id1", SendAt: past, Status: entity.StatusScheduled}
+		n2 := entity.Notify{ID: "id2", SendAt: past, Status: entity.StatusSent}
+		n3 := entity.Notify{ID: "id3", SendAt: past, Status: entity.StatusScheduled}
+
+		schedule.On("PopDue", ctx, mock.Anything, defaultBatchSize).Return([]string{"id1", "id2", "id3", "gone"}, nil).Once()
+		db.On("GetNotify", ctx, "id1").Return(n1, nil).Once()
+		db.On("GetNotify", ctx, "id2").Return(n2, nil).Once()
+		db.On("GetNotify", ctx, "id3").Return(n3, nil).Once()
+		db.On("GetNotify", ctx, "gone").Return(entity.Notify{}, entity.ErrNotifyNotFound).Once()
+
+		db.On("ClaimNotify", ctx, n1.ID).Return(entity.Notify{ID: n1.ID, Status: entity.StatusQueued}, true, nil).Once()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil).Once()
+		producer.On("Send", ctx, n1).Return(nil).Once()
+
+		db.On("ClaimNotify", ctx, n3.ID).Return(entity.Notify{ID: n3.ID, Status: entity.StatusQueued}, true, nil).Once()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil)
+		producer.On("Send", ctx, n3).Return(assert.AnError).Once()
+		db.On("UpdateNotifyStatus", ctx, n3.ID, entity.StatusScheduled).Return(entity.Notify{ID: n3.ID, Status: entity.StatusScheduled}, nil).Once()
+		schedule.On("Add", ctx, n3.ID, n3.SendAt).Return(nil).Once()
+
+		_, err := s.ScheduleReadyNotifies(ctx)
+
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+		producer.AssertExpectations(t)
+		schedule.AssertExpectations(t)
+		db.AssertNotCalled(t, "GetReadyNotifies", mock.Anything, mock.Anything)
 		producer.AssertNotCalled(t, "Send", ctx, n2)
-		db.AssertCalled(t, "UpdateNotifyStatus", ctx, n1.ID, entity.StatusQueued)
-		db.AssertNotCalled(t, "UpdateNotifyStatus", ctx, n2.ID, entity.StatusQueued)
-		cache.AssertNotCalled(t, "DeleteNotify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("schedule error returns unprocessed notifies", func(t *testing.T) {
+		ctx, db, _, schedule, _, s := setupTestServiceWithSchedule(t)
+
+		schedule.On("PopDue", ctx, mock.Anything, defaultBatchSize).Return([]string{"id1", "id2", "id3"}, nil).Once()
+		db.On("GetNotify", ctx, "id1").Return(entity.Notify{}, assert.AnError).Once()
+		schedule.On("Add", ctx, "id1", mock.Anything).Return(nil).Once()
+		schedule.On("Add", ctx, "id2", mock.Anything).Return(nil).Once()
+		schedule.On("Add", ctx, "id3", mock.Anything).Return(nil).Once()
+
+		_, err := s.ScheduleReadyNotifies(ctx)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		schedule.AssertExpectations(t)
+		db.AssertNotCalled(t, "GetNotify", ctx, "id2")
+	})
+
+	t.Run("reconcile repairs drift", func(t *testing.T) {
+		ctx, db, _, schedule, _, s := setupTestServiceWithSchedule(t)
+
+		at1 := time.UnixMilli(1761387010555)
+		at2 := time.UnixMilli(1761473471111)
+		db.On("GetScheduledSendTimes", ctx).Return(map[string]time.Time{"id1": at1, "id2": at2}, nil).Once()
+		schedule.On("Members", ctx).Return(map[string]time.Time{"id1": at1, "stale": at2}, nil).Once()
+		schedule.On("Add", ctx, "id2", at2).Return(nil).Once()
+		db.On("GetNotify", ctx, "stale").Return(entity.Notify{ID: "stale", Status: entity.StatusSent}, nil).Once()
+		schedule.On("Remove", ctx, "stale").Return(nil).Once()
+
+		err := s.ReconcileSchedule(ctx)
+
+		assert.NoError(t, err)
+		schedule.AssertExpectations(t)
+		schedule.AssertNotCalled(t, "Add", ctx, "id1", mock.Anything)
+	})
+
+	t.Run("reconcile keeps notify scheduled after snapshot", func(t *testing.T) {
+		ctx, db, _, schedule, _, s := setupTestServiceWithSchedule(t)
+
+		var calls []string
+		at := time.UnixMilli(1761387010555)
+		schedule.On("Members", ctx).Return(map[string]time.Time{"new": at, "gone": at}, nil).
+			Run(func(mock.Arguments) { calls = append(calls, "Members") }).Once()
+		db.On("GetScheduledSendTimes", ctx).Return(map[string]time.Time{}, nil).
+			Run(func(mock.Arguments) { calls = append(calls, "GetScheduledSendTimes") }).Once()
+		// Перенесено после снимка БД
+		db.On("GetNotify", ctx, "new").Return(entity.Notify{ID: "new", Status: entity.StatusScheduled}, nil).Once()
+		db.On("GetNotify", ctx, "gone").Return(entity.Notify{}, entity.ErrNotifyNotFound).Once()
+		schedule.On("Remove", ctx, "gone").Return(nil).Once()
+
+		err := s.ReconcileSchedule(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Members", "GetScheduledSendTimes"}, calls)
+		schedule.AssertExpectations(t)
+		schedule.AssertNotCalled(t, "Remove", ctx, "new")
 	})
 }
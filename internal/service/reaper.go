@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"delayed-notifier/internal/entity"
+	"delayed-notifier/internal/requestid"
+)
+
+// ReapStuckNotifies находит уведомления, зависшие в статусе queued дольше порога
+// (сообщение потерялось в Kafka или консьюмер упал), и ставит их в очередь заново.
+// После MaxReaps попыток уведомление помечается failed. Каждое действие записывается
+// в историю уведомления.
+func (s *NotifyService) ReapStuckNotifies(ctx context.Context) (entity.ReapReport, error) {
+	var report entity.ReapReport
+
+	before := time.Now().Add(-s.reap.Threshold)
+	stuck, err := s.db.GetStuckNotifies(ctx, before, s.batchSize)
+	if err != nil {
+		return report, fmt.Errorf("ReapStuckNotifies: get stuck notifies: %w", err)
+	}
+
+	for _, notify := range stuck {
+		ctx := requestid.NewContext(ctx, notify.RequestID)
+		if notify.ReapCount >= s.reap.MaxReaps {
+			failed, ok, err := s.db.FailStuckNotify(ctx, notify.ID, before)
+			if err != nil {
+				return report, fmt.Errorf("ReapStuckNotifies: fail ID=%s: %w", notify.ID, err)
+			}
+			if !ok {
+				continue
+			}
+			_ = s.cache.SetNotify(ctx, failed)
+			s.logger.WarnContext(ctx, "stuck notify marked failed", slog.String("ID", notify.ID), slog.Int("reap_count", notify.ReapCount))
+			s.recordEvent(ctx, notify.ID, entity.EventReaperFailed, fmt.Sprintf("queued since %s, reaped %d times", notify.QueuedAt.Format(time.RFC3339), notify.ReapCount))
+			report.Failed++
+			continue
+		}
+
+		requeued, err := s.db.RequeueStuckNotify(ctx, notify.ID, before)
+		if err != nil {
+			return report, fmt.Errorf("ReapStuckNotifies: requeue ID=%s: %w", notify.ID, err)
+		}
+		if !requeued {
+			continue
+		}
+
+		// queued_at уже сдвинут, поэтому при ошибке Kafka уведомление поднимется
+		// снова через Threshold и израсходует ещё одну попытку
+		if err := s.producer.Send(ctx, notify.Notify); err != nil {
+			s.logger.ErrorContext(ctx, "failed to requeue stuck notify", slog.String("ID", notify.ID), slog.Any("error", err))
+			s.recordEvent(ctx, notify.ID, entity.EventReaperError, err.Error())
+			continue
+		}
+		s.logger.InfoContext(ctx, "stuck notify requeued", slog.String("ID", notify.ID), slog.Int("attempt", notify.ReapCount+1))
+		s.recordEvent(ctx, notify.ID, entity.EventReaperRequeued, fmt.Sprintf("queued since %s, attempt %d", notify.QueuedAt.Format(time.RFC3339), notify.ReapCount+1))
+		report.Requeued++
+	}
+
+	report.HasMore = len(stuck) == s.batchSize
+	return report, nil
+}
+
+func (s *NotifyService) recordEvent(ctx context.Context, notifyID, action, details string) {
+	event := entity.NotifyEvent{NotifyID: notifyID, Action: action, Details: details}
+	if err := s.db.AddNotifyEvent(ctx, event); err != nil {
+		s.logger.ErrorContext(ctx, "failed to record notify event", slog.String("ID", notifyID), slog.String("action", action), slog.Any("error", err))
+	}
+}
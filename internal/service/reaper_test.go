@@ -0,0 +1,71 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"delayed-notifier/internal/entity"
+)
+
+func TestReapStuckNotifies(t *testing.T) {
+	t.Run("requeues and fails", func(t *testing.T) {
+		ctx, db, cache, producer, s := setupTestService(t)
+
+		queuedAt := time.Now().Add(-time.Hour)
+		retry := entity.StuckNotify{Notify: entity.Notify{ID: "id1", Status: entity.StatusQueued}, QueuedAt: queuedAt, ReapCount: 1}
+		exhausted := entity.StuckNotify{Notify: entity.Notify{ID: "id2", Status: entity.StatusQueued}, QueuedAt: queuedAt, ReapCount: 3}
+		taken := entity.StuckNotify{Notify: entity.Notify{ID: "id3", Status: entity.StatusQueued}, QueuedAt: queuedAt}
+
+		db.On("GetStuckNotifies", ctx, mock.Anything, defaultBatchSize).Return([]entity.StuckNotify{retry, exhausted, taken}, nil).Once()
+		db.On("RequeueStuckNotify", ctx, retry.ID, mock.Anything).Return(true, nil).Once()
+		producer.On("Send", ctx, retry.Notify).Return(nil).Once()
+		db.On("FailStuckNotify", ctx, exhausted.ID, mock.Anything).Return(entity.Notify{ID: exhausted.ID, Status: entity.StatusFailed}, true, nil).Once()
+		cache.On("SetNotify", ctx, mock.Anything).Return(nil).Once()
+		db.On("RequeueStuckNotify", ctx, taken.ID, mock.Anything).Return(false, nil).Once()
+		db.On("AddNotifyEvent", ctx, mock.MatchedBy(func(e entity.NotifyEvent) bool {
+			return e.NotifyID == retry.ID && e.Action == entity.EventReaperRequeued
+		})).Return(nil).Once()
+		db.On("AddNotifyEvent", ctx, mock.MatchedBy(func(e entity.NotifyEvent) bool {
+			return e.NotifyID == exhausted.ID && e.Action == entity.EventReaperFailed
+		})).Return(nil).Once()
+
+		report, err := s.ReapStuckNotifies(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.ReapReport{Requeued: 1, Failed: 1}, report)
+		db.AssertExpectations(t)
+		producer.AssertExpectations(t)
+		producer.AssertNotCalled(t, "Send", ctx, taken.Notify)
+	})
+
+	t.Run("producer error recorded", func(t *testing.T) {
+		ctx, db, _, producer, s := setupTestService(t)
+
+		stuck := entity.StuckNotify{Notify: entity.Notify{ID: "id1", Status: entity.StatusQueued}, QueuedAt: time.Now().Add(-time.Hour)}
+		db.On("GetStuckNotifies", ctx, mock.Anything, defaultBatchSize).Return([]entity.StuckNotify{stuck}, nil).Once()
+		db.On("RequeueStuckNotify", ctx, stuck.ID, mock.Anything).Return(true, nil).Once()
+		producer.On("Send", ctx, stuck.Notify).Return(assert.AnError).Once()
+		db.On("AddNotifyEvent", ctx, mock.MatchedBy(func(e entity.NotifyEvent) bool {
+			return e.Action == entity.EventReaperError
+		})).Return(nil).Once()
+
+		report, err := s.ReapStuckNotifies(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, report.Requeued)
+		db.AssertExpectations(t)
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		ctx, db, _, _, s := setupTestService(t)
+
+		db.On("GetStuckNotifies", ctx, mock.Anything, defaultBatchSize).Return(nil, assert.AnError).Once()
+
+		_, err := s.ReapStuckNotifies(ctx)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"delayed-notifier/internal/entity"
+)
+
+type RecipientListRepository interface {
+	CreateRecipientList(ctx context.Context, list entity.RecipientList) (entity.RecipientList, error)
+	GetRecipientList(ctx context.Context, listID string) (entity.RecipientList, error)
+	ListRecipientLists(ctx context.Context) ([]entity.RecipientList, error)
+	UpdateRecipientList(ctx context.Context, list entity.RecipientList) (entity.RecipientList, error)
+	DeleteRecipientList(ctx context.Context, listID string) error
+}
+
+// RecipientListService управляет сохранёнными списками получателей. Уведомления
+// ссылаются на списки по ID и раскрывают их в момент отправки.
+type RecipientListService struct {
+	repo RecipientListRepository
+}
+
+func NewRecipientListService(repo RecipientListRepository) *RecipientListService {
+	return &RecipientListService{repo: repo}
+}
+
+func (s *RecipientListService) CreateRecipientList(ctx context.Context, list entity.RecipientList) (entity.RecipientList, error) {
+	created, err := s.repo.CreateRecipientList(ctx, list)
+	if err != nil {
+		return entity.RecipientList{}, fmt.Errorf("CreateRecipientList: %w", err)
+	}
+	return created, nil
+}
+
+func (s *RecipientListService) GetRecipientList(ctx context.Context, listID string) (entity.RecipientList, error) {
+	list, err := s.repo.GetRecipientList(ctx, listID)
+	if err != nil {
+		return entity.RecipientList{}, fmt.Errorf("GetRecipientList: %w", err)
+	}
+	return list, nil
+}
+
+func (s *RecipientListService) ListRecipientLists(ctx context.Context) ([]entity.RecipientList, error) {
+	lists, err := s.repo.ListRecipientLists(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ListRecipientLists: %w", err)
+	}
+	return lists, nil
+}
+
+// UpdateRecipientList заменяет название и участников списка. Уже начатые отправки
+// продолжают использовать состав списка на момент первой попытки.
+func (s *RecipientListService) UpdateRecipientList(ctx context.Context, list entity.RecipientList) (entity.RecipientList, error) {
+	updated, err := s.repo.UpdateRecipientList(ctx, list)
+	if err != nil {
+		return entity.RecipientList{}, fmt.Errorf("UpdateRecipientList: %w", err)
+	}
+	return updated, nil
+}
+
+func (s *RecipientListService) DeleteRecipientList(ctx context.Context, listID string) error {
+	if err := s.repo.DeleteRecipientList(ctx, listID); err != nil {
+		return fmt.Errorf("DeleteRecipientList: %w", err)
+	}
+	return nil
+}
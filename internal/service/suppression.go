@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"delayed-notifier/internal/entity"
+)
+
+// SuppressionService показывает и очищает список подавления. Адреса попадают в него
+// сами, когда сервер получателя окончательно отклоняет письмо.
+type SuppressionService struct {
+	repo SuppressionRepository
+}
+
+func NewSuppressionService(repo SuppressionRepository) *SuppressionService {
+	return &SuppressionService{repo: repo}
+}
+
+func (s *SuppressionService) ListSuppressions(ctx context.Context, filter entity.SuppressionFilter) ([]entity.Suppression, error) {
+	suppressions, err := s.repo.ListSuppressions(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("ListSuppressions: %w", err)
+	}
+	return suppressions, nil
+}
+
+func (s *SuppressionService) GetSuppression(ctx context.Context, email string) (entity.Suppression, error) {
+	suppression, err := s.repo.GetSuppression(ctx, email)
+	if err != nil {
+		return entity.Suppression{}, fmt.Errorf("GetSuppression: %w", err)
+	}
+	return suppression, nil
+}
+
+// DeleteSuppression убирает адрес из списка; уже пропущенные доставки не повторяются,
+// их можно отправить заново через resend.
+func (s *SuppressionService) DeleteSuppression(ctx context.Context, email string) error {
+	if err := s.repo.DeleteSuppression(ctx, email); err != nil {
+		return fmt.Errorf("DeleteSuppression: %w", err)
+	}
+	return nil
+}
+
+// checkSuppressions возвращает ошибки по полям для адресов уведомления из списка подавления.
+func (s *NotifyService) checkSuppressions(ctx context.Context, notify entity.Notify) (entity.ValidationError, error) {
+	if s.suppressions == nil {
+		return nil, nil
+	}
+	fields := make(map[string]string)
+	var emails []string
+	add := func(field string, addrs []string) {
+		for i, addr := range addrs {
+			key := strings.ToLower(addr)
+			if _, ok := fields[key]; !ok {
+				fields[key] = fmt.Sprintf("%s.%d", field, i)
+				emails = append(emails, addr)
+			}
+		}
+	}
+	add("to", notify.To)
+	add("cc", notify.Cc)
+	add("bcc", notify.Bcc)
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
+	suppressions, err := s.suppressions.FindSuppressions(ctx, emails)
+	if err != nil {
+		return nil, fmt.Errorf("find suppressions: %w", err)
+	}
+	var errs entity.ValidationError
+	for _, suppression := range suppressions {
+		errs = append(errs, entity.FieldError{
+			Field:   fields[strings.ToLower(suppression.Email)],
+			Message: "recipient " + suppression.Email + " is suppressed",
+		})
+	}
+	return errs, nil
+}
+
+// suppressedSet возвращает адреса доставок из списка подавления (в нижнем регистре).
+func (s *NotifyService) suppressedSet(ctx context.Context, deliveries []entity.Delivery) (map[string]bool, error) {
+	if s.suppressions == nil {
+		return nil, nil
+	}
+	emails := make([]string, 0, len(deliveries))
+	for _, d := range deliveries {
+		if d.Status == entity.DeliveryPending || d.Status == entity.StatusFailed {
+			emails = append(emails, d.Email)
+		}
+	}
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
+	suppressions, err := s.suppressions.FindSuppressions(ctx, emails)
+	if err != nil {
+		return nil, fmt.Errorf("find suppressions: %w", err)
+	}
+	set := make(map[string]bool, len(suppressions))
+	for _, suppression := range suppressions {
+		set[strings.ToLower(suppression.Email)] = true
+	}
+	return set, nil
+}
+
+// suppress добавляет адрес с постоянным отказом сервера в список подавления.
+func (s *NotifyService) suppress(ctx context.Context, notifyID, email string, sendErr *entity.SendError) {
+	if s.suppressions == nil || !sendErr.Bounce {
+		return
+	}
+	if err := s.suppressions.AddSuppression(ctx, entity.Suppression{
+		Email:    email,
+		Reason:   entity.SuppressionHardBounce,
+		Code:     sendErr.Code,
+		Details:  sendErr.Error(),
+		NotifyID: notifyID,
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "failed to add suppression", slog.String("notify_id", notifyID), slog.String("email", email), slog.Any("error", err))
+		return
+	}
+	s.logger.InfoContext(ctx, "recipient suppressed after hard bounce", slog.String("notify_id", notifyID), slog.String("email", email), slog.Int("code", sendErr.Code))
+}
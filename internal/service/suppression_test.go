@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"delayed-notifier/internal/entity"
+	mock_email "delayed-notifier/internal/repository/email/mocks"
+	mock_db "delayed-notifier/internal/repository/postgres/mocks"
+	mock_cache "delayed-notifier/internal/repository/redis/mocks"
+)
+
+func setupSuppressionService(t *testing.T) (context.Context, *mock_db.NotifyDBRepository, *mock_db.SuppressionRepository, *mock_email.Notifier, *NotifyService) {
+	t.Helper()
+
+	db := new(mock_db.NotifyDBRepository)
+	cache := new(mock_cache.NotifyCacheRepository)
+	cache.On("SetNotify", mock.Anything, mock.Anything).Return(nil).Maybe()
+	suppressions := new(mock_db.SuppressionRepository)
+	notifier := new(mock_email.Notifier)
+	s := NewNotifyService(db, cache, nil, notifier, slog.New(slog.NewTextHandler(io.Discard, nil)),
+		WithSuppressions(suppressions))
+	return context.Background(), db, suppressions, notifier, s
+}
+
+func TestCreateNotifySuppressed(t *testing.T) {
+	ctx, db, suppressions, _, s := setupSuppressionService(t)
+
+	n := entity.Notify{Message: "hi", Email: "a@example.com", To: []string{"a@example.com"}, Cc: []string{"B@example.com"}, Bcc: []string{"a@example.com"}}
+	suppressions.On("FindSuppressions", ctx, []string{"a@example.com", "B@example.com"}).
+		Return([]entity.Suppression{{Email: "b@example.com", Reason: entity.SuppressionHardBounce}}, nil).Once()
+
+	_, err := s.CreateNotify(ctx, n)
+
+	var verr entity.ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.Equal(t, entity.ValidationError{{Field: "cc.0", Message: "recipient b@example.com is suppressed"}}, verr)
+	db.AssertNotCalled(t, "CreateNotify", mock.Anything, mock.Anything)
+}
+
+func TestProcessNotifySuppressions(t *testing.T) {
+	n := entity.Notify{ID: "id1", Email: "a@example.com", To: []string{"a@example.com"}}
+	bounce := &entity.SendError{Code: 550, EnhancedCode: "5.1.1", Permanent: true, Bounce: true, Err: errors.New("550 5.1.1 no such user")}
+
+	t.Run("hard bounce suppresses recipient", func(t *testing.T) {
+		ctx, db, suppressions, notifier, s := setupSuppressionService(t)
+		db.On("ClaimSending", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusSending}, true, nil).Once()
+
+		db.On("GetDeliveries", ctx, n.ID).Return([]entity.Delivery{
+			{NotifyID: "id1", Email: "a@example.com", Kind: entity.RecipientTo, Status: entity.DeliveryPending},
+		}, nil).Once()
+		suppressions.On("FindSuppressions", ctx, []string{"a@example.com"}).Return([]entity.Suppression{}, nil).Once()
+		notifier.On("Send", ctx, n, "a@example.com").Return(bounce).Once()
+		suppressions.On("AddSuppression", ctx, entity.Suppression{
+			Email:    "a@example.com",
+			Reason:   entity.SuppressionHardBounce,
+			Code:     550,
+			Details:  "550 5.1.1 no such user",
+			NotifyID: "id1",
+		}).Return(nil).Once()
+		db.On("AddNotifyEvent", ctx, mock.Anything).Return(nil).Once()
+		db.On("UpdateDelivery", ctx, n.ID, "a@example.com", entity.DeliveryBounced, bounce.Error()).Return(nil).Once()
+		db.On("UpdateNotifyStatus", ctx, n.ID, entity.StatusFailed).Return(entity.Notify{ID: "id1"}, nil).Once()
+
+		err := s.ProcessNotify(ctx, n)
+
+		assert.ErrorIs(t, err, entity.ErrPermanentFailure)
+		db.AssertExpectations(t)
+		suppressions.AssertExpectations(t)
+	})
+
+	t.Run("permanent failure without bounce is not suppressed", func(t *testing.T) {
+		ctx, db, suppressions, notifier, s := setupSuppressionService(t)
+		db.On("ClaimSending", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusSending}, true, nil).Once()
+
+		rejected := &entity.SendError{Code: 554, EnhancedCode: "5.7.1", Permanent: true, Err: errors.New("554 5.7.1 spam")}
+		db.On("GetDeliveries", ctx, n.ID).Return([]entity.Delivery{
+			{NotifyID: "id1", Email: "a@example.com", Kind: entity.RecipientTo, Status: entity.DeliveryPending},
+		}, nil).Once()
+		suppressions.On("FindSuppressions", ctx, []string{"a@example.com"}).Return([]entity.Suppression{}, nil).Once()
+		notifier.On("Send", ctx, n, "a@example.com").Return(rejected).Once()
+		db.On("AddNotifyEvent", ctx, mock.Anything).Return(nil).Once()
+		db.On("UpdateDelivery", ctx, n.ID, "a@example.com", entity.DeliveryBounced, rejected.Error()).Return(nil).Once()
+		db.On("UpdateNotifyStatus", ctx, n.ID, entity.StatusFailed).Return(entity.Notify{ID: "id1"}, nil).Once()
+
+		assert.ErrorIs(t, s.ProcessNotify(ctx, n), entity.ErrPermanentFailure)
+		suppressions.AssertNotCalled(t, "AddSuppression", mock.Anything, mock.Anything)
+	})
+
+	t.Run("transient failure is retried", func(t *testing.T) {
+		ctx, db, suppressions, notifier, s := setupSuppressionService(t)
+		db.On("ClaimSending", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusSending}, true, nil).Once()
+
+		busy := &entity.SendError{Code: 451, Err: errors.New("451 try again later")}
+		db.On("GetDeliveries", ctx, n.ID).Return([]entity.Delivery{
+			{NotifyID: "id1", Email: "a@example.com", Kind: entity.RecipientTo, Status: entity.DeliveryPending},
+		}, nil).Once()
+		suppressions.On("FindSuppressions", ctx, []string{"a@example.com"}).Return([]entity.Suppression{}, nil).Once()
+		notifier.On("Send", ctx, n, "a@example.com").Return(busy).Once()
+		db.On("AddNotifyEvent", ctx, mock.Anything).Return(nil).Once()
+		db.On("UpdateDelivery", ctx, n.ID, "a@example.com", entity.StatusFailed, busy.Error()).Return(nil).Once()
+		db.On("UpdateNotifyStatus", ctx, n.ID, entity.StatusFailed).Return(entity.Notify{ID: "id1"}, nil).Once()
+
+		err := s.ProcessNotify(ctx, n)
+
+		assert.ErrorIs(t, err, busy)
+		assert.NotErrorIs(t, err, entity.ErrPermanentFailure)
+	})
+
+	t.Run("suppressed list member skipped", func(t *testing.T) {
+		ctx, db, suppressions, notifier, s := setupSuppressionService(t)
+		db.On("ClaimSending", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusSending}, true, nil).Once()
+
+		db.On("GetDeliveries", ctx, n.ID).Return([]entity.Delivery{
+			{NotifyID: "id1", Email: "a@example.com", Kind: entity.RecipientTo, Status: entity.DeliveryPending},
+			{NotifyID: "id1", Email: "C@example.com", Kind: entity.RecipientBcc, ListID: "l1", Status: entity.DeliveryPending},
+		}, nil).Once()
+		suppressions.On("FindSuppressions", ctx, []string{"a@example.com", "C@example.com"}).
+			Return([]entity.Suppression{{Email: "c@example.com"}}, nil).Once()
+		notifier.On("Send", ctx, n, "a@example.com").Return(nil).Once()
+		db.On("UpdateDelivery", ctx, n.ID, "a@example.com", entity.StatusSent, "").Return(nil).Once()
+		db.On("AddNotifyEvent", ctx, entity.NotifyEvent{NotifyID: "id1", Action: entity.EventSuppressed, Details: "C@example.com"}).Return(nil).Once()
+		db.On("UpdateDelivery", ctx, n.ID, "C@example.com", entity.DeliverySuppressed, "recipient is suppressed").Return(nil).Once()
+		db.On("UpdateNotifyStatus", ctx, n.ID, entity.StatusSent).Return(entity.Notify{ID: "id1"}, nil).Once()
+
+		assert.NoError(t, s.ProcessNotify(ctx, n))
+		db.AssertExpectations(t)
+		notifier.AssertExpectations(t)
+	})
+
+	t.Run("all recipients suppressed", func(t *testing.T) {
+		ctx, db, suppressions, notifier, s := setupSuppressionService(t)
+		db.On("ClaimSending", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusSending}, true, nil).Once()
+
+		db.On("GetDeliveries", ctx, n.ID).Return([]entity.Delivery{
+			{NotifyID: "id1", Email: "a@example.com", Kind: entity.RecipientTo, Status: entity.DeliveryPending},
+		}, nil).Once()
+		suppressions.On("FindSuppressions", ctx, []string{"a@example.com"}).Return([]entity.Suppression{{Email: "a@example.com"}}, nil).Once()
+		db.On("AddNotifyEvent", ctx, mock.Anything).Return(nil).Once()
+		db.On("UpdateDelivery", ctx, n.ID, "a@example.com", entity.DeliverySuppressed, "recipient is suppressed").Return(nil).Once()
+		db.On("UpdateNotifyStatus", ctx, n.ID, entity.StatusSkipped).Return(entity.Notify{ID: "id1"}, nil).Once()
+
+		assert.NoError(t, s.ProcessNotify(ctx, n))
+		db.AssertExpectations(t)
+		notifier.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("earlier bounce keeps notify failed", func(t *testing.T) {
+		ctx, db, suppressions, notifier, s := setupSuppressionService(t)
+		db.On("ClaimSending", ctx, "id1").Return(entity.Notify{ID: "id1", Status: entity.StatusSending}, true, nil).Once()
+
+		db.On("GetDeliveries", ctx, n.ID).Return([]entity.Delivery{
+			{NotifyID: "id1", Email: "a@example.com", Kind: entity.RecipientTo, Status: entity.DeliveryBounced, LastError: "550 no such user"},
+			{NotifyID: "id1", Email: "b@example.com", Kind: entity.RecipientTo, Status: entity.StatusFailed},
+		}, nil).Once()
+		suppressions.On("FindSuppressions", ctx, []string{"b@example.com"}).Return([]entity.Suppression{}, nil).Once()
+		notifier.On("Send", ctx, n, "b@example.com").Return(nil).Once()
+		db.On("UpdateDelivery", ctx, n.ID, "b@example.com", entity.StatusSent, "").Return(nil).Once()
+		db.On("UpdateNotifyStatus", ctx, n.ID, entity.StatusFailed).Return(entity.Notify{ID: "id1"}, nil).Once()
+
+		err := s.ProcessNotify(ctx, n)
+
+		assert.ErrorIs(t, err, entity.ErrPermanentFailure)
+		assert.ErrorContains(t, err, "a@example.com: 550 no such user")
+		db.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,177 @@
+package timingwheel
+
+import (
+	"sync"
+	"time"
+)
+
+type slotRef struct {
+	level int
+	slot  int64
+	at    time.Time
+}
+
+// Wheel — иерархическое колесо таймеров. Уровень 0 делится на слоты длиной tick,
+// каждый следующий уровень — на слоты длиной size слотов предыдущего. При переходе
+// через границу слота верхнего уровня его элементы перекладываются на нижние уровни.
+// Время срабатывания округляется вверх до tick, поэтому элемент никогда не
+// срабатывает раньше заданного момента.
+type Wheel struct {
+	mu      sync.Mutex
+	tick    time.Duration
+	size    int64
+	levels  [][]map[string]time.Time
+	current time.Time
+	entries map[string]slotRef
+}
+
+func New(tick time.Duration, size, levels int, now time.Time) *Wheel {
+	w := &Wheel{
+		tick:    tick,
+		size:    int64(size),
+		levels:  make([][]map[string]time.Time, levels),
+		current: now.Truncate(tick),
+		entries: make(map[string]slotRef),
+	}
+	for l := range w.levels {
+		w.levels[l] = make([]map[string]time.Time, size)
+		for s := range w.levels[l] {
+			w.levels[l][s] = make(map[string]time.Time)
+		}
+	}
+	return w
+}
+
+// Horizon — максимальное расстояние от текущего момента, которое помещается в колесо.
+func (w *Wheel) Horizon() time.Duration {
+	return w.levelTick(len(w.levels)-1) * time.Duration(w.size-1)
+}
+
+// Add кладёт (или переносит) элемент id на момент at. Возвращает due=true, если момент
+// уже наступил, и ok=false, если он за горизонтом колеса; в обоих случаях элемент
+// в колесо не попадает.
+func (w *Wheel) Add(id string, at time.Time) (due, ok bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.remove(id)
+	return w.place(id, at)
+}
+
+func (w *Wheel) Remove(id string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.remove(id)
+}
+
+func (w *Wheel) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return len(w.entries)
+}
+
+// Advance сдвигает колесо до момента now и возвращает ID элементов, время которых наступило.
+func (w *Wheel) Advance(now time.Time) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	target := now.Truncate(w.tick)
+	var due []string
+
+	// После простоя дольше горизонта проще разложить все элементы заново
+	if target.Sub(w.current) > w.Horizon() {
+		entries := w.entries
+		w.entries = make(map[string]slotRef, len(entries))
+		for l := range w.levels {
+			for s := range w.levels[l] {
+				w.levels[l][s] = make(map[string]time.Time)
+			}
+		}
+		w.current = target
+		for id, ref := range entries {
+			if isDue, _ := w.place(id, ref.at); isDue {
+				due = append(due, id)
+			}
+		}
+		return due
+	}
+
+	for w.current.Before(target) {
+		w.current = w.current.Add(w.tick)
+
+		// Сначала перекладываем верхние уровни, затем срабатывает слот нижнего
+		for l := len(w.levels) - 1; l >= 1; l-- {
+			lt := w.levelTick(l)
+			if w.current.UnixNano()%int64(lt) != 0 {
+				continue
+			}
+			slot := w.slotIndex(l, w.current)
+			bucket := w.levels[l][slot]
+			w.levels[l][slot] = make(map[string]time.Time)
+			for id, at := range bucket {
+				delete(w.entries, id)
+				if isDue, _ := w.place(id, at); isDue {
+					due = append(due, id)
+				}
+			}
+		}
+
+		slot := w.slotIndex(0, w.current)
+		for id := range w.levels[0][slot] {
+			due = append(due, id)
+			delete(w.entries, id)
+		}
+		w.levels[0][slot] = make(map[string]time.Time)
+	}
+
+	return due
+}
+
+func (w *Wheel) place(id string, at time.Time) (due, ok bool) {
+	at = ceilTo(at, w.tick)
+	if !at.After(w.current) {
+		return true, false
+	}
+
+	for l := range w.levels {
+		lt := int64(w.levelTick(l))
+		if at.UnixNano()/lt-w.current.UnixNano()/lt < w.size {
+			slot := w.slotIndex(l, at)
+			w.levels[l][slot][id] = at
+			w.entries[id] = slotRef{level: l, slot: slot, at: at}
+			return false, true
+		}
+	}
+	return false, false
+}
+
+func (w *Wheel) remove(id string) {
+	ref, ok := w.entries[id]
+	if !ok {
+		return
+	}
+	delete(w.levels[ref.level][ref.slot], id)
+	delete(w.entries, id)
+}
+
+func (w *Wheel) levelTick(level int) time.Duration {
+	lt := w.tick
+	for i := 0; i < level; i++ {
+		lt *= time.Duration(w.size)
+	}
+	return lt
+}
+
+func (w *Wheel) slotIndex(level int, at time.Time) int64 {
+	return (at.UnixNano() / int64(w.levelTick(level))) % w.size
+}
+
+func ceilTo(t time.Time, d time.Duration) time.Time {
+	truncated := t.Truncate(d)
+	if truncated.Equal(t) {
+		return t
+	}
+	return truncated.Add(d)
+}
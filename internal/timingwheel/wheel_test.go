@@ -0,0 +1,92 @@
+package timingwheel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWheel(t *testing.T) {
+	start := time.Date(2025, 10, 25, 10, 0, 0, 0, time.UTC)
+
+	t.Run("fires at exact tick", func(t *testing.T) {
+		w := New(time.Second, 60, 2, start)
+
+		due, ok := w.Add("a", start.Add(3*time.Second))
+		assert.False(t, due)
+		assert.True(t, ok)
+
+		assert.Empty(t, w.Advance(start.Add(2*time.Second)))
+		assert.Equal(t, []string{"a"}, w.Advance(start.Add(3*time.Second)))
+		assert.Equal(t, 0, w.Len())
+	})
+
+	t.Run("never fires early", func(t *testing.T) {
+		w := New(time.Second, 60, 2, start)
+
+		w.Add("a", start.Add(2500*time.Millisecond))
+
+		assert.Empty(t, w.Advance(start.Add(2*time.Second)))
+		assert.Equal(t, []string{"a"}, w.Advance(start.Add(3*time.Second)))
+	})
+
+	t.Run("cascades from upper level", func(t *testing.T) {
+		w := New(time.Second, 60, 2, start)
+
+		w.Add("a", start.Add(3*time.Minute+7*time.Second))
+
+		assert.Empty(t, w.Advance(start.Add(3*time.Minute+6*time.Second)))
+		assert.Equal(t, 1, w.Len())
+		assert.Equal(t, []string{"a"}, w.Advance(start.Add(3*time.Minute+7*time.Second)))
+	})
+
+	t.Run("fires on slot boundary of upper level", func(t *testing.T) {
+		w := New(time.Second, 60, 2, start)
+
+		w.Add("a", start.Add(2*time.Minute))
+
+		assert.Empty(t, w.Advance(start.Add(2*time.Minute-time.Second)))
+		assert.Equal(t, []string{"a"}, w.Advance(start.Add(2*time.Minute)))
+	})
+
+	t.Run("due and beyond horizon", func(t *testing.T) {
+		w := New(time.Second, 60, 2, start)
+
+		due, ok := w.Add("past", start.Add(-time.Second))
+		assert.True(t, due)
+		assert.False(t, ok)
+
+		due, ok = w.Add("far", start.Add(2*time.Hour))
+		assert.False(t, due)
+		assert.False(t, ok)
+		assert.Equal(t, 0, w.Len())
+	})
+
+	t.Run("reschedule and remove", func(t *testing.T) {
+		w := New(time.Second, 60, 2, start)
+
+		w.Add("a", start.Add(5*time.Second))
+		w.Add("a", start.Add(10*time.Second))
+		w.Add("b", start.Add(5*time.Second))
+		w.Remove("b")
+
+		assert.Empty(t, w.Advance(start.Add(9*time.Second)))
+		assert.Equal(t, []string{"a"}, w.Advance(start.Add(10*time.Second)))
+	})
+
+	t.Run("catches up after long pause", func(t *testing.T) {
+		w := New(time.Second, 60, 2, start)
+
+		w.Add("a", start.Add(30*time.Second))
+		w.Add("b", start.Add(58*time.Minute))
+
+		assert.ElementsMatch(t, []string{"a", "b"}, w.Advance(start.Add(2*time.Hour)))
+		assert.Equal(t, 0, w.Len())
+
+		due, ok := w.Add("c", start.Add(2*time.Hour+time.Second))
+		assert.False(t, due)
+		assert.True(t, ok)
+		assert.Equal(t, []string{"c"}, w.Advance(start.Add(2*time.Hour+time.Second)))
+	})
+}
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION notify_scheduled_event() RETURNS trigger AS $$
+BEGIN
+    IF NEW.status = 'scheduled' THEN
+        PERFORM pg_notify('notify_scheduled', json_build_object('id', NEW.id, 'send_at', NEW.send_at)::text);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER notify_scheduled_trigger
+AFTER INSERT OR UPDATE OF send_at, status ON notify
+FOR EACH ROW EXECUTE FUNCTION notify_scheduled_event();
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX notify_status_send_at_idx ON notify (status, send_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS notify_status_send_at_idx;
+DROP TRIGGER IF EXISTS notify_scheduled_trigger ON notify;
+DROP FUNCTION IF EXISTS notify_scheduled_event();
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE notify
+    ADD COLUMN queued_at TIMESTAMPTZ,
+    ADD COLUMN reap_count INT NOT NULL DEFAULT 0;
+
+UPDATE notify SET queued_at = NOW() WHERE status = 'queued';
+
+CREATE INDEX notify_status_queued_at_idx ON notify (status, queued_at);
+
+CREATE TABLE notify_events (
+    id BIGSERIAL PRIMARY KEY,
+    notify_id UUID NOT NULL REFERENCES notify (id) ON DELETE CASCADE,
+    action TEXT NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX notify_events_notify_id_idx ON notify_events (notify_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS notify_events;
+DROP INDEX IF EXISTS notify_status_queued_at_idx;
+ALTER TABLE notify
+    DROP COLUMN IF EXISTS reap_count,
+    DROP COLUMN IF EXISTS queued_at;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE notify ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+CREATE OR REPLACE FUNCTION notify_bump_version() RETURNS trigger AS $$
+BEGIN
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER notify_version_trigger
+BEFORE UPDATE ON notify
+FOR EACH ROW EXECUTE FUNCTION notify_bump_version();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS notify_version_trigger ON notify;
+DROP FUNCTION IF EXISTS notify_bump_version();
+ALTER TABLE notify DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
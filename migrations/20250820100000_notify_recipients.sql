@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE notify
+    ADD COLUMN to_emails TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN cc_emails TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN bcc_emails TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN recipient_lists TEXT[] NOT NULL DEFAULT '{}';
+
+UPDATE notify SET to_emails = ARRAY[email] WHERE email <> '';
+
+CREATE TABLE recipient_lists (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    members TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Доставки не ссылаются на recipient_lists: удаление списка не должно стирать историю отправок
+CREATE TABLE notify_deliveries (
+    notify_id UUID NOT NULL REFERENCES notify (id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    list_id TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    position INT NOT NULL,
+    PRIMARY KEY (notify_id, email)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS notify_deliveries;
+DROP TABLE IF EXISTS recipient_lists;
+ALTER TABLE notify
+    DROP COLUMN IF EXISTS recipient_lists,
+    DROP COLUMN IF EXISTS bcc_emails,
+    DROP COLUMN IF EXISTS cc_emails,
+    DROP COLUMN IF EXISTS to_emails;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE notify
+    ADD COLUMN subject TEXT NOT NULL DEFAULT '',
+    ADD COLUMN html TEXT NOT NULL DEFAULT '',
+    ADD COLUMN reply_to TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN headers JSONB NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE notify
+    DROP COLUMN IF EXISTS subject,
+    DROP COLUMN IF EXISTS html,
+    DROP COLUMN IF EXISTS reply_to,
+    DROP COLUMN IF EXISTS headers;
+-- +goose StatementEnd
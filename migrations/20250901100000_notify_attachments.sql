@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Содержимое вложений лежит в хранилище файлов, здесь только описания
+ALTER TABLE notify
+    ADD COLUMN attachments JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN attachments_purged_at TIMESTAMPTZ;
+
+CREATE INDEX notify_attachments_retention_idx ON notify (send_at)
+    WHERE attachments <> '[]' AND attachments_purged_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS notify_attachments_retention_idx;
+ALTER TABLE notify
+    DROP COLUMN IF EXISTS attachments,
+    DROP COLUMN IF EXISTS attachments_purged_at;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Имя отправителя из конфигурации мейлера; пустое — отправитель по умолчанию
+ALTER TABLE notify ADD COLUMN sender TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE notify DROP COLUMN IF EXISTS sender;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Список подавления: адреса, на которые письма не отправляются. email хранится в нижнем регистре
+CREATE TABLE suppressions (
+    email TEXT PRIMARY KEY,
+    reason TEXT NOT NULL,
+    code INT NOT NULL DEFAULT 0,
+    details TEXT NOT NULL DEFAULT '',
+    notify_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS suppressions;
+-- +goose StatementEnd
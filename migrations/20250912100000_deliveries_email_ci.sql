@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Адреса доставок сравниваются без учёта регистра, как в списке подавления.
+-- Дубли, различающиеся только регистром, схлопываются в первую по порядку доставку
+DELETE FROM notify_deliveries d
+USING notify_deliveries o
+WHERE d.notify_id = o.notify_id
+  AND lower(d.email) = lower(o.email)
+  AND d.position > o.position;
+
+CREATE UNIQUE INDEX notify_deliveries_email_ci ON notify_deliveries (notify_id, lower(email));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS notify_deliveries_email_ci;
+-- +goose StatementEnd